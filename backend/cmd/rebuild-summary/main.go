@@ -0,0 +1,32 @@
+// daily_exercise_summary を workout_sets から作り直すコマンド
+// 使い方: rebuild-summary [-user <ユーザーID>]（省略時は全ユーザー）
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/training-memo/backend/internal/database"
+	"github.com/training-memo/backend/internal/repository"
+)
+
+func main() {
+	userID := flag.Uint64("user", 0, "作り直すユーザーのID（0の場合は全ユーザー）")
+	flag.Parse()
+
+	db, err := database.Connect()
+	if err != nil {
+		log.Fatalf("Database connection failed: %v", err)
+	}
+
+	workoutRepo := repository.NewWorkoutRepository(db)
+	if err := workoutRepo.RebuildDailySummaries(*userID); err != nil {
+		log.Fatalf("Rebuilding daily exercise summary failed: %v", err)
+	}
+
+	if *userID == 0 {
+		log.Printf("Rebuilt daily exercise summary for all users")
+	} else {
+		log.Printf("Rebuilt daily exercise summary for user %d", *userID)
+	}
+}
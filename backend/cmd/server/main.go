@@ -3,20 +3,19 @@
 package main
 
 import (
-	"fmt"
 	"log"
 	"net/http"
 	"os"
 
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
 
+	"github.com/training-memo/backend/internal/database"
 	"github.com/training-memo/backend/internal/handler"
 	"github.com/training-memo/backend/internal/middleware"
 	"github.com/training-memo/backend/internal/repository"
 	"github.com/training-memo/backend/internal/service"
+	"github.com/training-memo/backend/internal/storage"
 )
 
 var dbError error
@@ -24,14 +23,18 @@ var dbError error
 func main() {
 	// Echo インスタンスの作成
 	e := echo.New()
+	e.Validator = handler.NewValidator()
+	e.HTTPErrorHandler = handler.ErrorHandler
 
 	// ミドルウェアの設定
+	e.Use(echoMiddleware.RequestID())
 	e.Use(echoMiddleware.Logger())
 	e.Use(echoMiddleware.Recover())
 	e.Use(echoMiddleware.CORSWithConfig(echoMiddleware.CORSConfig{
-		AllowOrigins: []string{"http://localhost:3000", "http://localhost:3001", "https://*.pages.dev"},
-		AllowMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowOrigins:  []string{"http://localhost:3000", "http://localhost:3001", "https://*.pages.dev"},
+		AllowMethods:  []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		AllowHeaders:  []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		ExposeHeaders: []string{echo.HeaderXRequestID},
 	}))
 
 	// ヘルスチェックエンドポイント（DB接続前に登録）
@@ -48,7 +51,7 @@ func main() {
 	})
 
 	// データベース接続
-	db, err := connectDB()
+	db, err := database.Connect()
 	if err != nil {
 		log.Printf("WARNING: Failed to connect to database: %v", err)
 		dbError = err
@@ -61,19 +64,59 @@ func main() {
 		workoutRepo := repository.NewWorkoutRepository(db)
 		menuRepo := repository.NewMenuRepository(db)
 		bodyWeightRepo := repository.NewBodyWeightRepository(db)
+		personalRecordRepo := repository.NewPersonalRecordRepository(db)
+		goalRepo := repository.NewGoalRepository(db)
+		achievementRepo := repository.NewAchievementRepository(db)
+
+		// 種目の画像・動画の保存先
+		// 保存先を用意できない場合も他の機能は使えるよう、画像・動画の機能だけを止めて起動する
+		mediaDir := os.Getenv("MEDIA_DIR")
+		if mediaDir == "" {
+			mediaDir = "media"
+		}
+		var mediaStorage service.MediaStorage
+		if localStorage, err := storage.NewLocalStorage(mediaDir); err != nil {
+			log.Printf("WARNING: Failed to initialize media storage, media is disabled: %v", err)
+		} else {
+			mediaStorage = localStorage
+		}
 
 		// サービスの初期化
-		authService := service.NewAuthService(userRepo)
-		workoutService := service.NewWorkoutService(workoutRepo, exerciseRepo)
-		menuService := service.NewMenuService(menuRepo, exerciseRepo)
+		authService := service.NewAuthService(userRepo, mediaStorage)
+		recordTracker := service.NewPersonalRecordTracker(workoutRepo, personalRecordRepo)
+		achievementService := service.NewAchievementService(achievementRepo, workoutRepo, exerciseRepo, bodyWeightRepo, service.DefaultAchievementRules)
+		goalService := service.NewGoalService(goalRepo, workoutRepo, exerciseRepo, bodyWeightRepo)
+		workoutService := service.NewWorkoutService(workoutRepo, exerciseRepo, recordTracker, achievementService, goalService, mediaStorage)
+		targetResolver := service.NewTargetWeightResolver(workoutRepo)
+		menuService := service.NewMenuService(menuRepo, exerciseRepo, targetResolver)
 		aiMenuService := service.NewAIMenuService(exerciseRepo)
-		bodyWeightService := service.NewBodyWeightService(bodyWeightRepo)
+		bodyWeightService := service.NewBodyWeightService(bodyWeightRepo, achievementService, goalService)
+		reportService := service.NewReportService(workoutRepo, personalRecordRepo, bodyWeightRepo)
+		plateauRules, err := service.LoadPlateauRules(os.Getenv("PLATEAU_RULES_FILE"))
+		if err != nil {
+			log.Printf("WARNING: Failed to load plateau rules, using defaults: %v", err)
+			plateauRules = service.DefaultPlateauRules
+		}
+		statsService := service.NewStatsService(workoutRepo, plateauRules)
+		strengthStandards, err := service.LoadStrengthStandards(os.Getenv("STRENGTH_STANDARDS_FILE"))
+		if err != nil {
+			log.Printf("WARNING: Failed to load strength standards, using defaults: %v", err)
+			strengthStandards = service.DefaultStrengthStandards
+		}
+		strengthService := service.NewStrengthService(workoutRepo, exerciseRepo, bodyWeightRepo, strengthStandards)
+		exerciseContentService := service.NewExerciseContentService(exerciseRepo, mediaStorage)
+		localizer := service.NewLocalizer(exerciseRepo, userRepo)
 
 		// ハンドラーの初期化
 		authHandler := handler.NewAuthHandler(authService)
-		workoutHandler := handler.NewWorkoutHandler(workoutService)
-		menuHandler := handler.NewMenuHandler(menuService, aiMenuService)
+		workoutHandler := handler.NewWorkoutHandler(workoutService, localizer)
+		exerciseHandler := handler.NewExerciseHandler(exerciseContentService, localizer)
+		menuHandler := handler.NewMenuHandler(menuService, aiMenuService, localizer)
 		bodyWeightHandler := handler.NewBodyWeightHandler(bodyWeightService)
+		goalHandler := handler.NewGoalHandler(goalService, localizer)
+		achievementHandler := handler.NewAchievementHandler(achievementService)
+		reportHandler := handler.NewReportHandler(reportService, localizer)
+		statsHandler := handler.NewStatsHandler(statsService, strengthService, localizer)
 
 		// API v1 グループ
 		v1 := e.Group("/api/v1")
@@ -89,14 +132,33 @@ func main() {
 		// ユーザー
 		authGroup.GET("/auth/me", authHandler.Me)
 		authGroup.DELETE("/auth/account", authHandler.DeleteAccount)
+		authGroup.GET("/auth/me/equipment", authHandler.GetEquipment)
+		authGroup.PUT("/auth/me/equipment", authHandler.UpdateEquipment)
+		authGroup.PUT("/auth/me/locale", authHandler.UpdateLocale)
 
 		// 種目
 		authGroup.GET("/exercises", workoutHandler.GetExercises)
+		authGroup.GET("/exercises/search", workoutHandler.SearchExercises)
 		authGroup.GET("/exercises/custom", workoutHandler.GetCustomExercises)
 		authGroup.POST("/exercises/custom", workoutHandler.CreateCustomExercise)
 		authGroup.PUT("/exercises/custom/:id", workoutHandler.UpdateCustomExercise)
 		authGroup.DELETE("/exercises/custom/:id", workoutHandler.DeleteCustomExercise)
+		authGroup.PUT("/exercises/custom/:id/archive", workoutHandler.ArchiveCustomExercise)
+		authGroup.DELETE("/exercises/custom/:id/archive", workoutHandler.UnarchiveCustomExercise)
+		authGroup.POST("/exercises/custom/:id/merge-into/:targetId", workoutHandler.MergeCustomExercise)
+		authGroup.POST("/exercises/custom/:id/media", exerciseHandler.UploadMedia)
+		authGroup.DELETE("/exercises/custom/:id/media/:mediaId", exerciseHandler.DeleteMedia)
+		authGroup.GET("/exercises/:id", exerciseHandler.GetExercise)
+		authGroup.GET("/exercises/:id/media/:mediaId", exerciseHandler.GetMedia)
 		authGroup.GET("/exercises/:id/progress", workoutHandler.GetExerciseProgress)
+		authGroup.PUT("/exercises/:id/favorite", workoutHandler.AddFavorite)
+		authGroup.DELETE("/exercises/:id/favorite", workoutHandler.RemoveFavorite)
+		authGroup.GET("/exercises/:id/aliases", workoutHandler.GetAliases)
+		authGroup.POST("/exercises/:id/aliases", workoutHandler.CreateAlias)
+		authGroup.GET("/exercises/:id/variations", workoutHandler.GetVariations)
+		authGroup.GET("/exercises/:id/progression", workoutHandler.GetProgression)
+		authGroup.DELETE("/exercises/aliases/:aliasId", workoutHandler.DeleteAlias)
+		authGroup.GET("/muscles", workoutHandler.GetMuscles)
 
 		// トレーニング記録
 		authGroup.POST("/workouts", workoutHandler.CreateWorkout)
@@ -109,13 +171,26 @@ func main() {
 
 		// 統計
 		authGroup.GET("/stats/muscle-groups", workoutHandler.GetMuscleGroupStats)
+		authGroup.GET("/stats/muscles", workoutHandler.GetMuscleStats)
 		authGroup.GET("/stats/personal-bests", workoutHandler.GetPersonalBests)
+		authGroup.GET("/stats/personal-records", workoutHandler.GetPersonalRecordHistory)
+		authGroup.GET("/stats/exercises/:id/rep-maxes", workoutHandler.GetRepMaxes)
+		authGroup.GET("/stats/consistency", statsHandler.GetConsistency)
+		authGroup.GET("/stats/load", statsHandler.GetLoad)
+		authGroup.GET("/stats/plateaus", statsHandler.GetPlateaus)
+		authGroup.GET("/stats/strength-scores", statsHandler.GetStrengthScores)
+		authGroup.GET("/stats/compare", reportHandler.Compare)
+
+		// レポート
+		authGroup.GET("/reports/weekly", reportHandler.GetWeeklyReport)
+		authGroup.GET("/reports/monthly", reportHandler.GetMonthlyReport)
 
 		// メニュー管理
 		authGroup.POST("/menus/ai-generate", menuHandler.GenerateMenuWithAI)
 		authGroup.POST("/menus", menuHandler.CreateMenu)
 		authGroup.GET("/menus", menuHandler.GetMenus)
 		authGroup.GET("/menus/:id", menuHandler.GetMenu)
+		authGroup.GET("/menus/:id/workout-plan", menuHandler.GetWorkoutPlan)
 		authGroup.PUT("/menus/:id", menuHandler.UpdateMenu)
 		authGroup.DELETE("/menus/:id", menuHandler.DeleteMenu)
 
@@ -125,6 +200,16 @@ func main() {
 		authGroup.GET("/body-weights/range", bodyWeightHandler.GetRecordsByDateRange)
 		authGroup.GET("/body-weights/latest", bodyWeightHandler.GetLatest)
 		authGroup.DELETE("/body-weights/:id", bodyWeightHandler.Delete)
+
+		// 目標
+		authGroup.POST("/goals", goalHandler.CreateGoal)
+		authGroup.GET("/goals", goalHandler.GetGoals)
+		authGroup.GET("/goals/:id", goalHandler.GetGoal)
+		authGroup.PUT("/goals/:id", goalHandler.UpdateGoal)
+		authGroup.DELETE("/goals/:id", goalHandler.DeleteGoal)
+
+		// 実績
+		authGroup.GET("/achievements", achievementHandler.GetAchievements)
 	}
 
 	// サーバー起動
@@ -136,42 +221,3 @@ func main() {
 	log.Printf("Server starting on port %s", port)
 	e.Logger.Fatal(e.Start(":" + port))
 }
-
-func connectDB() (*gorm.DB, error) {
-	// DATABASE_URL環境変数がある場合はそれを使用（Neon等）
-	databaseURL := os.Getenv("DATABASE_URL")
-	if databaseURL != "" {
-		log.Printf("Connecting to database using DATABASE_URL...")
-		db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{})
-		if err != nil {
-			return nil, fmt.Errorf("failed to connect with DATABASE_URL: %w", err)
-		}
-		return db, nil
-	}
-
-	// 個別の環境変数から接続文字列を構築
-	host := getEnv("DB_HOST", "localhost")
-	port := getEnv("DB_PORT", "5432")
-	user := getEnv("DB_USER", "training_user")
-	password := getEnv("DB_PASSWORD", "training_password")
-	dbname := getEnv("DB_NAME", "training_memo")
-	sslmode := getEnv("DB_SSLMODE", "disable")
-
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		host, port, user, password, dbname, sslmode)
-
-	log.Printf("Connecting to database at %s:%s...", host, port)
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
-	}
-
-	return db, nil
-}
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
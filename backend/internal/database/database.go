@@ -0,0 +1,50 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Connect は環境変数の設定でデータベースに接続する
+func Connect() (*gorm.DB, error) {
+	// DATABASE_URL環境変数がある場合はそれを使用（Neon等）
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL != "" {
+		log.Printf("Connecting to database using DATABASE_URL...")
+		db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect with DATABASE_URL: %w", err)
+		}
+		return db, nil
+	}
+
+	// 個別の環境変数から接続文字列を構築
+	host := getEnv("DB_HOST", "localhost")
+	port := getEnv("DB_PORT", "5432")
+	user := getEnv("DB_USER", "training_user")
+	password := getEnv("DB_PASSWORD", "training_password")
+	dbname := getEnv("DB_NAME", "training_memo")
+	sslmode := getEnv("DB_SSLMODE", "disable")
+
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		host, port, user, password, dbname, sslmode)
+
+	log.Printf("Connecting to database at %s:%s...", host, port)
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return db, nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/training-memo/backend/internal/middleware"
+	"github.com/training-memo/backend/internal/service"
+)
+
+type AchievementHandler struct {
+	achievementService *service.AchievementService
+}
+
+func NewAchievementHandler(achievementService *service.AchievementService) *AchievementHandler {
+	return &AchievementHandler{achievementService: achievementService}
+}
+
+// 実績一覧（未獲得の実績も現在の値とともに返す）
+func (h *AchievementHandler) GetAchievements(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	achievements, err := h.achievementService.GetAchievements(userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, achievements)
+}
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -20,34 +19,16 @@ func NewAuthHandler(authService *service.AuthService) *AuthHandler {
 func (h *AuthHandler) Register(c echo.Context) error {
 	var input service.RegisterInput
 	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid request body",
-		})
+		return errInvalidRequestBody
 	}
 
-	// バリデーション
-	if input.Email == "" || input.Password == "" || input.Name == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "email, password, and name are required",
-		})
-	}
-
-	if len(input.Password) < 8 {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "password must be at least 8 characters",
-		})
+	if err := c.Validate(&input); err != nil {
+		return err
 	}
 
 	response, err := h.authService.Register(&input)
 	if err != nil {
-		if errors.Is(err, service.ErrEmailAlreadyExists) {
-			return c.JSON(http.StatusConflict, map[string]string{
-				"error": "email already exists",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "failed to register user",
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusCreated, response)
@@ -56,27 +37,16 @@ func (h *AuthHandler) Register(c echo.Context) error {
 func (h *AuthHandler) Login(c echo.Context) error {
 	var input service.LoginInput
 	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid request body",
-		})
+		return errInvalidRequestBody
 	}
 
-	if input.Email == "" || input.Password == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "email and password are required",
-		})
+	if err := c.Validate(&input); err != nil {
+		return err
 	}
 
 	response, err := h.authService.Login(&input)
 	if err != nil {
-		if errors.Is(err, service.ErrInvalidCredentials) {
-			return c.JSON(http.StatusUnauthorized, map[string]string{
-				"error": "invalid email or password",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "failed to login",
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, response)
@@ -87,9 +57,7 @@ func (h *AuthHandler) Me(c echo.Context) error {
 
 	user, err := h.authService.GetUserByID(userID)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]string{
-			"error": "user not found",
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, user)
@@ -99,11 +67,53 @@ func (h *AuthHandler) DeleteAccount(c echo.Context) error {
 	userID := middleware.GetUserID(c)
 
 	if err := h.authService.DeleteAccount(userID); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "アカウントの削除に失敗しました",
-		})
+		return err
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+// 使える器具の設定
+func (h *AuthHandler) GetEquipment(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	profile, err := h.authService.GetEquipmentProfile(userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, profile)
+}
+
+func (h *AuthHandler) UpdateEquipment(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	var input service.UpdateEquipmentInput
+	if err := c.Bind(&input); err != nil {
+		return errInvalidRequestBody
+	}
+
+	profile, err := h.authService.UpdateEquipmentProfile(userID, &input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, profile)
+}
+
+// 表示言語の設定（locale に null を指定すると Accept-Language に従う）
+func (h *AuthHandler) UpdateLocale(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	var input service.UpdateLocaleInput
+	if err := c.Bind(&input); err != nil {
+		return errInvalidRequestBody
+	}
+
+	user, err := h.authService.UpdateLocale(userID, &input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, user)
+}
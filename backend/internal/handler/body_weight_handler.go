@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -23,22 +22,16 @@ func (h *BodyWeightHandler) CreateOrUpdate(c echo.Context) error {
 
 	var input service.CreateBodyWeightInput
 	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid request body",
-		})
+		return errInvalidRequestBody
 	}
 
-	if input.Date == "" || input.Weight <= 0 {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "date and weight are required",
-		})
+	if err := c.Validate(&input); err != nil {
+		return err
 	}
 
 	record, err := h.bodyWeightService.CreateOrUpdate(userID, &input)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, record)
@@ -57,9 +50,7 @@ func (h *BodyWeightHandler) GetRecords(c echo.Context) error {
 
 	records, err := h.bodyWeightService.GetRecords(userID, limit)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, records)
@@ -72,16 +63,12 @@ func (h *BodyWeightHandler) GetRecordsByDateRange(c echo.Context) error {
 	endDate := c.QueryParam("end")
 
 	if startDate == "" || endDate == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "start and end date are required",
-		})
+		return missingParameter("start and end date")
 	}
 
 	records, err := h.bodyWeightService.GetRecordsByDateRange(userID, startDate, endDate)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, records)
@@ -92,9 +79,7 @@ func (h *BodyWeightHandler) GetLatest(c echo.Context) error {
 
 	record, err := h.bodyWeightService.GetLatest(userID)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]string{
-			"error": "no body weight record found",
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, record)
@@ -105,26 +90,12 @@ func (h *BodyWeightHandler) Delete(c echo.Context) error {
 
 	recordID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid record id",
-		})
+		return invalidParameter("record id")
 	}
 
 	err = h.bodyWeightService.Delete(userID, recordID)
 	if err != nil {
-		if errors.Is(err, service.ErrBodyWeightNotFound) {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "record not found",
-			})
-		}
-		if errors.Is(err, service.ErrUnauthorized) {
-			return c.JSON(http.StatusForbidden, map[string]string{
-				"error": "unauthorized",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.NoContent(http.StatusNoContent)
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/training-memo/backend/internal/service"
+)
+
+// ErrorResponse はエラー時のレスポンス
+// code はクライアントが分岐に使う固定の値で、error は表示用のメッセージ
+type ErrorResponse struct {
+	Error     string      `json:"error"`
+	Code      string      `json:"code"`
+	RequestID string      `json:"request_id,omitempty"`
+	Details   interface{} `json:"details,omitempty"`
+}
+
+// APIError はハンドラーで判定したリクエストのエラー（パラメータの形式など）
+type APIError struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// errInvalidRequestBody はリクエストボディを読み取れない場合のエラー
+var errInvalidRequestBody = &APIError{Status: http.StatusBadRequest, Code: "INVALID_REQUEST_BODY", Message: "invalid request body"}
+
+// invalidParameter はパスやクエリのパラメータが不正な場合のエラー（例: invalidParameter("workout id")）
+func invalidParameter(name string) error {
+	return &APIError{Status: http.StatusBadRequest, Code: "INVALID_PARAMETER", Message: "invalid " + name}
+}
+
+// missingParameter は必須のクエリパラメータがない場合のエラー
+func missingParameter(name string) error {
+	return &APIError{Status: http.StatusBadRequest, Code: "MISSING_PARAMETER", Message: name + " query parameter is required"}
+}
+
+// serviceErrors はサービスのエラーとステータス・コードの対応
+var serviceErrors = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{service.ErrUnauthorized, http.StatusForbidden, "FORBIDDEN"},
+	{service.ErrInvalidDateFormat, http.StatusBadRequest, "INVALID_DATE"},
+
+	{service.ErrInvalidCredentials, http.StatusUnauthorized, "INVALID_CREDENTIALS"},
+	{service.ErrEmailAlreadyExists, http.StatusConflict, "EMAIL_ALREADY_EXISTS"},
+	{service.ErrUserNotFound, http.StatusNotFound, "USER_NOT_FOUND"},
+	{service.ErrInvalidLocale, http.StatusBadRequest, "INVALID_LOCALE"},
+
+	{service.ErrWorkoutNotFound, http.StatusNotFound, "WORKOUT_NOT_FOUND"},
+	{service.ErrInvalidRPE, http.StatusBadRequest, "INVALID_RPE"},
+
+	{service.ErrExerciseNotFound, http.StatusNotFound, "EXERCISE_NOT_FOUND"},
+	{service.ErrExerciseInUse, http.StatusConflict, "EXERCISE_IN_USE"},
+	{service.ErrNotCustomExercise, http.StatusForbidden, "NOT_CUSTOM_EXERCISE"},
+	{service.ErrInvalidMuscle, http.StatusBadRequest, "INVALID_MUSCLE"},
+	{service.ErrInvalidEquipment, http.StatusBadRequest, "INVALID_EQUIPMENT"},
+	{service.ErrExerciseNotAvailable, http.StatusBadRequest, "EXERCISE_NOT_AVAILABLE"},
+	{service.ErrInvalidExerciseSort, http.StatusBadRequest, "INVALID_EXERCISE_SORT"},
+	{service.ErrInvalidAlias, http.StatusBadRequest, "INVALID_ALIAS"},
+	{service.ErrAliasNotFound, http.StatusNotFound, "ALIAS_NOT_FOUND"},
+	{service.ErrAliasAlreadyExists, http.StatusConflict, "ALIAS_ALREADY_EXISTS"},
+	{service.ErrInvalidMergeTarget, http.StatusBadRequest, "INVALID_MERGE_TARGET"},
+	{service.ErrInvalidContent, http.StatusBadRequest, "INVALID_EXERCISE_CONTENT"},
+	{service.ErrInvalidVariation, http.StatusBadRequest, "INVALID_VARIATION"},
+	{service.ErrInvalidProgression, http.StatusBadRequest, "INVALID_PROGRESSION"},
+	{service.ErrInvalidExerciseReference, http.StatusUnprocessableEntity, "INVALID_EXERCISE_REFERENCE"},
+
+	{service.ErrMediaNotFound, http.StatusNotFound, "MEDIA_NOT_FOUND"},
+	{service.ErrUnsupportedMediaType, http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE"},
+	{service.ErrMediaTooLarge, http.StatusRequestEntityTooLarge, "MEDIA_TOO_LARGE"},
+	{service.ErrTooManyMedia, http.StatusConflict, "TOO_MANY_MEDIA"},
+	{service.ErrMediaUnavailable, http.StatusServiceUnavailable, "MEDIA_UNAVAILABLE"},
+
+	{service.ErrMenuNotFound, http.StatusNotFound, "MENU_NOT_FOUND"},
+	{service.ErrInvalidMenuTarget, http.StatusBadRequest, "INVALID_MENU_TARGET"},
+
+	{service.ErrInvalidFormula, http.StatusBadRequest, "INVALID_FORMULA"},
+	{service.ErrInvalidBucket, http.StatusBadRequest, "INVALID_BUCKET"},
+	{service.ErrInvalidDateRange, http.StatusBadRequest, "INVALID_DATE_RANGE"},
+	{service.ErrInvalidWeeklyTarget, http.StatusBadRequest, "INVALID_WEEKLY_TARGET"},
+	{service.ErrInvalidIntensity, http.StatusBadRequest, "INVALID_INTENSITY"},
+	{service.ErrInvalidLoadMetric, http.StatusBadRequest, "INVALID_LOAD_METRIC"},
+	{service.ErrInvalidPlateauThreshold, http.StatusBadRequest, "INVALID_PLATEAU_THRESHOLD"},
+	{service.ErrInvalidSex, http.StatusBadRequest, "INVALID_SEX"},
+	{service.ErrInvalidRollUp, http.StatusBadRequest, "INVALID_ROLLUP"},
+
+	{service.ErrInvalidReportPeriod, http.StatusBadRequest, "INVALID_REPORT_PERIOD"},
+
+	{service.ErrBodyWeightNotFound, http.StatusNotFound, "BODY_WEIGHT_NOT_FOUND"},
+
+	{service.ErrGoalNotFound, http.StatusNotFound, "GOAL_NOT_FOUND"},
+	{service.ErrInvalidGoal, http.StatusBadRequest, "INVALID_GOAL"},
+}
+
+// ErrorHandler はハンドラーが返したエラーを ErrorResponse にして返す（echo.HTTPErrorHandler）
+// サービスのエラーは serviceErrors のステータスとコードにし、想定外のエラーは内容を隠して 500 とする
+func ErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	status, response := errorResponse(err)
+	response.RequestID = requestID
+	if status == http.StatusInternalServerError {
+		c.Logger().Errorf("request %s: %v", requestID, err)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else {
+		err = c.JSON(status, response)
+	}
+	if err != nil {
+		c.Logger().Error(err)
+	}
+}
+
+// errorResponse はエラーのステータスとレスポンスを返す
+func errorResponse(err error) (int, ErrorResponse) {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest, ErrorResponse{Error: "validation failed", Code: "VALIDATION_FAILED", Details: validationErr.Fields}
+	}
+	var refErr *service.ExerciseReferenceError
+	if errors.As(err, &refErr) {
+		return http.StatusUnprocessableEntity, ErrorResponse{Error: service.ErrInvalidExerciseReference.Error(), Code: "INVALID_EXERCISE_REFERENCE", Details: refErr.Invalid}
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status, ErrorResponse{Error: apiErr.Message, Code: apiErr.Code}
+	}
+	for _, e := range serviceErrors {
+		if errors.Is(err, e.err) {
+			return e.status, ErrorResponse{Error: err.Error(), Code: e.code}
+		}
+	}
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) && httpErr.Code != http.StatusInternalServerError {
+		message := http.StatusText(httpErr.Code)
+		if m, ok := httpErr.Message.(string); ok {
+			message = m
+		}
+		return httpErr.Code, ErrorResponse{Error: message, Code: statusCode(httpErr.Code)}
+	}
+	return http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"}
+}
+
+// statusCode はステータスからコードを作る（例: 404 → NOT_FOUND）
+func statusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return fmt.Sprintf("HTTP_%d", status)
+	}
+	return strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text))
+}
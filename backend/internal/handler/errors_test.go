@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/training-memo/backend/internal/service"
+)
+
+func TestErrorHandler(t *testing.T) {
+	// ハンドラーが返したエラーを ErrorHandler で変換したレスポンスを返す
+	serve := func(t *testing.T, body string, handler echo.HandlerFunc) (*httptest.ResponseRecorder, ErrorResponse) {
+		t.Helper()
+		e := echo.New()
+		e.Validator = NewValidator()
+		e.HTTPErrorHandler = ErrorHandler
+		e.Use(echoMiddleware.RequestID())
+		e.POST("/test", handler)
+
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		var response ErrorResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("JSONパースエラー: %v", err)
+		}
+		return rec, response
+	}
+
+	t.Run("検証エラーは項目ごとに details に入る", func(t *testing.T) {
+		rec, response := serve(t, `{"email":"invalid","password":"short"}`, NewAuthHandler(nil).Register)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("期待されるステータスコード: %d, 実際: %d", http.StatusBadRequest, rec.Code)
+		}
+		if response.Code != "VALIDATION_FAILED" {
+			t.Errorf("期待されるcode: VALIDATION_FAILED, 実際: %s", response.Code)
+		}
+		details, ok := response.Details.([]interface{})
+		if !ok || len(details) != 3 {
+			t.Errorf("email・password・name のエラーを期待, 実際: %+v", response.Details)
+		}
+		if response.RequestID == "" || response.RequestID != rec.Header().Get(echo.HeaderXRequestID) {
+			t.Errorf("request_id は X-Request-ID と一致するべき, 実際: %q", response.RequestID)
+		}
+	})
+
+	t.Run("サービスのエラーはステータスとコードに変換する", func(t *testing.T) {
+		tests := []struct {
+			err     error
+			status  int
+			code    string
+			message string
+		}{
+			{fmt.Errorf("%w: 2026/10/01", service.ErrInvalidDateFormat), http.StatusBadRequest, "INVALID_DATE", "invalid date format: 2026/10/01"},
+			{service.ErrWorkoutNotFound, http.StatusNotFound, "WORKOUT_NOT_FOUND", "workout not found"},
+			{service.ErrUnauthorized, http.StatusForbidden, "FORBIDDEN", "unauthorized"},
+			{&service.ExerciseReferenceError{Invalid: []service.InvalidExerciseReference{{Field: "sets[0].exercise_id", ExerciseID: 999, Reason: "not_found"}}}, http.StatusUnprocessableEntity, "INVALID_EXERCISE_REFERENCE", "invalid exercise reference"},
+			{invalidParameter("workout id"), http.StatusBadRequest, "INVALID_PARAMETER", "invalid workout id"},
+			{echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired token"), http.StatusUnauthorized, "UNAUTHORIZED", "invalid or expired token"},
+		}
+		for _, tt := range tests {
+			rec, response := serve(t, `{}`, func(c echo.Context) error { return tt.err })
+			if rec.Code != tt.status || response.Code != tt.code {
+				t.Errorf("%v: 期待: %d %s, 実際: %d %s", tt.err, tt.status, tt.code, rec.Code, response.Code)
+			}
+			if response.Error != tt.message {
+				t.Errorf("%v: 期待されるerror: %s, 実際: %s", tt.err, tt.message, response.Error)
+			}
+		}
+	})
+
+	t.Run("想定外のエラーは内容を隠す", func(t *testing.T) {
+		rec, response := serve(t, `{}`, func(c echo.Context) error {
+			return errors.New(`pq: relation "workouts" does not exist`)
+		})
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("期待されるステータスコード: %d, 実際: %d", http.StatusInternalServerError, rec.Code)
+		}
+		if response.Code != "INTERNAL_ERROR" || response.Error != "internal server error" {
+			t.Errorf("汎用のメッセージを期待, 実際: %+v", response)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/training-memo/backend/internal/middleware"
+	"github.com/training-memo/backend/internal/service"
+)
+
+type ExerciseHandler struct {
+	contentService *service.ExerciseContentService
+	localizer      *service.Localizer
+}
+
+func NewExerciseHandler(contentService *service.ExerciseContentService, localizer *service.Localizer) *ExerciseHandler {
+	return &ExerciseHandler{contentService: contentService, localizer: localizer}
+}
+
+// 種目詳細（解説・画像・動画を含む）
+func (h *ExerciseHandler) GetExercise(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	exerciseID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParameter("exercise id")
+	}
+
+	exercise, err := h.contentService.GetExercise(userID, exerciseID)
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, exercise)
+}
+
+// 種目の画像・動画の配信（閲覧できる種目のもののみ）
+func (h *ExerciseHandler) GetMedia(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	exerciseID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParameter("exercise id")
+	}
+	mediaID, err := strconv.ParseUint(c.Param("mediaId"), 10, 64)
+	if err != nil {
+		return invalidParameter("media id")
+	}
+
+	media, body, err := h.contentService.OpenMedia(userID, exerciseID, mediaID)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	// 認証付きのレスポンスのため共有キャッシュには保存させない
+	c.Response().Header().Set("Cache-Control", "private, max-age=3600")
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	return c.Stream(http.StatusOK, media.ContentType, body)
+}
+
+// カスタム種目への画像・動画の追加（multipart の file フィールド）
+func (h *ExerciseHandler) UploadMedia(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	exerciseID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParameter("exercise id")
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return &APIError{Status: http.StatusBadRequest, Code: "MISSING_FILE", Message: "file is required"}
+	}
+	src, err := file.Open()
+	if err != nil {
+		return &APIError{Status: http.StatusBadRequest, Code: "INVALID_FILE", Message: "invalid file"}
+	}
+	defer src.Close()
+
+	media, err := h.contentService.UploadMedia(userID, exerciseID, &service.UploadMediaInput{
+		Size: file.Size,
+		Body: src,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, media)
+}
+
+// カスタム種目の画像・動画の削除
+func (h *ExerciseHandler) DeleteMedia(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	exerciseID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParameter("exercise id")
+	}
+	mediaID, err := strconv.ParseUint(c.Param("mediaId"), 10, 64)
+	if err != nil {
+		return invalidParameter("media id")
+	}
+
+	if err := h.contentService.DeleteMedia(userID, exerciseID, mediaID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/training-memo/backend/internal/middleware"
+	"github.com/training-memo/backend/internal/service"
+)
+
+type GoalHandler struct {
+	goalService *service.GoalService
+	localizer   *service.Localizer
+}
+
+func NewGoalHandler(goalService *service.GoalService, localizer *service.Localizer) *GoalHandler {
+	return &GoalHandler{goalService: goalService, localizer: localizer}
+}
+
+func (h *GoalHandler) CreateGoal(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	var input service.CreateGoalInput
+	if err := c.Bind(&input); err != nil {
+		return errInvalidRequestBody
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return err
+	}
+
+	goal, err := h.goalService.CreateGoal(userID, &input)
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusCreated, goal)
+}
+
+func (h *GoalHandler) GetGoals(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	goals, err := h.goalService.GetGoals(userID)
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, goals)
+}
+
+func (h *GoalHandler) GetGoal(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	goalID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParameter("goal id")
+	}
+
+	goal, err := h.goalService.GetGoal(userID, goalID)
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, goal)
+}
+
+func (h *GoalHandler) UpdateGoal(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	goalID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParameter("goal id")
+	}
+
+	var input service.UpdateGoalInput
+	if err := c.Bind(&input); err != nil {
+		return errInvalidRequestBody
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return err
+	}
+
+	goal, err := h.goalService.UpdateGoal(userID, goalID, &input)
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, goal)
+}
+
+func (h *GoalHandler) DeleteGoal(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	goalID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParameter("goal id")
+	}
+
+	if err := h.goalService.DeleteGoal(userID, goalID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
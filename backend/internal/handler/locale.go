@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/training-memo/backend/internal/middleware"
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/service"
+)
+
+// requestLocale はユーザー設定と Accept-Language から表示言語を決める
+func requestLocale(c echo.Context, localizer *service.Localizer) model.Locale {
+	return localizer.ResolveLocale(middleware.GetUserID(c), c.Request().Header.Get("Accept-Language"))
+}
+
+// localizedJSON は種目名などを表示言語に置き換えてから JSON を返す
+func localizedJSON(c echo.Context, localizer *service.Localizer, status int, v interface{}) error {
+	locale := requestLocale(c, localizer)
+	if err := localizer.Localize(locale, v); err != nil {
+		return err
+	}
+	c.Response().Header().Set("Content-Language", string(locale))
+	return c.JSON(status, v)
+}
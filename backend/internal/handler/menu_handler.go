@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -13,10 +12,11 @@ import (
 type MenuHandler struct {
 	menuService   *service.MenuService
 	aiMenuService *service.AIMenuService
+	localizer     *service.Localizer
 }
 
-func NewMenuHandler(menuService *service.MenuService, aiMenuService *service.AIMenuService) *MenuHandler {
-	return &MenuHandler{menuService: menuService, aiMenuService: aiMenuService}
+func NewMenuHandler(menuService *service.MenuService, aiMenuService *service.AIMenuService, localizer *service.Localizer) *MenuHandler {
+	return &MenuHandler{menuService: menuService, aiMenuService: aiMenuService, localizer: localizer}
 }
 
 func (h *MenuHandler) CreateMenu(c echo.Context) error {
@@ -24,25 +24,19 @@ func (h *MenuHandler) CreateMenu(c echo.Context) error {
 
 	var input service.CreateMenuInput
 	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid request body",
-		})
+		return errInvalidRequestBody
 	}
 
-	if input.Name == "" || len(input.Items) == 0 {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "name and at least one item are required",
-		})
+	if err := c.Validate(&input); err != nil {
+		return err
 	}
 
 	menu, err := h.menuService.CreateMenu(userID, &input)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusCreated, menu)
+	return localizedJSON(c, h.localizer, http.StatusCreated, menu)
 }
 
 func (h *MenuHandler) GetMenus(c echo.Context) error {
@@ -50,12 +44,10 @@ func (h *MenuHandler) GetMenus(c echo.Context) error {
 
 	menus, err := h.menuService.GetMenus(userID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusOK, menus)
+	return localizedJSON(c, h.localizer, http.StatusOK, menus)
 }
 
 func (h *MenuHandler) GetMenu(c echo.Context) error {
@@ -63,29 +55,15 @@ func (h *MenuHandler) GetMenu(c echo.Context) error {
 
 	menuID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid menu id",
-		})
+		return invalidParameter("menu id")
 	}
 
 	menu, err := h.menuService.GetMenu(userID, menuID)
 	if err != nil {
-		if errors.Is(err, service.ErrMenuNotFound) {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "menu not found",
-			})
-		}
-		if errors.Is(err, service.ErrUnauthorized) {
-			return c.JSON(http.StatusForbidden, map[string]string{
-				"error": "unauthorized",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
-	}
-
-	return c.JSON(http.StatusOK, menu)
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, menu)
 }
 
 func (h *MenuHandler) UpdateMenu(c echo.Context) error {
@@ -93,36 +71,41 @@ func (h *MenuHandler) UpdateMenu(c echo.Context) error {
 
 	menuID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid menu id",
-		})
+		return invalidParameter("menu id")
 	}
 
 	var input service.UpdateMenuInput
 	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid request body",
-		})
+		return errInvalidRequestBody
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return err
 	}
 
 	menu, err := h.menuService.UpdateMenu(userID, menuID, &input)
 	if err != nil {
-		if errors.Is(err, service.ErrMenuNotFound) {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "menu not found",
-			})
-		}
-		if errors.Is(err, service.ErrUnauthorized) {
-			return c.JSON(http.StatusForbidden, map[string]string{
-				"error": "unauthorized",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
-	}
-
-	return c.JSON(http.StatusOK, menu)
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, menu)
+}
+
+// メニューからワークアウト開始：目標重量を確定させたセット一覧
+func (h *MenuHandler) GetWorkoutPlan(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	menuID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParameter("menu id")
+	}
+
+	plan, err := h.menuService.GetWorkoutPlan(userID, menuID)
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, plan)
 }
 
 func (h *MenuHandler) GenerateMenuWithAI(c echo.Context) error {
@@ -130,25 +113,20 @@ func (h *MenuHandler) GenerateMenuWithAI(c echo.Context) error {
 
 	var input service.GenerateMenuInput
 	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid request body",
-		})
+		return errInvalidRequestBody
 	}
 
-	if input.Goal == "" || input.FitnessLevel == "" || input.DaysPerWeek == 0 || input.DurationMinutes == 0 {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "goal, fitness_level, days_per_week, duration_minutes are required",
-		})
+	if err := c.Validate(&input); err != nil {
+		return err
 	}
 
+	input.Locale = requestLocale(c, h.localizer)
 	output, err := h.aiMenuService.GenerateMenu(c.Request().Context(), userID, &input)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusOK, output)
+	return localizedJSON(c, h.localizer, http.StatusOK, output)
 }
 
 func (h *MenuHandler) DeleteMenu(c echo.Context) error {
@@ -156,26 +134,12 @@ func (h *MenuHandler) DeleteMenu(c echo.Context) error {
 
 	menuID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid menu id",
-		})
+		return invalidParameter("menu id")
 	}
 
 	err = h.menuService.DeleteMenu(userID, menuID)
 	if err != nil {
-		if errors.Is(err, service.ErrMenuNotFound) {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "menu not found",
-			})
-		}
-		if errors.Is(err, service.ErrUnauthorized) {
-			return c.JSON(http.StatusForbidden, map[string]string{
-				"error": "unauthorized",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.NoContent(http.StatusNoContent)
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/training-memo/backend/internal/middleware"
+	"github.com/training-memo/backend/internal/service"
+)
+
+type ReportHandler struct {
+	reportService *service.ReportService
+	localizer     *service.Localizer
+}
+
+func NewReportHandler(reportService *service.ReportService, localizer *service.Localizer) *ReportHandler {
+	return &ReportHandler{reportService: reportService, localizer: localizer}
+}
+
+// 週間レポート（week=2026-W41、省略時は今週）
+func (h *ReportHandler) GetWeeklyReport(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	report, err := h.reportService.GetWeeklyReport(userID, c.QueryParam("week"))
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, report)
+}
+
+// 月間レポート（month=2026-10、省略時は今月）
+func (h *ReportHandler) GetMonthlyReport(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	report, err := h.reportService.GetMonthlyReport(userID, c.QueryParam("month"))
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, report)
+}
+
+// 期間比較（a_from・a_to と b_from・b_to で2つの期間を指定、差は b − a）
+func (h *ReportHandler) Compare(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	a, err := service.ParseComparisonRange(c.QueryParam("a_from"), c.QueryParam("a_to"))
+	if err != nil {
+		return fmt.Errorf("period a: %w", err)
+	}
+	b, err := service.ParseComparisonRange(c.QueryParam("b_from"), c.QueryParam("b_to"))
+	if err != nil {
+		return fmt.Errorf("period b: %w", err)
+	}
+
+	comparison, err := h.reportService.Compare(userID, a, b, c.QueryParam("formula"))
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, comparison)
+}
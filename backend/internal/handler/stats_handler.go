@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/training-memo/backend/internal/middleware"
+	"github.com/training-memo/backend/internal/service"
+)
+
+type StatsHandler struct {
+	statsService    *service.StatsService
+	strengthService *service.StrengthService
+	localizer       *service.Localizer
+}
+
+func NewStatsHandler(statsService *service.StatsService, strengthService *service.StrengthService, localizer *service.Localizer) *StatsHandler {
+	return &StatsHandler{
+		statsService:    statsService,
+		strengthService: strengthService,
+		localizer:       localizer,
+	}
+}
+
+// 統計：継続状況（target=週の目標回数、intensity=volume|sets）
+func (h *StatsHandler) GetConsistency(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	stats, err := h.statsService.GetConsistency(userID, c.QueryParam("target"), c.QueryParam("intensity"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
+
+// 統計：トレーニング負荷（from・to で期間を指定、metric=auto|volume|rpe）
+func (h *StatsHandler) GetLoad(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	stats, err := h.statsService.GetLoad(userID, statsParams(c), c.QueryParam("metric"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
+
+// 統計：停滞している種目（sessions 回または weeks 週、推定1RMの更新がない種目）
+func (h *StatsHandler) GetPlateaus(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	lifts, err := h.statsService.GetPlateaus(userID, c.QueryParam("sessions"), c.QueryParam("weeks"), c.QueryParam("formula"))
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, lifts)
+}
+
+// 統計：体重比の強さ（sex=male|female、from・to・formula は他の統計と共通）
+func (h *StatsHandler) GetStrengthScores(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	scores, err := h.strengthService.GetStrengthScores(userID, c.QueryParam("sex"), statsParams(c))
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, scores)
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/service"
+)
+
+// Validator は入力構造体の validate タグを検証する（echo.Validator）
+// 標準のルールに加えて次のルールを使える
+//   - isodate: YYYY-MM-DD 形式の日付
+//   - musclegroup: 部位（chest・back など）
+//
+// ワークアウトのセットは種目ごとにセット番号が重複しないことも検証する
+type Validator struct {
+	validate *validator.Validate
+}
+
+func NewValidator() *Validator {
+	v := validator.New()
+	// エラーの項目名は JSON のキーにする
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	v.RegisterValidation("isodate", isISODate)
+	v.RegisterValidation("musclegroup", isMuscleGroup)
+	v.RegisterStructValidation(validateWorkoutSets, service.CreateWorkoutInput{}, service.UpdateWorkoutInput{})
+	return &Validator{validate: v}
+}
+
+// FieldError は入力の項目ごとの検証エラー
+type FieldError struct {
+	Field   string `json:"field"`  // 例: sets[0].reps
+	Reason  string `json:"reason"` // 違反したルール
+	Message string `json:"message"`
+}
+
+// ValidationError は入力の検証エラー
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		messages[i] = f.Field + " " + f.Message
+	}
+	return "validation failed: " + strings.Join(messages, ", ")
+}
+
+func (v *Validator) Validate(i interface{}) error {
+	err := v.validate.Struct(i)
+	if err == nil {
+		return nil
+	}
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return err
+	}
+	fields := make([]FieldError, len(errs))
+	for i, fe := range errs {
+		// 先頭の構造体名を除く（CreateWorkoutInput.sets[0].reps → sets[0].reps）
+		_, field, _ := strings.Cut(fe.Namespace(), ".")
+		fields[i] = FieldError{
+			Field:   field,
+			Reason:  fe.Tag(),
+			Message: fieldMessage(fe),
+		}
+	}
+	return &ValidationError{Fields: fields}
+}
+
+// fieldMessage はルールごとのエラーメッセージを返す
+func fieldMessage(fe validator.FieldError) string {
+	kind := fe.Kind()
+	switch fe.Tag() {
+	case "required", "required_if":
+		return "is required"
+	case "min", "max":
+		bound := "at least"
+		if fe.Tag() == "max" {
+			bound = "at most"
+		}
+		switch kind {
+		case reflect.String:
+			return fmt.Sprintf("must be %s %s characters", bound, fe.Param())
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return fmt.Sprintf("must contain %s %s items", bound, fe.Param())
+		}
+		return fmt.Sprintf("must be %s %s", bound, fe.Param())
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "lt":
+		return "must be less than " + fe.Param()
+	case "lte":
+		return "must be at most " + fe.Param()
+	case "email":
+		return "must be a valid email address"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "isodate":
+		return "must be a date in YYYY-MM-DD format"
+	case "musclegroup":
+		return "must be a valid muscle group"
+	case "unique_per_exercise":
+		return "must be unique for each exercise"
+	}
+	return "is invalid"
+}
+
+// isISODate は YYYY-MM-DD 形式の日付かどうかを返す
+func isISODate(fl validator.FieldLevel) bool {
+	_, err := time.Parse("2006-01-02", fl.Field().String())
+	return err == nil
+}
+
+// isMuscleGroup は部位かどうかを返す
+func isMuscleGroup(fl validator.FieldLevel) bool {
+	switch model.MuscleGroup(fl.Field().String()) {
+	case model.MuscleGroupChest, model.MuscleGroupBack, model.MuscleGroupShoulders,
+		model.MuscleGroupArms, model.MuscleGroupLegs, model.MuscleGroupAbs, model.MuscleGroupOther:
+		return true
+	}
+	return false
+}
+
+// validateWorkoutSets は同じ種目でセット番号が重複しているセットを報告する
+func validateWorkoutSets(sl validator.StructLevel) {
+	var sets []service.CreateSetInput
+	switch input := sl.Current().Interface().(type) {
+	case service.CreateWorkoutInput:
+		sets = input.Sets
+	case service.UpdateWorkoutInput:
+		sets = input.Sets
+	}
+
+	type key struct {
+		exerciseID uint64
+		setNumber  uint8
+	}
+	seen := make(map[key]bool, len(sets))
+	for i, set := range sets {
+		k := key{set.ExerciseID, set.SetNumber}
+		if seen[k] {
+			sl.ReportError(set.SetNumber, fmt.Sprintf("sets[%d].set_number", i), "SetNumber", "unique_per_exercise", "")
+		}
+		seen[k] = true
+	}
+}
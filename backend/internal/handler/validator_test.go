@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/training-memo/backend/internal/service"
+)
+
+func TestValidator(t *testing.T) {
+	validator := NewValidator()
+
+	fieldErrors := func(t *testing.T, err error) []FieldError {
+		t.Helper()
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("ValidationError が返されるべき, 実際: %v", err)
+		}
+		return validationErr.Fields
+	}
+
+	t.Run("不正な日付とセットの値を項目ごとに返す", func(t *testing.T) {
+		err := validator.Validate(&service.CreateWorkoutInput{
+			Date: "2026/10/01",
+			Sets: []service.CreateSetInput{
+				{ExerciseID: 1, SetNumber: 1, Weight: 100, Reps: 5},
+				{ExerciseID: 1, SetNumber: 2, Weight: 1500, Reps: 5},
+			},
+		})
+
+		fields := fieldErrors(t, err)
+		if len(fields) != 2 {
+			t.Fatalf("2件のエラーを期待, 実際: %+v", fields)
+		}
+		if fields[0].Field != "date" || fields[0].Reason != "isodate" {
+			t.Errorf("1件目: 期待: date/isodate, 実際: %+v", fields[0])
+		}
+		if fields[1].Field != "sets[1].weight" || fields[1].Reason != "max" {
+			t.Errorf("2件目: 期待: sets[1].weight/max, 実際: %+v", fields[1])
+		}
+	})
+
+	t.Run("同じ種目のセット番号の重複はエラー", func(t *testing.T) {
+		err := validator.Validate(&service.UpdateWorkoutInput{
+			Sets: []service.UpdateSetInput{
+				{ExerciseID: 1, SetNumber: 1, Weight: 100, Reps: 5},
+				{ExerciseID: 2, SetNumber: 1, Weight: 50, Reps: 10},
+				{ExerciseID: 1, SetNumber: 1, Weight: 100, Reps: 5},
+			},
+		})
+
+		fields := fieldErrors(t, err)
+		if len(fields) != 1 || fields[0].Field != "sets[2].set_number" || fields[0].Reason != "unique_per_exercise" {
+			t.Errorf("sets[2].set_number の重複を期待, 実際: %+v", fields)
+		}
+	})
+
+	t.Run("重量0（自重）は記録できる", func(t *testing.T) {
+		err := validator.Validate(&service.CreateWorkoutInput{
+			Date: "2026-10-01",
+			Sets: []service.CreateSetInput{{ExerciseID: 1, SetNumber: 1, Weight: 0, Reps: 10}},
+		})
+		if err != nil {
+			t.Errorf("予期しないエラー: %v", err)
+		}
+	})
+
+	t.Run("RPEや回数が範囲外の場合はエラー", func(t *testing.T) {
+		rpe := 11.0
+		err := validator.Validate(&service.CreateWorkoutInput{
+			Date: "2026-10-01",
+			Sets: []service.CreateSetInput{
+				{ExerciseID: 1, SetNumber: 1, Weight: 60, Reps: 10, RPE: &rpe},
+				{ExerciseID: 1, SetNumber: 2, Weight: 60, Reps: 201},
+			},
+		})
+
+		fields := fieldErrors(t, err)
+		if len(fields) != 2 || fields[0].Field != "sets[0].rpe" || fields[1].Field != "sets[1].reps" {
+			t.Errorf("sets[0].rpe と sets[1].reps のエラーを期待, 実際: %+v", fields)
+		}
+	})
+
+	t.Run("目標の指定方法に必要な値がない場合はエラー", func(t *testing.T) {
+		percentage := 80.0
+		err := validator.Validate(&service.CreateMenuInput{
+			Name: "脚の日",
+			Items: []service.CreateItemInput{
+				{ExerciseID: 1, OrderNumber: 1, TargetSets: 3, TargetReps: 5, TargetType: "percent_e1rm", TargetPercentage: &percentage},
+				{ExerciseID: 2, OrderNumber: 2, TargetSets: 3, TargetReps: 8, TargetType: "rpe"},
+			},
+		})
+
+		fields := fieldErrors(t, err)
+		if len(fields) != 1 || fields[0].Field != "items[1].target_rpe" || fields[0].Reason != "required_if" {
+			t.Errorf("items[1].target_rpe のエラーを期待, 実際: %+v", fields)
+		}
+	})
+
+	t.Run("不明な部位はエラー", func(t *testing.T) {
+		err := validator.Validate(&service.CreateExerciseInput{Name: "マイ種目", MuscleGroup: "neck"})
+
+		fields := fieldErrors(t, err)
+		if len(fields) != 1 || fields[0].Field != "muscle_group" || fields[0].Reason != "musclegroup" {
+			t.Errorf("muscle_group のエラーを期待, 実際: %+v", fields)
+		}
+	})
+}
@@ -1,21 +1,22 @@
 package handler
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"github.com/training-memo/backend/internal/middleware"
+	"github.com/training-memo/backend/internal/model"
 	"github.com/training-memo/backend/internal/service"
 )
 
 type WorkoutHandler struct {
 	workoutService *service.WorkoutService
+	localizer      *service.Localizer
 }
 
-func NewWorkoutHandler(workoutService *service.WorkoutService) *WorkoutHandler {
-	return &WorkoutHandler{workoutService: workoutService}
+func NewWorkoutHandler(workoutService *service.WorkoutService, localizer *service.Localizer) *WorkoutHandler {
+	return &WorkoutHandler{workoutService: workoutService, localizer: localizer}
 }
 
 func (h *WorkoutHandler) CreateWorkout(c echo.Context) error {
@@ -23,25 +24,19 @@ func (h *WorkoutHandler) CreateWorkout(c echo.Context) error {
 
 	var input service.CreateWorkoutInput
 	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid request body",
-		})
+		return errInvalidRequestBody
 	}
 
-	if input.Date == "" || len(input.Sets) == 0 {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "date and at least one set are required",
-		})
+	if err := c.Validate(&input); err != nil {
+		return err
 	}
 
 	workout, err := h.workoutService.CreateWorkout(userID, &input)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusCreated, workout)
+	return localizedJSON(c, h.localizer, http.StatusCreated, workout)
 }
 
 func (h *WorkoutHandler) GetWorkout(c echo.Context) error {
@@ -49,29 +44,15 @@ func (h *WorkoutHandler) GetWorkout(c echo.Context) error {
 
 	workoutID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid workout id",
-		})
+		return invalidParameter("workout id")
 	}
 
 	workout, err := h.workoutService.GetWorkout(userID, workoutID)
 	if err != nil {
-		if errors.Is(err, service.ErrWorkoutNotFound) {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "workout not found",
-			})
-		}
-		if errors.Is(err, service.ErrUnauthorized) {
-			return c.JSON(http.StatusForbidden, map[string]string{
-				"error": "unauthorized",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusOK, workout)
+	return localizedJSON(c, h.localizer, http.StatusOK, workout)
 }
 
 func (h *WorkoutHandler) GetWorkoutByDate(c echo.Context) error {
@@ -79,19 +60,15 @@ func (h *WorkoutHandler) GetWorkoutByDate(c echo.Context) error {
 	date := c.QueryParam("date")
 
 	if date == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "date query parameter is required",
-		})
+		return missingParameter("date")
 	}
 
 	workout, err := h.workoutService.GetWorkoutByDate(userID, date)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]string{
-			"error": "workout not found for this date",
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusOK, workout)
+	return localizedJSON(c, h.localizer, http.StatusOK, workout)
 }
 
 func (h *WorkoutHandler) GetWorkoutList(c echo.Context) error {
@@ -109,12 +86,10 @@ func (h *WorkoutHandler) GetWorkoutList(c echo.Context) error {
 
 	response, err := h.workoutService.GetWorkoutList(userID, page, perPage)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusOK, response)
+	return localizedJSON(c, h.localizer, http.StatusOK, response)
 }
 
 func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
@@ -122,36 +97,24 @@ func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
 
 	workoutID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid workout id",
-		})
+		return invalidParameter("workout id")
 	}
 
 	var input service.UpdateWorkoutInput
 	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid request body",
-		})
+		return errInvalidRequestBody
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return err
 	}
 
 	workout, err := h.workoutService.UpdateWorkout(userID, workoutID, &input)
 	if err != nil {
-		if errors.Is(err, service.ErrWorkoutNotFound) {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "workout not found",
-			})
-		}
-		if errors.Is(err, service.ErrUnauthorized) {
-			return c.JSON(http.StatusForbidden, map[string]string{
-				"error": "unauthorized",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusOK, workout)
+	return localizedJSON(c, h.localizer, http.StatusOK, workout)
 }
 
 func (h *WorkoutHandler) DeleteWorkout(c echo.Context) error {
@@ -159,25 +122,11 @@ func (h *WorkoutHandler) DeleteWorkout(c echo.Context) error {
 
 	workoutID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid workout id",
-		})
+		return invalidParameter("workout id")
 	}
 
 	if err := h.workoutService.DeleteWorkout(userID, workoutID); err != nil {
-		if errors.Is(err, service.ErrWorkoutNotFound) {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "workout not found",
-			})
-		}
-		if errors.Is(err, service.ErrUnauthorized) {
-			return c.JSON(http.StatusForbidden, map[string]string{
-				"error": "unauthorized",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.NoContent(http.StatusNoContent)
@@ -185,24 +134,158 @@ func (h *WorkoutHandler) DeleteWorkout(c echo.Context) error {
 
 func (h *WorkoutHandler) GetExercises(c echo.Context) error {
 	userID := middleware.GetUserID(c)
-	muscleGroup := c.QueryParam("muscle_group")
 
-	var exercises interface{}
-	var err error
+	exercises, err := h.workoutService.GetExercises(userID, service.ExerciseQuery{
+		MuscleGroup:   c.QueryParam("muscle_group"),
+		Equipment:     c.QueryParam("equipment"),
+		AvailableOnly: c.QueryParam("available_only") == "true",
+		Sort:          c.QueryParam("sort"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, exercises)
+}
+
+// お気に入り登録
+func (h *WorkoutHandler) AddFavorite(c echo.Context) error {
+	return h.updateFavorite(c, h.workoutService.AddFavorite)
+}
+
+// お気に入り解除
+func (h *WorkoutHandler) RemoveFavorite(c echo.Context) error {
+	return h.updateFavorite(c, h.workoutService.RemoveFavorite)
+}
+
+func (h *WorkoutHandler) updateFavorite(c echo.Context, update func(userID, exerciseID uint64) error) error {
+	userID := middleware.GetUserID(c)
+
+	exerciseID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParameter("exercise id")
+	}
+
+	if err := update(userID, exerciseID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// 種目検索（種目名と別名の一致度順）
+func (h *WorkoutHandler) SearchExercises(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	results, err := h.workoutService.SearchExercises(userID, c.QueryParam("q"))
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, results)
+}
+
+// 種目の別名一覧
+func (h *WorkoutHandler) GetAliases(c echo.Context) error {
+	userID := middleware.GetUserID(c)
 
-	if muscleGroup != "" {
-		exercises, err = h.workoutService.GetExercisesByMuscleGroup(userID, muscleGroup)
-	} else {
-		exercises, err = h.workoutService.GetExercises(userID)
+	exerciseID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParameter("exercise id")
 	}
 
+	aliases, err := h.workoutService.GetAliases(userID, exerciseID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusOK, exercises)
+	return c.JSON(http.StatusOK, aliases)
+}
+
+// 種目の別名登録
+func (h *WorkoutHandler) CreateAlias(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	exerciseID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParameter("exercise id")
+	}
+
+	var input service.CreateAliasInput
+	if err := c.Bind(&input); err != nil {
+		return errInvalidRequestBody
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return err
+	}
+
+	alias, err := h.workoutService.CreateAlias(userID, exerciseID, &input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, alias)
+}
+
+// 種目の別名削除
+func (h *WorkoutHandler) DeleteAlias(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	aliasID, err := strconv.ParseUint(c.Param("aliasId"), 10, 64)
+	if err != nil {
+		return invalidParameter("alias id")
+	}
+
+	if err := h.workoutService.DeleteAlias(userID, aliasID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// 種目のバリエーション
+func (h *WorkoutHandler) GetVariations(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	exerciseID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParameter("exercise id")
+	}
+
+	variations, err := h.workoutService.GetVariations(userID, exerciseID)
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, variations)
+}
+
+// 種目の段階と次に取り組む種目
+func (h *WorkoutHandler) GetProgression(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	exerciseID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParameter("exercise id")
+	}
+
+	progression, err := h.workoutService.GetProgression(userID, exerciseID)
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, progression)
+}
+
+// 筋肉の階層
+func (h *WorkoutHandler) GetMuscles(c echo.Context) error {
+	muscles, err := h.workoutService.GetMuscles()
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, muscles)
 }
 
 // カレンダー用：月別ワークアウト取得
@@ -211,37 +294,41 @@ func (h *WorkoutHandler) GetWorkoutsByMonth(c echo.Context) error {
 
 	year, err := strconv.Atoi(c.QueryParam("year"))
 	if err != nil || year < 2000 || year > 2100 {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid year",
-		})
+		return invalidParameter("year")
 	}
 
 	month, err := strconv.Atoi(c.QueryParam("month"))
 	if err != nil || month < 1 || month > 12 {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid month",
-		})
+		return invalidParameter("month")
 	}
 
 	workouts, err := h.workoutService.GetWorkoutsByMonth(userID, year, month)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusOK, workouts)
+	return localizedJSON(c, h.localizer, http.StatusOK, workouts)
 }
 
 // 統計：部位別集計
 func (h *WorkoutHandler) GetMuscleGroupStats(c echo.Context) error {
 	userID := middleware.GetUserID(c)
 
-	stats, err := h.workoutService.GetMuscleGroupStats(userID)
+	stats, err := h.workoutService.GetMuscleGroupStats(userID, statsParams(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
+
+// 統計：筋肉別集計
+func (h *WorkoutHandler) GetMuscleStats(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	stats, err := h.workoutService.GetMuscleStats(userID, statsParams(c))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, stats)
@@ -251,14 +338,12 @@ func (h *WorkoutHandler) GetMuscleGroupStats(c echo.Context) error {
 func (h *WorkoutHandler) GetPersonalBests(c echo.Context) error {
 	userID := middleware.GetUserID(c)
 
-	bests, err := h.workoutService.GetPersonalBests(userID)
+	bests, err := h.workoutService.GetPersonalBests(userID, statsParams(c))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusOK, bests)
+	return localizedJSON(c, h.localizer, http.StatusOK, bests)
 }
 
 // 統計：種目の重量推移
@@ -267,43 +352,71 @@ func (h *WorkoutHandler) GetExerciseProgress(c echo.Context) error {
 
 	exerciseID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid exercise id",
-		})
+		return invalidParameter("exercise id")
 	}
 
-	progress, err := h.workoutService.GetExerciseProgress(userID, exerciseID)
+	progress, err := h.workoutService.GetExerciseProgress(userID, exerciseID, statsParams(c))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, progress)
 }
 
+// 統計：自己ベストの更新履歴
+func (h *WorkoutHandler) GetPersonalRecordHistory(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	var exerciseID uint64
+	if v := c.QueryParam("exercise_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return invalidParameter("exercise id")
+		}
+		exerciseID = id
+	}
+
+	records, err := h.workoutService.GetPersonalRecordHistory(userID, exerciseID)
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, records)
+}
+
+// 統計：レップ数ごとの自己ベスト表
+func (h *WorkoutHandler) GetRepMaxes(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	exerciseID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParameter("exercise id")
+	}
+
+	repMaxes, err := h.workoutService.GetRepMaxes(userID, exerciseID, c.QueryParam("formula"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, repMaxes)
+}
+
 // カスタム種目作成
 func (h *WorkoutHandler) CreateCustomExercise(c echo.Context) error {
 	userID := middleware.GetUserID(c)
 
 	var input service.CreateExerciseInput
 	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid request body",
-		})
+		return errInvalidRequestBody
 	}
 
-	if input.Name == "" || input.MuscleGroup == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "name and muscle_group are required",
-		})
+	if err := c.Validate(&input); err != nil {
+		return err
 	}
 
 	exercise, err := h.workoutService.CreateCustomExercise(userID, &input)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusCreated, exercise)
@@ -315,9 +428,7 @@ func (h *WorkoutHandler) GetCustomExercises(c echo.Context) error {
 
 	exercises, err := h.workoutService.GetCustomExercises(userID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, exercises)
@@ -329,33 +440,21 @@ func (h *WorkoutHandler) UpdateCustomExercise(c echo.Context) error {
 
 	exerciseID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid exercise id",
-		})
+		return invalidParameter("exercise id")
 	}
 
 	var input service.CreateExerciseInput
 	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid request body",
-		})
+		return errInvalidRequestBody
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return err
 	}
 
 	exercise, err := h.workoutService.UpdateCustomExercise(userID, exerciseID, &input)
 	if err != nil {
-		if errors.Is(err, service.ErrExerciseNotFound) {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "exercise not found",
-			})
-		}
-		if errors.Is(err, service.ErrNotCustomExercise) {
-			return c.JSON(http.StatusForbidden, map[string]string{
-				"error": "cannot modify preset exercise",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, exercise)
@@ -367,31 +466,71 @@ func (h *WorkoutHandler) DeleteCustomExercise(c echo.Context) error {
 
 	exerciseID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid exercise id",
-		})
+		return invalidParameter("exercise id")
 	}
 
 	if err := h.workoutService.DeleteCustomExercise(userID, exerciseID); err != nil {
-		if errors.Is(err, service.ErrExerciseNotFound) {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "exercise not found",
-			})
-		}
-		if errors.Is(err, service.ErrNotCustomExercise) {
-			return c.JSON(http.StatusForbidden, map[string]string{
-				"error": "cannot delete preset exercise",
-			})
-		}
-		if errors.Is(err, service.ErrExerciseInUse) {
-			return c.JSON(http.StatusConflict, map[string]string{
-				"error": "exercise is in use",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// カスタム種目のアーカイブ
+func (h *WorkoutHandler) ArchiveCustomExercise(c echo.Context) error {
+	return h.updateArchived(c, h.workoutService.ArchiveCustomExercise)
+}
+
+// カスタム種目のアーカイブ解除
+func (h *WorkoutHandler) UnarchiveCustomExercise(c echo.Context) error {
+	return h.updateArchived(c, h.workoutService.UnarchiveCustomExercise)
+}
+
+func (h *WorkoutHandler) updateArchived(c echo.Context, update func(userID, exerciseID uint64) (*model.Exercise, error)) error {
+	userID := middleware.GetUserID(c)
+
+	exerciseID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParameter("exercise id")
+	}
+
+	exercise, err := update(userID, exerciseID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, exercise)
+}
+
+// カスタム種目の統合
+func (h *WorkoutHandler) MergeCustomExercise(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	sourceID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParameter("exercise id")
+	}
+	targetID, err := strconv.ParseUint(c.Param("targetId"), 10, 64)
+	if err != nil {
+		return invalidParameter("target exercise id")
+	}
+
+	exercise, err := h.workoutService.MergeCustomExercise(userID, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+
+	return localizedJSON(c, h.localizer, http.StatusOK, exercise)
+}
+
+// statsParams は統計エンドポイント共通のクエリパラメータを取得する
+func statsParams(c echo.Context) service.StatsParams {
+	return service.StatsParams{
+		From:    c.QueryParam("from"),
+		To:      c.QueryParam("to"),
+		Bucket:  c.QueryParam("bucket"),
+		Formula: c.QueryParam("formula"),
+		RollUp:  c.QueryParam("rollup"),
+	}
+}
+
@@ -13,24 +13,18 @@ func AuthMiddleware() echo.MiddlewareFunc {
 		return func(c echo.Context) error {
 			authHeader := c.Request().Header.Get("Authorization")
 			if authHeader == "" {
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "authorization header required",
-				})
+				return echo.NewHTTPError(http.StatusUnauthorized, "authorization header required")
 			}
 
 			parts := strings.Split(authHeader, " ")
 			if len(parts) != 2 || parts[0] != "Bearer" {
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "invalid authorization header format",
-				})
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid authorization header format")
 			}
 
 			tokenString := parts[1]
 			userID, err := service.ValidateToken(tokenString)
 			if err != nil {
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "invalid or expired token",
-				})
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired token")
 			}
 
 			// コンテキストにユーザーIDを設定
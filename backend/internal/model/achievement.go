@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+)
+
+// UserAchievement はユーザーが獲得した実績を表す
+// AchievementKey は実績ルールのキーに対応する
+type UserAchievement struct {
+	ID             uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID         uint64    `json:"user_id" gorm:"not null;index"`
+	AchievementKey string    `json:"achievement_key" gorm:"size:50;not null"`
+	EarnedOn       time.Time `json:"earned_on" gorm:"type:date;not null"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (UserAchievement) TableName() string {
+	return "user_achievements"
+}
@@ -12,6 +12,9 @@ type BodyWeight struct {
 	Weight            float64   `json:"weight" gorm:"type:decimal(5,2);not null"`
 	BodyFatPercentage *float64  `json:"body_fat_percentage" gorm:"type:decimal(4,1)"`
 	CreatedAt         time.Time `json:"created_at"`
+
+	// NewAchievements は記録で新たに獲得した実績（保存しない）
+	NewAchievements []UserAchievement `json:"new_achievements,omitempty" gorm:"-"`
 }
 
 func (BodyWeight) TableName() string {
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+)
+
+// DailyExerciseSummary はユーザー・種目・日ごとのセットの集計を表す
+// ワークアウトの保存・削除と同じトランザクションで更新し、統計はこの表から集計する
+type DailyExerciseSummary struct {
+	UserID          uint64    `json:"user_id" gorm:"primaryKey"`
+	ExerciseID      uint64    `json:"exercise_id" gorm:"primaryKey"`
+	Date            time.Time `json:"date" gorm:"primaryKey;type:date"`
+	SetCount        int       `json:"set_count" gorm:"not null"`
+	TotalReps       int       `json:"total_reps" gorm:"not null"`
+	TotalVolume     float64   `json:"total_volume" gorm:"type:numeric;not null"`
+	MaxWeight       float64   `json:"max_weight" gorm:"type:decimal(6,2);not null"`
+	MaxE1RMEpley    float64   `json:"max_e1rm_epley" gorm:"column:max_e1rm_epley;type:numeric;not null"`
+	MaxE1RMBrzycki  float64   `json:"max_e1rm_brzycki" gorm:"column:max_e1rm_brzycki;type:numeric;not null"`
+	MaxE1RMLombardi float64   `json:"max_e1rm_lombardi" gorm:"column:max_e1rm_lombardi;type:numeric;not null"`
+}
+
+func (DailyExerciseSummary) TableName() string {
+	return "daily_exercise_summary"
+}
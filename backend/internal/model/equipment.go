@@ -0,0 +1,105 @@
+package model
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// Equipment は種目に使う器具
+type Equipment string
+
+const (
+	EquipmentBarbell    Equipment = "barbell"
+	EquipmentDumbbell   Equipment = "dumbbell"
+	EquipmentCable      Equipment = "cable"
+	EquipmentMachine    Equipment = "machine"
+	EquipmentBodyweight Equipment = "bodyweight" // 器具の設定にかかわらず常に使える
+	EquipmentKettlebell Equipment = "kettlebell"
+	EquipmentBand       Equipment = "band"
+)
+
+// AllEquipment は対応している器具の一覧
+var AllEquipment = []Equipment{
+	EquipmentBarbell, EquipmentDumbbell, EquipmentCable, EquipmentMachine,
+	EquipmentBodyweight, EquipmentKettlebell, EquipmentBand,
+}
+
+// IsValid は対応している器具かどうかを返す
+func (e Equipment) IsValid() bool {
+	for _, v := range AllEquipment {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// EquipmentList は器具の一覧（Postgres の varchar[] 列に対応する）
+// nil は NULL として保存する
+type EquipmentList []Equipment
+
+// Value は Postgres の配列リテラルに変換する
+func (l EquipmentList) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	values := make([]string, len(l))
+	for i, e := range l {
+		if !e.IsValid() {
+			return nil, fmt.Errorf("invalid equipment: %s", e)
+		}
+		values[i] = string(e)
+	}
+	return "{" + strings.Join(values, ",") + "}", nil
+}
+
+// Scan は Postgres の配列リテラルを読み込む
+func (l *EquipmentList) Scan(src interface{}) error {
+	var literal string
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case string:
+		literal = v
+	case []byte:
+		literal = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into EquipmentList", src)
+	}
+
+	literal = strings.TrimSuffix(strings.TrimPrefix(literal, "{"), "}")
+	list := EquipmentList{}
+	if literal != "" {
+		for _, v := range strings.Split(literal, ",") {
+			list = append(list, Equipment(strings.Trim(v, `"`)))
+		}
+	}
+	*l = list
+	return nil
+}
+
+// Contains は器具が含まれているかどうかを返す
+func (l EquipmentList) Contains(e Equipment) bool {
+	for _, v := range l {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows はこの器具がそろっていれば exercise の器具をすべて使えるかどうかを返す
+// nil（制限なし）の場合は常に true
+func (l EquipmentList) Allows(exercise EquipmentList) bool {
+	if l == nil {
+		return true
+	}
+	for _, e := range exercise {
+		if e != EquipmentBodyweight && !l.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
@@ -16,13 +16,41 @@ const (
 	MuscleGroupOther     MuscleGroup = "other"
 )
 
+// Exercise は種目を表す
+// MuscleGroup は主な部位。Muscles は関与する筋肉で、空の場合は MuscleGroup のみに関与するものとして集計する
+// Equipment は使う器具で、すべてそろっている場合に実施できる
+// Instructions・Cues・CommonMistakes は手順・フォームのポイント・よくある間違いで、Media は種目詳細でのみ取得する
+// ArchivedAt が設定されたカスタム種目は種目の選択肢に出さないが、過去の記録や統計からは参照できる
+// ParentID はバリエーションの親種目（統計で親種目にまとめられる）で、親種目はそれ自体バリエーションではない
+// ProgressesFromID は段階的な種目の1つ前（易しい）種目
+// PresetKey はプリセット種目を表示名に依存せず識別するキー（例: bench_press）
 type Exercise struct {
-	ID          uint64      `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name        string      `json:"name" gorm:"size:100;not null"`
-	MuscleGroup MuscleGroup `json:"muscle_group" gorm:"type:enum('chest','back','shoulders','arms','legs','abs','other');not null"`
-	IsCustom    bool        `json:"is_custom" gorm:"default:false"`
-	UserID      *uint64     `json:"user_id"`
-	CreatedAt   time.Time   `json:"created_at"`
+	ID          uint64           `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string           `json:"name" gorm:"size:100;not null"`
+	MuscleGroup MuscleGroup      `json:"muscle_group" gorm:"type:enum('chest','back','shoulders','arms','legs','abs','other');not null"`
+	IsCustom    bool             `json:"is_custom" gorm:"default:false"`
+	PresetKey   *string          `json:"preset_key,omitempty" gorm:"size:50"`
+	Equipment   EquipmentList    `json:"equipment" gorm:"type:varchar(20)[];not null;default:'{}'"`
+	UserID      *uint64          `json:"user_id"`
+	ArchivedAt  *time.Time       `json:"archived_at,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+	Muscles     []ExerciseMuscle `json:"muscles,omitempty" gorm:"foreignKey:ExerciseID"`
+
+	ParentID         *uint64 `json:"parent_id"`
+	ProgressesFromID *uint64 `json:"progresses_from_id"`
+
+	Instructions   TextList        `json:"instructions" gorm:"type:jsonb;not null;default:'[]'"`
+	Cues           TextList        `json:"cues" gorm:"type:jsonb;not null;default:'[]'"`
+	CommonMistakes TextList        `json:"common_mistakes" gorm:"type:jsonb;not null;default:'[]'"`
+	Media          []ExerciseMedia `json:"media,omitempty" gorm:"foreignKey:ExerciseID"`
+
+	// Description は表示言語の説明で、Name とあわせて応答を返す前に翻訳から設定する
+	Description string `json:"description,omitempty" gorm:"-"`
+
+	// 以下は種目一覧でのみ設定する（ユーザーごとの値）
+	IsFavorite bool       `json:"is_favorite" gorm:"->;-:migration"`
+	LastUsedOn *time.Time `json:"last_used_on,omitempty" gorm:"->;-:migration"`
+	UseCount   int        `json:"use_count" gorm:"->;-:migration"` // 種目を記録したワークアウトの数
 }
 
 func (Exercise) TableName() string {
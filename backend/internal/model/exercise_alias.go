@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+)
+
+// ExerciseAlias は種目の別名（英語名・略称・ローマ字など）を表す
+// UserID が nil の別名は全ユーザー共通で、それ以外は登録したユーザーのみが検索に使う
+type ExerciseAlias struct {
+	ID         uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	ExerciseID uint64    `json:"exercise_id" gorm:"not null;index"`
+	UserID     *uint64   `json:"user_id"`
+	Alias      string    `json:"alias" gorm:"size:100;not null"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (ExerciseAlias) TableName() string {
+	return "exercise_aliases"
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TextList は文章の一覧（Postgres の jsonb 列に対応する）
+// nil は空の一覧として保存する
+type TextList []string
+
+// Value は JSON の配列に変換する
+func (l TextList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan は JSON の配列を読み込む
+func (l *TextList) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*l = TextList{}
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("cannot scan %T into TextList", src)
+	}
+
+	list := TextList{}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("scanning TextList: %w", err)
+	}
+	*l = list
+	return nil
+}
+
+// MediaType は種目の添付ファイルの種類
+type MediaType string
+
+const (
+	MediaTypeImage MediaType = "image"
+	MediaTypeVideo MediaType = "video"
+)
+
+// ExerciseMedia は種目の画像・動画
+// ファイル本体はストレージに保存し、認証が必要な URL から配信する
+type ExerciseMedia struct {
+	ID          uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	ExerciseID  uint64    `json:"exercise_id" gorm:"not null;index"`
+	MediaType   MediaType `json:"media_type" gorm:"size:10;not null"`
+	StorageKey  string    `json:"-" gorm:"size:255;not null;uniqueIndex"`
+	ContentType string    `json:"content_type" gorm:"size:100;not null"`
+	Size        int64     `json:"size" gorm:"not null"`
+	Position    int       `json:"position" gorm:"not null;default:0"`
+	CreatedAt   time.Time `json:"created_at"`
+	URL         string    `json:"url" gorm:"-"`
+}
+
+func (ExerciseMedia) TableName() string {
+	return "exercise_media"
+}
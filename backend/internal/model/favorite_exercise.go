@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+)
+
+// FavoriteExercise はユーザーがお気に入りに登録した種目を表す
+type FavoriteExercise struct {
+	UserID     uint64    `json:"user_id" gorm:"primaryKey"`
+	ExerciseID uint64    `json:"exercise_id" gorm:"primaryKey"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (FavoriteExercise) TableName() string {
+	return "favorite_exercises"
+}
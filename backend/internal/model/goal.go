@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+)
+
+// GoalType は目標の種類
+type GoalType string
+
+const (
+	GoalTypeLift       GoalType = "lift"        // 種目の推定1RM（kg）
+	GoalTypeBodyWeight GoalType = "body_weight" // 体重（kg）
+	GoalTypeBodyFat    GoalType = "body_fat"    // 体脂肪率（%）
+	GoalTypeFrequency  GoalType = "frequency"   // 週あたりのワークアウト回数
+)
+
+// IsValid は対応している目標の種類かどうかを返す
+func (t GoalType) IsValid() bool {
+	switch t {
+	case GoalTypeLift, GoalTypeBodyWeight, GoalTypeBodyFat, GoalTypeFrequency:
+		return true
+	}
+	return false
+}
+
+// Goal はユーザーの目標を表す
+// StartValue は作成時点の値（記録がない場合は nil）で、増やす目標か減らす目標かの判定に使う
+type Goal struct {
+	ID          uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID      uint64     `json:"user_id" gorm:"not null;index"`
+	GoalType    GoalType   `json:"goal_type" gorm:"type:varchar(20);not null"`
+	ExerciseID  *uint64    `json:"exercise_id"`
+	TargetValue float64    `json:"target_value" gorm:"type:decimal(8,2);not null"`
+	StartValue  *float64   `json:"start_value" gorm:"type:decimal(8,2)"`
+	Deadline    *time.Time `json:"deadline" gorm:"type:date"`
+	AchievedOn  *time.Time `json:"achieved_on" gorm:"type:date"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	Exercise    *Exercise  `json:"exercise,omitempty" gorm:"foreignKey:ExerciseID"`
+
+	// Progress は記録から算出した進捗（保存しない）
+	Progress *GoalProgress `json:"progress,omitempty" gorm:"-"`
+}
+
+func (Goal) TableName() string {
+	return "goals"
+}
+
+// GoalProgress は目標の進捗
+type GoalProgress struct {
+	CurrentValue  *float64   `json:"current_value"`
+	Percent       float64    `json:"percent"`
+	TrendPerWeek  *float64   `json:"trend_per_week"`
+	ProjectedDate *time.Time `json:"projected_date"` // 直近の傾向から目標に届く見込みの日
+	OnTrack       *bool      `json:"on_track"`       // 期限までに届く見込みか（期限がない場合は nil）
+}
@@ -0,0 +1,37 @@
+package model
+
+// Locale は表示言語
+type Locale string
+
+const (
+	LocaleJa Locale = "ja"
+	LocaleEn Locale = "en"
+
+	// DefaultLocale は種目名などの基本の言語（exercises.name はこの言語で保存する）
+	DefaultLocale = LocaleJa
+)
+
+// SupportedLocales は対応している表示言語の一覧
+var SupportedLocales = []Locale{LocaleJa, LocaleEn}
+
+// IsValid は対応している表示言語かどうかを返す
+func (l Locale) IsValid() bool {
+	for _, v := range SupportedLocales {
+		if l == v {
+			return true
+		}
+	}
+	return false
+}
+
+// ExerciseTranslation は種目名・説明の翻訳
+type ExerciseTranslation struct {
+	ExerciseID  uint64  `json:"exercise_id" gorm:"primaryKey"`
+	Locale      Locale  `json:"locale" gorm:"primaryKey;size:10"`
+	Name        string  `json:"name" gorm:"size:100;not null"`
+	Description *string `json:"description" gorm:"type:text"`
+}
+
+func (ExerciseTranslation) TableName() string {
+	return "exercise_translations"
+}
@@ -19,18 +19,33 @@ func (Menu) TableName() string {
 	return "menus"
 }
 
+// TargetType はメニュー項目の目標重量の指定方法
+type TargetType string
+
+const (
+	TargetTypeFixed       TargetType = "fixed"        // 固定重量（TargetWeight）
+	TargetTypePercentE1RM TargetType = "percent_e1rm" // 推定1RMに対する割合（TargetPercentage）
+	TargetTypeRPE         TargetType = "rpe"          // 目標レップ数とRPE（TargetRPE）
+)
+
 // MenuItem はメニュー内の種目設定を表す
 type MenuItem struct {
-	ID           uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
-	MenuID       uint64    `json:"menu_id" gorm:"not null;index"`
-	ExerciseID   uint64    `json:"exercise_id" gorm:"not null;index"`
-	OrderNumber  uint8     `json:"order_number" gorm:"not null"`
-	TargetSets   uint8     `json:"target_sets" gorm:"not null;default:3"`
-	TargetReps   uint16    `json:"target_reps" gorm:"not null;default:10"`
-	TargetWeight *float64  `json:"target_weight" gorm:"type:decimal(6,2)"`
-	Note         *string   `json:"note" gorm:"type:text"`
-	CreatedAt    time.Time `json:"created_at"`
-	Exercise     *Exercise `json:"exercise,omitempty" gorm:"foreignKey:ExerciseID"`
+	ID               uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	MenuID           uint64     `json:"menu_id" gorm:"not null;index"`
+	ExerciseID       uint64     `json:"exercise_id" gorm:"not null;index"`
+	OrderNumber      uint8      `json:"order_number" gorm:"not null"`
+	TargetSets       uint8      `json:"target_sets" gorm:"not null;default:3"`
+	TargetReps       uint16     `json:"target_reps" gorm:"not null;default:10"`
+	TargetType       TargetType `json:"target_type" gorm:"type:varchar(20);not null;default:fixed"`
+	TargetWeight     *float64   `json:"target_weight" gorm:"type:decimal(6,2)"`
+	TargetPercentage *float64   `json:"target_percentage" gorm:"type:decimal(5,2)"`
+	TargetRPE        *float64   `json:"target_rpe" gorm:"type:decimal(3,1)"`
+	Note             *string    `json:"note" gorm:"type:text"`
+	CreatedAt        time.Time  `json:"created_at"`
+	Exercise         *Exercise  `json:"exercise,omitempty" gorm:"foreignKey:ExerciseID"`
+
+	// ResolvedWeight は直近の記録から算出した実際に扱う重量（保存しない）
+	ResolvedWeight *float64 `json:"resolved_weight" gorm:"-"`
 }
 
 func (MenuItem) TableName() string {
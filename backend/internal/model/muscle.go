@@ -0,0 +1,29 @@
+package model
+
+// Muscle は筋肉の階層の1要素を表す
+// 最上位（ParentID が nil）は部位で、Key は MuscleGroup と同じ値になる
+type Muscle struct {
+	ID          uint64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Key         string      `json:"key" gorm:"size:30;not null;uniqueIndex"`
+	Name        string      `json:"name" gorm:"size:50;not null"`
+	ParentID    *uint64     `json:"parent_id"`
+	MuscleGroup MuscleGroup `json:"muscle_group" gorm:"type:muscle_group_type;not null"`
+	Children    []Muscle    `json:"children,omitempty" gorm:"-"`
+}
+
+func (Muscle) TableName() string {
+	return "muscles"
+}
+
+// ExerciseMuscle は種目と筋肉の対応を表す
+// Contribution は種目への関与の度合い（0〜1）で、集計では種目ごとの合計が1になるように按分する
+type ExerciseMuscle struct {
+	ExerciseID   uint64  `json:"exercise_id" gorm:"primaryKey"`
+	MuscleID     uint64  `json:"muscle_id" gorm:"primaryKey"`
+	Contribution float64 `json:"contribution" gorm:"type:decimal(3,2);not null"`
+	Muscle       *Muscle `json:"muscle,omitempty" gorm:"foreignKey:MuscleID"`
+}
+
+func (ExerciseMuscle) TableName() string {
+	return "exercise_muscles"
+}
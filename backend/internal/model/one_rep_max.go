@@ -0,0 +1,44 @@
+package model
+
+import "math"
+
+// OneRepMaxFormula は推定1RMの算出式
+type OneRepMaxFormula string
+
+const (
+	OneRepMaxFormulaEpley    OneRepMaxFormula = "epley"
+	OneRepMaxFormulaBrzycki  OneRepMaxFormula = "brzycki"
+	OneRepMaxFormulaLombardi OneRepMaxFormula = "lombardi"
+)
+
+// IsValid は対応している算出式かどうかを返す
+func (f OneRepMaxFormula) IsValid() bool {
+	switch f {
+	case OneRepMaxFormulaEpley, OneRepMaxFormulaBrzycki, OneRepMaxFormulaLombardi:
+		return true
+	}
+	return false
+}
+
+// Estimate は重量とレップ数から推定1RMを算出する
+// どの式でも1レップの場合は重量そのものを1RMとみなす
+func (f OneRepMaxFormula) Estimate(weight float64, reps uint16) float64 {
+	if weight <= 0 || reps == 0 {
+		return 0
+	}
+	if reps == 1 {
+		return weight
+	}
+
+	r := float64(reps)
+	switch f {
+	case OneRepMaxFormulaBrzycki:
+		// 37レップ以上は式が成り立たないため Epley 式で代用する
+		if reps < 37 {
+			return weight * 36 / (37 - r)
+		}
+	case OneRepMaxFormulaLombardi:
+		return weight * math.Pow(r, 0.10)
+	}
+	return weight * (1 + r/30)
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+)
+
+// RecordType は自己ベストの種類
+type RecordType string
+
+const (
+	RecordTypeMaxWeight          RecordType = "max_weight"            // 最大重量
+	RecordTypeEstimatedOneRepMax RecordType = "estimated_one_rep_max" // 推定1RM（Epley式）
+	RecordTypeMaxRepsAtWeight    RecordType = "max_reps_at_weight"    // 同じ重量以上での最多レップ数
+	RecordTypeSessionVolume      RecordType = "session_volume"        // 1回のトレーニングでの総ボリューム
+)
+
+// PersonalRecord は自己ベストを更新した記録を表す（更新のたびに1行追加される履歴）
+type PersonalRecord struct {
+	ID            uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID        uint64     `json:"user_id" gorm:"not null;index"`
+	ExerciseID    uint64     `json:"exercise_id" gorm:"not null"`
+	WorkoutID     uint64     `json:"workout_id" gorm:"not null;index"`
+	WorkoutSetID  *uint64    `json:"workout_set_id"`
+	RecordType    RecordType `json:"record_type" gorm:"type:varchar(30);not null"`
+	Weight        float64    `json:"weight" gorm:"type:decimal(6,2);not null"`
+	Reps          uint16     `json:"reps" gorm:"not null"`
+	Value         float64    `json:"value" gorm:"type:decimal(10,2);not null"`
+	PreviousValue *float64   `json:"previous_value" gorm:"type:decimal(10,2)"`
+	AchievedOn    time.Time  `json:"achieved_on" gorm:"type:date;not null"`
+	CreatedAt     time.Time  `json:"created_at"`
+	Exercise      *Exercise  `json:"exercise,omitempty" gorm:"foreignKey:ExerciseID"`
+}
+
+func (PersonalRecord) TableName() string {
+	return "personal_records"
+}
@@ -5,16 +5,17 @@ import (
 )
 
 type User struct {
-	ID           uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
-	Email        string     `json:"email" gorm:"uniqueIndex;size:255;not null"`
-	PasswordHash string     `json:"-" gorm:"size:255;not null"`
-	Name         string     `json:"name" gorm:"size:100;not null"`
-	Height       *float64   `json:"height" gorm:"type:decimal(5,2)"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
+	ID                 uint64        `json:"id" gorm:"primaryKey;autoIncrement"`
+	Email              string        `json:"email" gorm:"uniqueIndex;size:255;not null"`
+	PasswordHash       string        `json:"-" gorm:"size:255;not null"`
+	Name               string        `json:"name" gorm:"size:100;not null"`
+	Height             *float64      `json:"height" gorm:"type:decimal(5,2)"`
+	AvailableEquipment EquipmentList `json:"available_equipment" gorm:"type:varchar(20)[]"` // 使える器具（nil の場合は制限しない）
+	Locale             *Locale       `json:"locale" gorm:"size:10"`                         // 表示言語（nil の場合は Accept-Language に従う）
+	CreatedAt          time.Time     `json:"created_at"`
+	UpdatedAt          time.Time     `json:"updated_at"`
 }
 
 func (User) TableName() string {
 	return "users"
 }
-
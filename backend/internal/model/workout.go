@@ -12,6 +12,11 @@ type Workout struct {
 	CreatedAt time.Time    `json:"created_at"`
 	UpdatedAt time.Time    `json:"updated_at"`
 	Sets      []WorkoutSet `json:"sets,omitempty" gorm:"foreignKey:WorkoutID"`
+
+	// NewPersonalRecords は作成・更新時に検出した自己ベスト（保存しない）
+	NewPersonalRecords []PersonalRecord `json:"new_personal_records,omitempty" gorm:"-"`
+	// NewAchievements は作成・更新で新たに獲得した実績（保存しない）
+	NewAchievements []UserAchievement `json:"new_achievements,omitempty" gorm:"-"`
 }
 
 func (Workout) TableName() string {
@@ -25,6 +30,7 @@ type WorkoutSet struct {
 	SetNumber  uint8     `json:"set_number" gorm:"not null"`
 	Weight     float64   `json:"weight" gorm:"type:decimal(6,2);not null"`
 	Reps       uint16    `json:"reps" gorm:"not null"`
+	RPE        *float64  `json:"rpe" gorm:"type:decimal(3,1)"`
 	CreatedAt  time.Time `json:"created_at"`
 	Exercise   *Exercise `json:"exercise,omitempty" gorm:"foreignKey:ExerciseID"`
 }
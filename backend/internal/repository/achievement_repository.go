@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"github.com/training-memo/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AchievementRepository struct {
+	db *gorm.DB
+}
+
+func NewAchievementRepository(db *gorm.DB) *AchievementRepository {
+	return &AchievementRepository{db: db}
+}
+
+// Create は獲得した実績を保存する（獲得済みの実績は無視する）
+func (r *AchievementRepository) Create(achievement *model.UserAchievement) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(achievement).Error
+}
+
+func (r *AchievementRepository) FindByUserID(userID uint64) ([]model.UserAchievement, error) {
+	var achievements []model.UserAchievement
+	err := r.db.Where("user_id = ?", userID).Order("earned_on ASC, id ASC").Find(&achievements).Error
+	if err != nil {
+		return nil, err
+	}
+	return achievements, nil
+}
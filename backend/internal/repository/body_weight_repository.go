@@ -59,6 +59,18 @@ func (r *BodyWeightRepository) FindByUserIDAndDateRange(userID uint64, startDate
 	return records, nil
 }
 
+// FindNearest は指定日に最も近い日付の体重記録を取得する（同じ差の場合は前の記録を優先）
+func (r *BodyWeightRepository) FindNearest(userID uint64, date time.Time) (*model.BodyWeight, error) {
+	var record model.BodyWeight
+	err := r.db.Where("user_id = ?", userID).
+		Order(gorm.Expr("ABS(date - ?::date), date ASC", date.Format("2006-01-02"))).
+		First(&record).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
 func (r *BodyWeightRepository) Update(record *model.BodyWeight) error {
 	return r.db.Save(record).Error
 }
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/training-memo/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// summarySelect は workout_sets から daily_exercise_summary の行を求めるSELECT文
+// 推定1RMは oneRepMaxExpr と同じ式で計算式ごとに求める
+var summarySelect = fmt.Sprintf(`SELECT workouts.user_id, workout_sets.exercise_id, workouts.date,
+	COUNT(*), SUM(workout_sets.reps), SUM(workout_sets.weight * workout_sets.reps), MAX(workout_sets.weight),
+	MAX(%s), MAX(%s), MAX(%s)
+FROM workout_sets
+JOIN workouts ON workouts.id = workout_sets.workout_id`,
+	oneRepMaxExpr(model.OneRepMaxFormulaEpley),
+	oneRepMaxExpr(model.OneRepMaxFormulaBrzycki),
+	oneRepMaxExpr(model.OneRepMaxFormulaLombardi),
+)
+
+const summaryInsert = `INSERT INTO daily_exercise_summary
+	(user_id, exercise_id, date, set_count, total_reps, total_volume, max_weight, max_e1rm_epley, max_e1rm_brzycki, max_e1rm_lombardi)
+`
+
+const summaryGroup = " GROUP BY workouts.user_id, workout_sets.exercise_id, workouts.date"
+
+// refreshDailySummary はユーザーの指定日の集計を workout_sets から作り直す
+// ワークアウトを変更したトランザクションの中で呼び出す
+func refreshDailySummary(tx *gorm.DB, userID uint64, date time.Time) error {
+	day := date.Format("2006-01-02")
+	if err := tx.Where("user_id = ? AND date = ?", userID, day).Delete(&model.DailyExerciseSummary{}).Error; err != nil {
+		return fmt.Errorf("deleting daily exercise summary: %w", err)
+	}
+	if err := tx.Exec(summaryInsert+summarySelect+" WHERE workouts.user_id = ? AND workouts.date = ?"+summaryGroup, userID, day).Error; err != nil {
+		return fmt.Errorf("inserting daily exercise summary: %w", err)
+	}
+	return nil
+}
+
+// refreshExerciseSummary はユーザーの統合元・統合先の種目の集計を workout_sets から作り直す
+// 種目の統合でセットを付け替えたトランザクションの中で呼び出す
+func refreshExerciseSummary(tx *gorm.DB, userID, sourceID, targetID uint64) error {
+	if err := tx.Where("user_id = ? AND exercise_id IN ?", userID, []uint64{sourceID, targetID}).
+		Delete(&model.DailyExerciseSummary{}).Error; err != nil {
+		return fmt.Errorf("deleting daily exercise summary: %w", err)
+	}
+	if err := tx.Exec(summaryInsert+summarySelect+" WHERE workouts.user_id = ? AND workout_sets.exercise_id = ?"+summaryGroup, userID, targetID).Error; err != nil {
+		return fmt.Errorf("inserting daily exercise summary: %w", err)
+	}
+	return nil
+}
+
+// RebuildDailySummaries は集計を workout_sets から作り直す（userID が0の場合は全ユーザー）
+func (r *WorkoutRepository) RebuildDailySummaries(userID uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if userID == 0 {
+			if err := tx.Exec("DELETE FROM daily_exercise_summary").Error; err != nil {
+				return fmt.Errorf("deleting daily exercise summary: %w", err)
+			}
+			return tx.Exec(summaryInsert + summarySelect + summaryGroup).Error
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&model.DailyExerciseSummary{}).Error; err != nil {
+			return fmt.Errorf("deleting daily exercise summary: %w", err)
+		}
+		return tx.Exec(summaryInsert+summarySelect+" WHERE workouts.user_id = ?"+summaryGroup, userID).Error
+	})
+}
+
+// summaryOneRepMaxColumn は計算式に対応する推定1RMの列を返す
+func summaryOneRepMaxColumn(formula model.OneRepMaxFormula) string {
+	switch formula {
+	case model.OneRepMaxFormulaBrzycki:
+		return "daily_exercise_summary.max_e1rm_brzycki"
+	case model.OneRepMaxFormulaLombardi:
+		return "daily_exercise_summary.max_e1rm_lombardi"
+	}
+	return "daily_exercise_summary.max_e1rm_epley"
+}
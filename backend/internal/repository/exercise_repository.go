@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/training-memo/backend/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ExerciseRepository struct {
@@ -15,8 +19,8 @@ func NewExerciseRepository(db *gorm.DB) *ExerciseRepository {
 
 func (r *ExerciseRepository) FindAll(userID uint64) ([]model.Exercise, error) {
 	var exercises []model.Exercise
-	// プリセット種目 + ユーザーのカスタム種目
-	if err := r.db.Where("is_custom = ? OR user_id = ?", false, userID).
+	// プリセット種目 + ユーザーのカスタム種目（アーカイブ済みを除く）
+	if err := r.db.Preload("Muscles.Muscle").Where("(is_custom = ? OR user_id = ?) AND archived_at IS NULL", false, userID).
 		Order("muscle_group, name").
 		Find(&exercises).Error; err != nil {
 		return nil, err
@@ -24,17 +28,175 @@ func (r *ExerciseRepository) FindAll(userID uint64) ([]model.Exercise, error) {
 	return exercises, nil
 }
 
+// ExerciseSort は種目一覧の並び順
+type ExerciseSort string
+
+const (
+	ExerciseSortDefault  ExerciseSort = ""         // 部位・名前順
+	ExerciseSortRecent   ExerciseSort = "recent"   // 最後に記録した日が新しい順
+	ExerciseSortFrequent ExerciseSort = "frequent" // 記録したワークアウトが多い順
+	ExerciseSortFavorite ExerciseSort = "favorite" // お気に入りを先頭に、記録したワークアウトが多い順
+)
+
+// IsValid は対応している並び順かどうかを返す
+func (s ExerciseSort) IsValid() bool {
+	switch s {
+	case ExerciseSortDefault, ExerciseSortRecent, ExerciseSortFrequent, ExerciseSortFavorite:
+		return true
+	}
+	return false
+}
+
+// order は並び順のSQLを返す（記録のない種目は部位・名前順で後ろに並べる）
+func (s ExerciseSort) order() string {
+	const byName = "exercises.muscle_group, exercises.name"
+	switch s {
+	case ExerciseSortRecent:
+		return "exercise_usage.last_used_on DESC NULLS LAST, " + byName
+	case ExerciseSortFrequent:
+		return "exercise_usage.use_count DESC NULLS LAST, " + byName
+	case ExerciseSortFavorite:
+		return "favorite_exercises.user_id IS NULL, exercise_usage.use_count DESC NULLS LAST, " + byName
+	}
+	return byName
+}
+
+// ExerciseFilter は種目一覧の絞り込み条件と並び順
+type ExerciseFilter struct {
+	MuscleGroup   model.MuscleGroup   // 空の場合は制限しない
+	Equipment     model.EquipmentList // いずれかの器具を使う種目（空の場合は制限しない）
+	AvailableOnly bool                // ユーザーの使える器具で実施できる種目のみ
+	Sort          ExerciseSort
+}
+
+// FindFiltered はプリセット種目とユーザーのカスタム種目（アーカイブ済みを除く）を条件で絞り込んで取得する
+// お気に入りかどうかとユーザーの記録の回数・最終日もあわせて取得する
+func (r *ExerciseRepository) FindFiltered(userID uint64, filter ExerciseFilter) ([]model.Exercise, error) {
+	query := withUsage(r.db.Preload("Muscles.Muscle"), userID).
+		Where("(exercises.is_custom = ? OR exercises.user_id = ?) AND exercises.archived_at IS NULL", false, userID)
+	if filter.MuscleGroup != "" {
+		query = query.Where("exercises.muscle_group = ?", filter.MuscleGroup)
+	}
+	if len(filter.Equipment) > 0 {
+		query = query.Where("exercises.equipment && ?::varchar[]", filter.Equipment)
+	}
+	if filter.AvailableOnly {
+		// 自重は器具の設定にかかわらず使える
+		query = query.Where(`NOT EXISTS (
+			SELECT 1 FROM users
+			WHERE users.id = ? AND users.available_equipment IS NOT NULL
+				AND NOT (exercises.equipment <@ array_append(users.available_equipment, 'bodyweight'))
+		)`, userID)
+	}
+
+	var exercises []model.Exercise
+	if err := query.Order(filter.Sort.order()).Find(&exercises).Error; err != nil {
+		return nil, err
+	}
+	return exercises, nil
+}
+
+// withUsage はお気に入りかどうかとユーザーの記録の回数・最終日をあわせて取得する
+func withUsage(query *gorm.DB, userID uint64) *gorm.DB {
+	return query.
+		Select("exercises.*, favorite_exercises.user_id IS NOT NULL AS is_favorite, exercise_usage.last_used_on, COALESCE(exercise_usage.use_count, 0) AS use_count").
+		Joins("LEFT JOIN favorite_exercises ON favorite_exercises.exercise_id = exercises.id AND favorite_exercises.user_id = ?", userID).
+		Joins(`LEFT JOIN (
+			SELECT workout_sets.exercise_id, MAX(workouts.date) AS last_used_on, COUNT(DISTINCT workouts.id) AS use_count
+			FROM workout_sets
+			JOIN workouts ON workouts.id = workout_sets.workout_id
+			WHERE workouts.user_id = ?
+			GROUP BY workout_sets.exercise_id
+		) AS exercise_usage ON exercise_usage.exercise_id = exercises.id`, userID)
+}
+
+// FindVariations は種目のバリエーションのうちユーザーが使えるもの（アーカイブ済みを除く）を取得する
+func (r *ExerciseRepository) FindVariations(userID, parentID uint64) ([]model.Exercise, error) {
+	var exercises []model.Exercise
+	if err := withUsage(r.db.Preload("Muscles.Muscle"), userID).
+		Where("exercises.parent_id = ? AND (exercises.is_custom = ? OR exercises.user_id = ?) AND exercises.archived_at IS NULL", parentID, false, userID).
+		Order("exercises.name").
+		Find(&exercises).Error; err != nil {
+		return nil, err
+	}
+	return exercises, nil
+}
+
+// progressionChainSQL は種目から易しい方向と難しい方向に段階をたどった種目のIDを求める
+// 難しい方向は分岐することがあり、ユーザーが使えない種目とアーカイブ済みの種目の先はたどらない
+const progressionChainSQL = `
+WITH RECURSIVE easier AS (
+	SELECT id, progresses_from_id, 0 AS depth FROM exercises WHERE id = @exercise
+	UNION ALL
+	SELECT exercises.id, exercises.progresses_from_id, easier.depth + 1
+	FROM exercises
+	JOIN easier ON exercises.id = easier.progresses_from_id
+	WHERE easier.depth < @max_depth
+), harder AS (
+	SELECT id, 0 AS depth FROM exercises WHERE id = @exercise
+	UNION ALL
+	SELECT exercises.id, harder.depth + 1
+	FROM exercises
+	JOIN harder ON exercises.progresses_from_id = harder.id
+	WHERE harder.depth < @max_depth
+		AND (exercises.is_custom = false OR exercises.user_id = @user)
+		AND exercises.archived_at IS NULL
+)
+SELECT id FROM easier
+UNION
+SELECT id FROM harder`
+
+// progressionMaxDepth は段階をたどる最大の数（誤って循環している場合の打ち切り）
+const progressionMaxDepth = 20
+
+// FindProgressionChain は種目を含む段階的な種目の一覧を取得する
+// 指定した種目以外はユーザーが使える種目（アーカイブ済みを除く）のみを返し、記録の回数・最終日もあわせて取得する
+func (r *ExerciseRepository) FindProgressionChain(userID, exerciseID uint64) ([]model.Exercise, error) {
+	var ids []uint64
+	if err := r.db.Raw(progressionChainSQL, map[string]interface{}{
+		"exercise":  exerciseID,
+		"user":      userID,
+		"max_depth": progressionMaxDepth,
+	}).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+
+	var exercises []model.Exercise
+	if err := withUsage(r.db.Preload("Muscles.Muscle"), userID).
+		Where("exercises.id IN ?", ids).
+		Where("exercises.id = ? OR ((exercises.is_custom = ? OR exercises.user_id = ?) AND exercises.archived_at IS NULL)", exerciseID, false, userID).
+		Order("exercises.id").
+		Find(&exercises).Error; err != nil {
+		return nil, err
+	}
+	return exercises, nil
+}
+
 func (r *ExerciseRepository) FindByID(id uint64) (*model.Exercise, error) {
 	var exercise model.Exercise
-	if err := r.db.First(&exercise, id).Error; err != nil {
+	if err := r.db.Preload("Muscles.Muscle").
+		Preload("Media", func(db *gorm.DB) *gorm.DB { return db.Order("position, id") }).
+		First(&exercise, id).Error; err != nil {
 		return nil, err
 	}
 	return &exercise, nil
 }
 
+// FindByIDs は指定したIDの種目をまとめて取得する（存在しないIDは含まない）
+func (r *ExerciseRepository) FindByIDs(ids []uint64) ([]model.Exercise, error) {
+	var exercises []model.Exercise
+	if len(ids) == 0 {
+		return exercises, nil
+	}
+	if err := r.db.Where("id IN ?", ids).Find(&exercises).Error; err != nil {
+		return nil, err
+	}
+	return exercises, nil
+}
+
 func (r *ExerciseRepository) FindByMuscleGroup(muscleGroup model.MuscleGroup, userID uint64) ([]model.Exercise, error) {
 	var exercises []model.Exercise
-	if err := r.db.Where("muscle_group = ? AND (is_custom = ? OR user_id = ?)", muscleGroup, false, userID).
+	if err := r.db.Preload("Muscles.Muscle").Where("muscle_group = ? AND (is_custom = ? OR user_id = ?) AND archived_at IS NULL", muscleGroup, false, userID).
 		Order("name").
 		Find(&exercises).Error; err != nil {
 		return nil, err
@@ -47,17 +209,255 @@ func (r *ExerciseRepository) Create(exercise *model.Exercise) error {
 }
 
 func (r *ExerciseRepository) Update(exercise *model.Exercise) error {
-	return r.db.Save(exercise).Error
+	return r.db.Omit("Muscles", "Media").Save(exercise).Error
+}
+
+// ReplaceMuscles は種目と筋肉の対応を置き換える
+func (r *ExerciseRepository) ReplaceMuscles(exerciseID uint64, muscles []model.ExerciseMuscle) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("exercise_id = ?", exerciseID).Delete(&model.ExerciseMuscle{}).Error; err != nil {
+			return err
+		}
+		for i := range muscles {
+			muscles[i].ExerciseID = exerciseID
+			if err := tx.Omit("Muscle").Create(&muscles[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AddFavorite は種目をお気に入りに登録する（登録済みの場合は何もしない）
+func (r *ExerciseRepository) AddFavorite(userID, exerciseID uint64) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.FavoriteExercise{UserID: userID, ExerciseID: exerciseID}).Error
+}
+
+// RemoveFavorite は種目をお気に入りから外す
+func (r *ExerciseRepository) RemoveFavorite(userID, exerciseID uint64) error {
+	return r.db.Where("user_id = ? AND exercise_id = ?", userID, exerciseID).Delete(&model.FavoriteExercise{}).Error
+}
+
+// searchMinScore は検索結果に含める類似度の下限
+const searchMinScore = 0.3
+
+// searchSQL は種目名と別名の一致度から種目ごとのスコアを求める
+// 完全一致は1、部分一致は0.8、それ以外は pg_trgm の類似度（単語単位の類似度は0.9倍）とする
+const searchSQL = `
+SELECT candidates.exercise_id, MAX(candidates.score) AS score
+FROM (
+	SELECT exercises.id AS exercise_id, GREATEST(
+		CASE WHEN lower(exercises.name) = @query THEN 1 WHEN lower(exercises.name) LIKE @pattern THEN 0.8 ELSE 0 END,
+		similarity(lower(exercises.name), @query),
+		word_similarity(@query, lower(exercises.name)) * 0.9
+	) AS score
+	FROM exercises
+	WHERE (exercises.is_custom = FALSE OR exercises.user_id = @user) AND exercises.archived_at IS NULL
+	UNION ALL
+	SELECT exercise_aliases.exercise_id, GREATEST(
+		CASE WHEN exercise_aliases.alias = @query THEN 1 WHEN exercise_aliases.alias LIKE @pattern THEN 0.8 ELSE 0 END,
+		similarity(exercise_aliases.alias, @query),
+		word_similarity(@query, exercise_aliases.alias) * 0.9
+	)
+	FROM exercise_aliases
+	JOIN exercises ON exercises.id = exercise_aliases.exercise_id
+	WHERE (exercise_aliases.user_id IS NULL OR exercise_aliases.user_id = @user)
+		AND (exercises.is_custom = FALSE OR exercises.user_id = @user) AND exercises.archived_at IS NULL
+) AS candidates
+GROUP BY candidates.exercise_id
+HAVING MAX(candidates.score) >= @min_score
+ORDER BY score DESC, candidates.exercise_id
+LIMIT @limit`
+
+// Search は正規化済みの検索語に一致する種目をスコアの高い順に取得する（アーカイブ済みを除く）
+func (r *ExerciseRepository) Search(userID uint64, query string, limit int) ([]ExerciseMatch, error) {
+	pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(query) + "%"
+
+	var matches []ExerciseMatch
+	if err := r.db.Raw(searchSQL, map[string]interface{}{
+		"query":     query,
+		"pattern":   pattern,
+		"user":      userID,
+		"min_score": searchMinScore,
+		"limit":     limit,
+	}).Scan(&matches).Error; err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// FindAliases は種目の別名のうち、共通のものとユーザーが登録したものを取得する
+func (r *ExerciseRepository) FindAliases(userID, exerciseID uint64) ([]model.ExerciseAlias, error) {
+	var aliases []model.ExerciseAlias
+	if err := r.db.Where("exercise_id = ? AND (user_id IS NULL OR user_id = ?)", exerciseID, userID).
+		Order("user_id NULLS FIRST, id").
+		Find(&aliases).Error; err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
+
+func (r *ExerciseRepository) FindAliasByID(id uint64) (*model.ExerciseAlias, error) {
+	var alias model.ExerciseAlias
+	if err := r.db.First(&alias, id).Error; err != nil {
+		return nil, err
+	}
+	return &alias, nil
+}
+
+func (r *ExerciseRepository) CreateAlias(alias *model.ExerciseAlias) error {
+	return r.db.Create(alias).Error
+}
+
+func (r *ExerciseRepository) DeleteAlias(id uint64) error {
+	return r.db.Delete(&model.ExerciseAlias{}, id).Error
+}
+
+type ExerciseMatch struct {
+	ExerciseID uint64  `json:"exercise_id"`
+	Score      float64 `json:"score"`
+}
+
+func (r *ExerciseRepository) FindMediaByID(id uint64) (*model.ExerciseMedia, error) {
+	var media model.ExerciseMedia
+	if err := r.db.First(&media, id).Error; err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// CreateMedia は種目の添付ファイルを末尾に追加する
+func (r *ExerciseRepository) CreateMedia(media *model.ExerciseMedia) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var position int
+		if err := tx.Model(&model.ExerciseMedia{}).Where("exercise_id = ?", media.ExerciseID).
+			Select("COALESCE(MAX(position) + 1, 0)").Scan(&position).Error; err != nil {
+			return err
+		}
+		media.Position = position
+		return tx.Create(media).Error
+	})
+}
+
+func (r *ExerciseRepository) DeleteMedia(id uint64) error {
+	return r.db.Delete(&model.ExerciseMedia{}, id).Error
+}
+
+func (r *ExerciseRepository) CountMedia(exerciseID uint64) (int64, error) {
+	var count int64
+	if err := r.db.Model(&model.ExerciseMedia{}).Where("exercise_id = ?", exerciseID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindTranslations は種目の指定した言語の翻訳を取得する
+func (r *ExerciseRepository) FindTranslations(locale model.Locale, exerciseIDs []uint64) ([]model.ExerciseTranslation, error) {
+	var translations []model.ExerciseTranslation
+	if len(exerciseIDs) == 0 {
+		return translations, nil
+	}
+	if err := r.db.Where("locale = ? AND exercise_id IN ?", locale, exerciseIDs).Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// FindMuscles は筋肉の一覧を部位ごとに取得する
+func (r *ExerciseRepository) FindMuscles() ([]model.Muscle, error) {
+	var muscles []model.Muscle
+	if err := r.db.Order("muscle_group, id").Find(&muscles).Error; err != nil {
+		return nil, err
+	}
+	return muscles, nil
 }
 
 func (r *ExerciseRepository) Delete(id uint64) error {
 	return r.db.Delete(&model.Exercise{}, id).Error
 }
 
+// Merge はユーザーのカスタム種目 sourceID の記録をすべて targetID に付け替えてから削除する
+// ユーザーのセット・メニュー・目標・お気に入り・別名を移し、日別集計は統合先について作り直す
+// 移したセットは同じワークアウトにある統合先のセットの後ろに番号を振り直す
+// 統合元の自己ベスト履歴は削除されるため、同じトランザクションで rebuild により統合先を作り直す
+// 画像・動画は統合先がユーザーのカスタム種目なら移し、それ以外は統合元と一緒に削除してそのストレージのキーを返す
+func (r *ExerciseRepository) Merge(userID, sourceID, targetID uint64, rebuild *RecordRebuild) ([]string, error) {
+	params := map[string]interface{}{"user": userID, "source": sourceID, "target": targetID}
+	var removedKeys []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`UPDATE workout_sets SET set_number = numbered.set_number, exercise_id = @target
+			FROM (
+				SELECT ws.id,
+					COALESCE((SELECT MAX(t.set_number) FROM workout_sets t
+						WHERE t.workout_id = ws.workout_id AND t.exercise_id = @target), 0)
+					+ ROW_NUMBER() OVER (PARTITION BY ws.workout_id ORDER BY ws.set_number, ws.id) AS set_number
+				FROM workout_sets ws
+				JOIN workouts w ON w.id = ws.workout_id
+				WHERE ws.exercise_id = @source AND w.user_id = @user
+			) numbered
+			WHERE workout_sets.id = numbered.id`, params).Error; err != nil {
+			return fmt.Errorf("moving workout sets: %w", err)
+		}
+		if err := tx.Exec(`UPDATE menu_items SET exercise_id = @target
+			WHERE exercise_id = @source AND menu_id IN (SELECT id FROM menus WHERE user_id = @user)`, params).Error; err != nil {
+			return fmt.Errorf("moving menu items: %w", err)
+		}
+		if err := tx.Model(&model.Goal{}).Where("exercise_id = ? AND user_id = ?", sourceID, userID).
+			Update("exercise_id", targetID).Error; err != nil {
+			return fmt.Errorf("moving goals: %w", err)
+		}
+		if err := tx.Exec(`INSERT INTO favorite_exercises (user_id, exercise_id, created_at)
+			SELECT user_id, @target, created_at FROM favorite_exercises WHERE exercise_id = @source AND user_id = @user
+			ON CONFLICT DO NOTHING`, params).Error; err != nil {
+			return fmt.Errorf("moving favorites: %w", err)
+		}
+		if err := tx.Exec(`INSERT INTO exercise_aliases (exercise_id, user_id, alias, created_at)
+			SELECT @target, user_id, alias, created_at FROM exercise_aliases WHERE exercise_id = @source AND user_id = @user
+			ON CONFLICT DO NOTHING`, params).Error; err != nil {
+			return fmt.Errorf("moving aliases: %w", err)
+		}
+		// 統合元を親や1つ前の段階にしている種目は統合先に付け替える（統合先自身は外し、親は1階層に保つ）
+		if err := tx.Exec(`UPDATE exercises
+			SET parent_id = NULLIF(COALESCE((SELECT parent_id FROM exercises WHERE id = @target), @target), id)
+			WHERE parent_id = @source`, params).Error; err != nil {
+			return fmt.Errorf("moving variations: %w", err)
+		}
+		if err := tx.Exec(`UPDATE exercises SET progresses_from_id = NULLIF(@target, id) WHERE progresses_from_id = @source`, params).Error; err != nil {
+			return fmt.Errorf("moving progressions: %w", err)
+		}
+		if err := refreshExerciseSummary(tx, userID, sourceID, targetID); err != nil {
+			return err
+		}
+		if err := rebuildPersonalRecords(tx, rebuild); err != nil {
+			return err
+		}
+		// プリセット種目の画像・動画は全ユーザーに見えるため、移すのはユーザーのカスタム種目への統合のみ
+		if err := tx.Exec(`UPDATE exercise_media
+			SET exercise_id = @target,
+				position = position + (SELECT COALESCE(MAX(position) + 1, 0) FROM exercise_media WHERE exercise_id = @target)
+			WHERE exercise_id = @source
+				AND EXISTS (SELECT 1 FROM exercises WHERE id = @target AND is_custom AND user_id = @user)`, params).Error; err != nil {
+			return fmt.Errorf("moving media: %w", err)
+		}
+		if err := tx.Model(&model.ExerciseMedia{}).Where("exercise_id = ?", sourceID).
+			Pluck("storage_key", &removedKeys).Error; err != nil {
+			return fmt.Errorf("finding media: %w", err)
+		}
+		// exercise_muscles・exercise_media・favorite_exercises・exercise_aliases・personal_records は連動して削除される
+		return tx.Delete(&model.Exercise{}, sourceID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return removedKeys, nil
+}
+
+// FindCustomByUserID はユーザーのカスタム種目をアーカイブ済みも含めて取得する（アーカイブ済みは後ろに並べる）
 func (r *ExerciseRepository) FindCustomByUserID(userID uint64) ([]model.Exercise, error) {
 	var exercises []model.Exercise
-	if err := r.db.Where("is_custom = ? AND user_id = ?", true, userID).
-		Order("muscle_group, name").
+	if err := r.db.Preload("Muscles.Muscle").Where("is_custom = ? AND user_id = ?", true, userID).
+		Order("archived_at IS NOT NULL, muscle_group, name").
 		Find(&exercises).Error; err != nil {
 		return nil, err
 	}
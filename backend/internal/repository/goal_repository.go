@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"github.com/training-memo/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type GoalRepository struct {
+	db *gorm.DB
+}
+
+func NewGoalRepository(db *gorm.DB) *GoalRepository {
+	return &GoalRepository{db: db}
+}
+
+func (r *GoalRepository) Create(goal *model.Goal) error {
+	return r.db.Create(goal).Error
+}
+
+func (r *GoalRepository) FindByID(id uint64) (*model.Goal, error) {
+	var goal model.Goal
+	err := r.db.Preload("Exercise").First(&goal, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
+
+func (r *GoalRepository) FindByUserID(userID uint64) ([]model.Goal, error) {
+	var goals []model.Goal
+	err := r.db.Preload("Exercise").Where("user_id = ?", userID).Order("created_at DESC").Find(&goals).Error
+	if err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+func (r *GoalRepository) Update(goal *model.Goal) error {
+	return r.db.Omit("Exercise").Save(goal).Error
+}
+
+func (r *GoalRepository) Delete(id uint64) error {
+	return r.db.Delete(&model.Goal{}, id).Error
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/training-memo/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// RecordBaseline は再計算の起点より前に達成していた自己ベスト
+type RecordBaseline struct {
+	BestWeight   float64
+	BestE1RM     float64
+	BestVolume   float64
+	RepsByWeight map[float64]uint16 // 重量ごとの最多レップ数
+}
+
+// PersonalRecordDetector は起点より前の自己ベストと起点以降のセット履歴から自己ベストの更新を検出する
+type PersonalRecordDetector func(exerciseID uint64, baseline RecordBaseline, history []SetHistory) []*model.PersonalRecord
+
+// RecordRebuild はワークアウトの変更に合わせて作り直す自己ベストの範囲
+// From 以降の記録だけを作り直す（From がゼロ値の場合は全期間）
+type RecordRebuild struct {
+	UserID      uint64
+	ExerciseIDs []uint64
+	From        time.Time
+	Detect      PersonalRecordDetector
+}
+
+type PersonalRecordRepository struct {
+	db *gorm.DB
+}
+
+func NewPersonalRecordRepository(db *gorm.DB) *PersonalRecordRepository {
+	return &PersonalRecordRepository{db: db}
+}
+
+func (r *PersonalRecordRepository) FindByWorkoutID(workoutID uint64) ([]model.PersonalRecord, error) {
+	var records []model.PersonalRecord
+	if err := r.db.Preload("Exercise").
+		Where("workout_id = ?", workoutID).
+		Order("exercise_id, record_type, weight DESC").
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// FindByUserID は自己ベストの更新履歴を新しい順に取得（exerciseID が0の場合は全種目）
+func (r *PersonalRecordRepository) FindByUserID(userID uint64, exerciseID uint64) ([]model.PersonalRecord, error) {
+	var records []model.PersonalRecord
+	query := r.db.Preload("Exercise").Where("user_id = ?", userID)
+	if exerciseID != 0 {
+		query = query.Where("exercise_id = ?", exerciseID)
+	}
+	if err := query.Order("achieved_on DESC, id DESC").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// FindByUserIDAndDateRange は期間内に更新した自己ベストを日付順に取得
+func (r *PersonalRecordRepository) FindByUserIDAndDateRange(userID uint64, startDate, endDate time.Time) ([]model.PersonalRecord, error) {
+	var records []model.PersonalRecord
+	if err := r.db.Preload("Exercise").
+		Where("user_id = ? AND achieved_on >= ? AND achieved_on <= ?", userID, startDate.Format("2006-01-02"), endDate.Format("2006-01-02")).
+		Order("achieved_on ASC, exercise_id, record_type").
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// rebuildPersonalRecords は種目ごとに自己ベスト履歴の From 以降を作り直す
+// ワークアウトを変更したトランザクションの中で呼び出す（rebuild が nil の場合は何もしない）
+func rebuildPersonalRecords(tx *gorm.DB, rebuild *RecordRebuild) error {
+	if rebuild == nil {
+		return nil
+	}
+	from := rebuild.From.Format("2006-01-02")
+	for _, exerciseID := range rebuild.ExerciseIDs {
+		baseline, err := recordBaseline(tx, rebuild.UserID, exerciseID, from)
+		if err != nil {
+			return fmt.Errorf("finding personal record baseline: %w", err)
+		}
+		var history []SetHistory
+		if err := tx.Table("workout_sets").
+			Select("workout_sets.workout_id, workout_sets.id as set_id, workouts.date, workout_sets.set_number, workout_sets.weight, workout_sets.reps").
+			Joins("JOIN workouts ON workouts.id = workout_sets.workout_id").
+			Where("workouts.user_id = ? AND workout_sets.exercise_id = ? AND workouts.date >= ?", rebuild.UserID, exerciseID, from).
+			Order("workouts.date ASC, workout_sets.workout_id ASC, workout_sets.set_number ASC, workout_sets.id ASC").
+			Scan(&history).Error; err != nil {
+			return fmt.Errorf("finding set history: %w", err)
+		}
+
+		if err := tx.Where("user_id = ? AND exercise_id = ? AND achieved_on >= ?", rebuild.UserID, exerciseID, from).
+			Delete(&model.PersonalRecord{}).Error; err != nil {
+			return fmt.Errorf("deleting personal records: %w", err)
+		}
+		for _, record := range rebuild.Detect(exerciseID, baseline, history) {
+			if err := tx.Create(record).Error; err != nil {
+				return fmt.Errorf("creating personal record: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// recordBaseline は from より前の自己ベストと重量ごとの最多レップ数を集計する
+func recordBaseline(tx *gorm.DB, userID, exerciseID uint64, from string) (RecordBaseline, error) {
+	baseline := RecordBaseline{RepsByWeight: make(map[float64]uint16)}
+
+	var bests []struct {
+		RecordType model.RecordType
+		Value      float64
+	}
+	if err := tx.Model(&model.PersonalRecord{}).
+		Select("record_type, MAX(value) AS value").
+		Where("user_id = ? AND exercise_id = ? AND achieved_on < ?", userID, exerciseID, from).
+		Group("record_type").
+		Scan(&bests).Error; err != nil {
+		return baseline, err
+	}
+	for _, best := range bests {
+		switch best.RecordType {
+		case model.RecordTypeMaxWeight:
+			baseline.BestWeight = best.Value
+		case model.RecordTypeEstimatedOneRepMax:
+			baseline.BestE1RM = best.Value
+		case model.RecordTypeSessionVolume:
+			baseline.BestVolume = best.Value
+		}
+	}
+
+	var reps []struct {
+		Weight float64
+		Reps   uint16
+	}
+	if err := tx.Table("workout_sets").
+		Select("workout_sets.weight, MAX(workout_sets.reps) AS reps").
+		Joins("JOIN workouts ON workouts.id = workout_sets.workout_id").
+		Where("workouts.user_id = ? AND workout_sets.exercise_id = ? AND workouts.date < ?", userID, exerciseID, from).
+		Group("workout_sets.weight").
+		Scan(&reps).Error; err != nil {
+		return baseline, err
+	}
+	for _, r := range reps {
+		baseline.RepsByWeight[r.Weight] = r.Reps
+	}
+	return baseline, nil
+}
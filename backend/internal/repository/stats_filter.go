@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StatsBucket は統計の集計単位
+type StatsBucket string
+
+const (
+	StatsBucketNone  StatsBucket = ""
+	StatsBucketDay   StatsBucket = "day"
+	StatsBucketWeek  StatsBucket = "week"
+	StatsBucketMonth StatsBucket = "month"
+)
+
+// IsValid は対応している集計単位かどうかを返す
+func (b StatsBucket) IsValid() bool {
+	switch b {
+	case StatsBucketNone, StatsBucketDay, StatsBucketWeek, StatsBucketMonth:
+		return true
+	}
+	return false
+}
+
+// expr は集計単位の期間の開始日を求めるSQL式を返す（週は月曜始まり）
+// SQLに直接埋め込むため、対応している値以外は空文字を返す
+func (b StatsBucket) expr() string {
+	return b.exprOn("workouts.date")
+}
+
+// exprOn は column の日付を集計単位の期間の開始日にするSQL式を返す
+func (b StatsBucket) exprOn(column string) string {
+	switch b {
+	case StatsBucketDay:
+		return column
+	case StatsBucketWeek:
+		return "date_trunc('week', " + column + ")::date"
+	case StatsBucketMonth:
+		return "date_trunc('month', " + column + ")::date"
+	}
+	return ""
+}
+
+// StatsFilter は統計の集計期間と集計単位
+// From・To は両端を含み、nil の場合は制限しない
+// RollUpVariations が true の場合、種目ごとの統計ではバリエーションを親種目にまとめる
+type StatsFilter struct {
+	From             *time.Time
+	To               *time.Time
+	Bucket           StatsBucket
+	RollUpVariations bool
+}
+
+// apply はワークアウト日付の期間条件をクエリに追加する
+func (f StatsFilter) apply(query *gorm.DB) *gorm.DB {
+	return f.applyOn(query, "workouts.date")
+}
+
+// applyOn は column の日付に期間条件をクエリに追加する
+func (f StatsFilter) applyOn(query *gorm.DB, column string) *gorm.DB {
+	if f.From != nil {
+		query = query.Where(column+" >= ?", f.From.Format("2006-01-02"))
+	}
+	if f.To != nil {
+		query = query.Where(column+" <= ?", f.To.Format("2006-01-02"))
+	}
+	return query
+}
+
+// exerciseJoin は column の種目を集計する種目の exercises に結合する
+// バリエーションをまとめる場合は親種目に結合する
+func (f StatsFilter) exerciseJoin(column string) string {
+	if f.RollUpVariations {
+		return "JOIN exercises AS recorded_exercises ON recorded_exercises.id = " + column +
+			" JOIN exercises ON exercises.id = COALESCE(recorded_exercises.parent_id, recorded_exercises.id)"
+	}
+	return "JOIN exercises ON exercises.id = " + column
+}
+
+// applyExercise は column の種目を exerciseID に絞り込む条件をクエリに追加する
+// バリエーションをまとめる場合は exerciseID のバリエーションも含める
+func (f StatsFilter) applyExercise(query *gorm.DB, column string, exerciseID uint64) *gorm.DB {
+	if f.RollUpVariations {
+		return query.Where(column+" IN (SELECT id FROM exercises WHERE id = ? OR parent_id = ?)", exerciseID, exerciseID)
+	}
+	return query.Where(column+" = ?", exerciseID)
+}
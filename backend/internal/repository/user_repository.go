@@ -46,8 +46,26 @@ func (r *UserRepository) ExistsByEmail(email string) (bool, error) {
 }
 
 // DeleteWithAllData はユーザーに関連する全データをトランザクションで削除する
-func (r *UserRepository) DeleteWithAllData(userID uint64) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+// カスタム種目の画像・動画のファイルは残るため、削除したレコードのストレージのキーを返す
+func (r *UserRepository) DeleteWithAllData(userID uint64) ([]string, error) {
+	var mediaKeys []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// user_achievements
+		if err := tx.Where("user_id = ?", userID).Delete(&model.UserAchievement{}).Error; err != nil {
+			return err
+		}
+		// goals
+		if err := tx.Where("user_id = ?", userID).Delete(&model.Goal{}).Error; err != nil {
+			return err
+		}
+		// personal_records
+		if err := tx.Where("user_id = ?", userID).Delete(&model.PersonalRecord{}).Error; err != nil {
+			return err
+		}
+		// daily_exercise_summary
+		if err := tx.Where("user_id = ?", userID).Delete(&model.DailyExerciseSummary{}).Error; err != nil {
+			return err
+		}
 		// workout_sets（workoutsを通じてuserに紐づく）
 		if err := tx.Exec("DELETE FROM workout_sets WHERE workout_id IN (SELECT id FROM workouts WHERE user_id = ?)", userID).Error; err != nil {
 			return err
@@ -68,7 +86,20 @@ func (r *UserRepository) DeleteWithAllData(userID uint64) error {
 		if err := tx.Where("user_id = ?", userID).Delete(&model.BodyWeight{}).Error; err != nil {
 			return err
 		}
-		// カスタム種目
+		// favorite_exercises
+		if err := tx.Where("user_id = ?", userID).Delete(&model.FavoriteExercise{}).Error; err != nil {
+			return err
+		}
+		// exercise_aliases
+		if err := tx.Where("user_id = ?", userID).Delete(&model.ExerciseAlias{}).Error; err != nil {
+			return err
+		}
+		// カスタム種目（exercise_media は連動して削除される）
+		if err := tx.Model(&model.ExerciseMedia{}).
+			Where("exercise_id IN (SELECT id FROM exercises WHERE user_id = ? AND is_custom = true)", userID).
+			Pluck("storage_key", &mediaKeys).Error; err != nil {
+			return err
+		}
 		if err := tx.Where("user_id = ? AND is_custom = true", userID).Delete(&model.Exercise{}).Error; err != nil {
 			return err
 		}
@@ -78,5 +109,9 @@ func (r *UserRepository) DeleteWithAllData(userID uint64) error {
 		}
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return mediaKeys, nil
 }
 
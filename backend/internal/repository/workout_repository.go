@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/training-memo/backend/internal/model"
@@ -62,14 +63,25 @@ func (r *WorkoutRepository) Update(workout *model.Workout) error {
 	return r.db.Save(workout).Error
 }
 
-func (r *WorkoutRepository) Delete(id uint64) error {
+// Delete はワークアウトを削除し、同じトランザクションで集計と自己ベストを作り直す
+func (r *WorkoutRepository) Delete(id uint64, rebuild *RecordRebuild) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
+		var workout model.Workout
+		if err := tx.First(&workout, id).Error; err != nil {
+			return err
+		}
 		// セットを先に削除
 		if err := tx.Where("workout_id = ?", id).Delete(&model.WorkoutSet{}).Error; err != nil {
 			return err
 		}
 		// ワークアウトを削除
-		return tx.Delete(&model.Workout{}, id).Error
+		if err := tx.Delete(&model.Workout{}, id).Error; err != nil {
+			return err
+		}
+		if err := refreshDailySummary(tx, workout.UserID, workout.Date); err != nil {
+			return err
+		}
+		return rebuildPersonalRecords(tx, rebuild)
 	})
 }
 
@@ -90,7 +102,8 @@ func (r *WorkoutRepository) DeleteSetsByWorkoutID(workoutID uint64) error {
 }
 
 // CreateWithSets creates a workout and all its sets in a single transaction.
-func (r *WorkoutRepository) CreateWithSets(workout *model.Workout, sets []*model.WorkoutSet) error {
+// The daily summary and the personal records in rebuild are updated in the same transaction.
+func (r *WorkoutRepository) CreateWithSets(workout *model.Workout, sets []*model.WorkoutSet, rebuild *RecordRebuild) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(workout).Error; err != nil {
 			return err
@@ -101,13 +114,21 @@ func (r *WorkoutRepository) CreateWithSets(workout *model.Workout, sets []*model
 				return err
 			}
 		}
-		return nil
+		if err := refreshDailySummary(tx, workout.UserID, workout.Date); err != nil {
+			return err
+		}
+		return rebuildPersonalRecords(tx, rebuild)
 	})
 }
 
 // ReplaceSetsByWorkoutID deletes existing sets and inserts replacements atomically.
-func (r *WorkoutRepository) ReplaceSetsByWorkoutID(workoutID uint64, sets []*model.WorkoutSet) error {
+// The daily summary and the personal records in rebuild are updated in the same transaction.
+func (r *WorkoutRepository) ReplaceSetsByWorkoutID(workoutID uint64, sets []*model.WorkoutSet, rebuild *RecordRebuild) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
+		var workout model.Workout
+		if err := tx.First(&workout, workoutID).Error; err != nil {
+			return err
+		}
 		if err := tx.Where("workout_id = ?", workoutID).Delete(&model.WorkoutSet{}).Error; err != nil {
 			return err
 		}
@@ -116,7 +137,10 @@ func (r *WorkoutRepository) ReplaceSetsByWorkoutID(workoutID uint64, sets []*mod
 				return err
 			}
 		}
-		return nil
+		if err := refreshDailySummary(tx, workout.UserID, workout.Date); err != nil {
+			return err
+		}
+		return rebuildPersonalRecords(tx, rebuild)
 	})
 }
 
@@ -135,46 +159,201 @@ func (r *WorkoutRepository) FindByUserIDAndMonth(userID uint64, year, month int)
 	return workouts, nil
 }
 
-// GetExerciseStats は種目別の統計を取得
-func (r *WorkoutRepository) GetExerciseStats(userID uint64, exerciseID uint64, limit int) ([]model.WorkoutSet, error) {
+// GetRecentSets は種目ごとに直近 limit 件のセットをまとめて取得
+func (r *WorkoutRepository) GetRecentSets(userID uint64, exerciseIDs []uint64, limit int) ([]model.WorkoutSet, error) {
 	var sets []model.WorkoutSet
-	if err := r.db.
+	if len(exerciseIDs) == 0 {
+		return sets, nil
+	}
+	recent := r.db.Table("workout_sets").
+		Select("workout_sets.*, ROW_NUMBER() OVER (PARTITION BY workout_sets.exercise_id ORDER BY workouts.date DESC, workout_sets.id DESC) AS recency").
 		Joins("JOIN workouts ON workouts.id = workout_sets.workout_id").
-		Where("workouts.user_id = ? AND workout_sets.exercise_id = ?", userID, exerciseID).
-		Order("workouts.date DESC").
-		Limit(limit).
+		Where("workouts.user_id = ? AND workout_sets.exercise_id IN ?", userID, exerciseIDs)
+	if err := r.db.Table("(?) AS recent_sets", recent).
+		Where("recency <= ?", limit).
 		Find(&sets).Error; err != nil {
 		return nil, err
 	}
 	return sets, nil
 }
 
-// GetMuscleGroupStats は部位別のトレーニング回数を取得
-func (r *WorkoutRepository) GetMuscleGroupStats(userID uint64) ([]MuscleGroupStat, error) {
+// exerciseShares は種目ごとの筋肉への按分比率を求める副問い合わせ
+// 筋肉の対応がない種目は exercises.muscle_group の部位に全量を割り当てる
+const exerciseShares = `(
+	SELECT exercise_muscles.exercise_id, exercise_muscles.muscle_id, muscles.muscle_group,
+		exercise_muscles.contribution / SUM(exercise_muscles.contribution) OVER (PARTITION BY exercise_muscles.exercise_id) AS share
+	FROM exercise_muscles
+	JOIN muscles ON muscles.id = exercise_muscles.muscle_id
+	UNION ALL
+	SELECT exercises.id, muscles.id, exercises.muscle_group, 1
+	FROM exercises
+	JOIN muscles ON muscles.key = exercises.muscle_group::text
+	WHERE NOT EXISTS (SELECT 1 FROM exercise_muscles WHERE exercise_muscles.exercise_id = exercises.id)
+) AS exercise_shares`
+
+// exerciseGroupShares は exerciseShares を部位ごとにまとめた按分比率を求める副問い合わせ
+// 種目の主な部位（exercises.muscle_group）は is_primary とし、関与する筋肉がない場合も比率0で含める
+const exerciseGroupShares = `(
+	SELECT shares.exercise_id, shares.muscle_group, exercises.muscle_group = shares.muscle_group AS is_primary, SUM(shares.share) AS share
+	FROM (
+		SELECT exercise_shares.exercise_id, exercise_shares.muscle_group, exercise_shares.share FROM ` + exerciseShares + `
+		UNION ALL
+		SELECT exercises.id, exercises.muscle_group, 0 FROM exercises
+	) AS shares
+	JOIN exercises ON exercises.id = shares.exercise_id
+	GROUP BY shares.exercise_id, shares.muscle_group, exercises.muscle_group
+) AS exercise_group_shares`
+
+// GetMuscleGroupStats は部位別のトレーニング回数とボリュームを取得
+// トレーニング回数・セット数・ボリュームは種目の主な部位で集計し、
+// weighted_set_count・weighted_volume は種目が関与する筋肉の部位に按分する
+// filter.Bucket を指定した場合は期間ごとに集計する
+// daily_exercise_summary から集計する
+func (r *WorkoutRepository) GetMuscleGroupStats(userID uint64, filter StatsFilter) ([]MuscleGroupStat, error) {
+	selects := "exercise_group_shares.muscle_group, " +
+		"COUNT(DISTINCT CASE WHEN exercise_group_shares.is_primary THEN daily_exercise_summary.date END) as workout_count, " +
+		"SUM(CASE WHEN exercise_group_shares.is_primary THEN daily_exercise_summary.set_count ELSE 0 END) as set_count, " +
+		"SUM(CASE WHEN exercise_group_shares.is_primary THEN daily_exercise_summary.total_volume ELSE 0 END) as total_volume, " +
+		"SUM(daily_exercise_summary.set_count * exercise_group_shares.share) as weighted_set_count, " +
+		"SUM(daily_exercise_summary.total_volume * exercise_group_shares.share) as weighted_volume"
+	groups := "exercise_group_shares.muscle_group"
+	if period := filter.Bucket.exprOn("daily_exercise_summary.date"); period != "" {
+		selects = period + " as period, " + selects
+		groups = period + ", " + groups
+	}
+
 	var stats []MuscleGroupStat
-	if err := r.db.Table("workout_sets").
-		Select("exercises.muscle_group, COUNT(DISTINCT workouts.date) as workout_count, COUNT(*) as set_count").
-		Joins("JOIN workouts ON workouts.id = workout_sets.workout_id").
-		Joins("JOIN exercises ON exercises.id = workout_sets.exercise_id").
-		Where("workouts.user_id = ?", userID).
-		Group("exercises.muscle_group").
+	query := r.db.Table("daily_exercise_summary").
+		Select(selects).
+		Joins("JOIN "+exerciseGroupShares+" ON exercise_group_shares.exercise_id = daily_exercise_summary.exercise_id").
+		Where("daily_exercise_summary.user_id = ?", userID)
+	if err := filter.applyOn(query, "daily_exercise_summary.date").
+		Group(groups).
+		Order(groups).
 		Scan(&stats).Error; err != nil {
 		return nil, err
 	}
 	return stats, nil
 }
 
-// GetPersonalBests は種目ごとの自己ベスト（最大重量）を取得
-func (r *WorkoutRepository) GetPersonalBests(userID uint64) ([]PersonalBest, error) {
-	var bests []PersonalBest
+// GetMuscleStats は筋肉別のトレーニング回数・セット数・ボリュームを取得
+// セット数・ボリュームは筋肉が関与する種目の合計で、weighted_set_count・weighted_volume は筋肉に按分した値
+// filter.Bucket を指定した場合は期間ごとに集計する
+func (r *WorkoutRepository) GetMuscleStats(userID uint64, filter StatsFilter) ([]MuscleStat, error) {
+	selects := "muscles.id as muscle_id, muscles.key as muscle_key, muscles.name as muscle_name, muscles.parent_id, muscles.muscle_group, " +
+		"COUNT(DISTINCT daily_exercise_summary.date) as workout_count, " +
+		"SUM(daily_exercise_summary.set_count) as set_count, " +
+		"SUM(daily_exercise_summary.total_volume) as total_volume, " +
+		"SUM(daily_exercise_summary.set_count * exercise_shares.share) as weighted_set_count, " +
+		"SUM(daily_exercise_summary.total_volume * exercise_shares.share) as weighted_volume"
+	groups := "muscles.id, muscles.key, muscles.name, muscles.parent_id, muscles.muscle_group"
+	order := "muscles.muscle_group, muscles.id"
+	if period := filter.Bucket.exprOn("daily_exercise_summary.date"); period != "" {
+		selects = period + " as period, " + selects
+		groups = period + ", " + groups
+		order = period + ", " + order
+	}
+
+	var stats []MuscleStat
+	query := r.db.Table("daily_exercise_summary").
+		Select(selects).
+		Joins("JOIN "+exerciseShares+" ON exercise_shares.exercise_id = daily_exercise_summary.exercise_id").
+		Joins("JOIN muscles ON muscles.id = exercise_shares.muscle_id").
+		Where("daily_exercise_summary.user_id = ?", userID)
+	if err := filter.applyOn(query, "daily_exercise_summary.date").
+		Group(groups).
+		Order(order).
+		Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// GetTrainingTotals は期間内のワークアウト数・セット数・レップ数・総ボリュームを取得
+func (r *WorkoutRepository) GetTrainingTotals(userID uint64, filter StatsFilter) (*TrainingTotals, error) {
+	var totals TrainingTotals
+	query := r.db.Table("workout_sets").
+		Select("COUNT(DISTINCT workouts.id) as sessions, COUNT(*) as sets, COALESCE(SUM(workout_sets.reps), 0) as reps, COALESCE(SUM(workout_sets.weight * workout_sets.reps), 0) as volume").
+		Joins("JOIN workouts ON workouts.id = workout_sets.workout_id").
+		Where("workouts.user_id = ?", userID)
+	if err := filter.apply(query).Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	return &totals, nil
+}
+
+// GetDailyActivity は日ごとのワークアウト数・セット数・総ボリュームを日付順に取得
+func (r *WorkoutRepository) GetDailyActivity(userID uint64, filter StatsFilter) ([]DailyActivity, error) {
+	var activity []DailyActivity
+	query := r.db.Table("workout_sets").
+		Select("workouts.date, COUNT(DISTINCT workouts.id) as sessions, COUNT(*) as sets, SUM(workout_sets.weight * workout_sets.reps) as volume").
+		Joins("JOIN workouts ON workouts.id = workout_sets.workout_id").
+		Where("workouts.user_id = ?", userID)
+	if err := filter.apply(query).
+		Group("workouts.date").
+		Order("workouts.date ASC").
+		Scan(&activity).Error; err != nil {
+		return nil, err
+	}
+	return activity, nil
+}
+
+// GetWorkoutVolumes はワークアウトごとの総ボリュームを日付順に取得
+func (r *WorkoutRepository) GetWorkoutVolumes(userID uint64) ([]WorkoutVolume, error) {
+	var volumes []WorkoutVolume
 	if err := r.db.Table("workout_sets").
-		Select("exercises.id as exercise_id, exercises.name as exercise_name, exercises.muscle_group, MAX(workout_sets.weight) as max_weight").
+		Select("workouts.id as workout_id, workouts.date, SUM(workout_sets.weight * workout_sets.reps) as volume").
 		Joins("JOIN workouts ON workouts.id = workout_sets.workout_id").
-		Joins("JOIN exercises ON exercises.id = workout_sets.exercise_id").
 		Where("workouts.user_id = ?", userID).
-		Group("exercises.id, exercises.name, exercises.muscle_group").
-		Having("MAX(workout_sets.weight) > 0").
-		Order("exercises.muscle_group, exercises.name").
+		Group("workouts.id, workouts.date").
+		Order("workouts.date ASC, workouts.id ASC").
+		Scan(&volumes).Error; err != nil {
+		return nil, err
+	}
+	return volumes, nil
+}
+
+// GetDailyMuscleGroupLoad は日・部位ごとのセット数・総ボリューム・RPEの合計を日付順に取得
+// RPE の合計は RPE が記録されたセットのみを対象とし、その件数を RPESets に返す
+func (r *WorkoutRepository) GetDailyMuscleGroupLoad(userID uint64, filter StatsFilter) ([]DailyMuscleGroupLoad, error) {
+	var loads []DailyMuscleGroupLoad
+	query := r.db.Table("workout_sets").
+		Select("workouts.date, exercises.muscle_group, COUNT(*) as sets, COUNT(workout_sets.rpe) as rpe_sets, SUM(workout_sets.weight * workout_sets.reps) as volume, COALESCE(SUM(workout_sets.rpe), 0) as rpe_total").
+		Joins("JOIN workouts ON workouts.id = workout_sets.workout_id").
+		Joins("JOIN exercises ON exercises.id = workout_sets.exercise_id").
+		Where("workouts.user_id = ?", userID)
+	if err := filter.apply(query).
+		Group("workouts.date, exercises.muscle_group").
+		Order("workouts.date ASC, exercises.muscle_group").
+		Scan(&loads).Error; err != nil {
+		return nil, err
+	}
+	return loads, nil
+}
+
+// GetPersonalBests は種目ごとの自己ベスト（最大重量・推定1RM）を取得
+// filter.Bucket を指定した場合は期間ごとのベストを返す
+// filter.RollUpVariations の場合はバリエーションの記録を親種目のベストに含める
+// daily_exercise_summary から集計する
+func (r *WorkoutRepository) GetPersonalBests(userID uint64, formula model.OneRepMaxFormula, filter StatsFilter) ([]PersonalBest, error) {
+	selects := fmt.Sprintf("exercises.id as exercise_id, exercises.name as exercise_name, exercises.muscle_group, MAX(daily_exercise_summary.max_weight) as max_weight, MAX(%s) as estimated_one_rep_max", summaryOneRepMaxColumn(formula))
+	groups := "exercises.id, exercises.name, exercises.muscle_group"
+	order := "exercises.muscle_group, exercises.name"
+	if period := filter.Bucket.exprOn("daily_exercise_summary.date"); period != "" {
+		selects = period + " as period, " + selects
+		groups = period + ", " + groups
+		order = period + ", " + order
+	}
+
+	var bests []PersonalBest
+	query := r.db.Table("daily_exercise_summary").
+		Select(selects).
+		Joins(filter.exerciseJoin("daily_exercise_summary.exercise_id")).
+		Where("daily_exercise_summary.user_id = ?", userID)
+	if err := filter.applyOn(query, "daily_exercise_summary.date").
+		Group(groups).
+		Having("MAX(daily_exercise_summary.max_weight) > 0").
+		Order(order).
 		Scan(&bests).Error; err != nil {
 		return nil, err
 	}
@@ -182,36 +361,192 @@ func (r *WorkoutRepository) GetPersonalBests(userID uint64) ([]PersonalBest, err
 }
 
 // GetExerciseProgress は種目の重量推移を取得
-func (r *WorkoutRepository) GetExerciseProgress(userID uint64, exerciseID uint64) ([]ExerciseProgress, error) {
+// 集計単位の指定がない場合は日ごとに集計する
+// filter.RollUpVariations の場合は種目のバリエーションの記録も含める
+// daily_exercise_summary から集計する
+func (r *WorkoutRepository) GetExerciseProgress(userID uint64, exerciseID uint64, formula model.OneRepMaxFormula, filter StatsFilter) ([]ExerciseProgress, error) {
+	period := filter.Bucket.exprOn("daily_exercise_summary.date")
+	if period == "" {
+		period = StatsBucketDay.exprOn("daily_exercise_summary.date")
+	}
+
 	var progress []ExerciseProgress
+	query := r.db.Table("daily_exercise_summary").
+		Select(fmt.Sprintf("%s as date, MAX(daily_exercise_summary.max_weight) as max_weight, SUM(daily_exercise_summary.total_volume) as total_volume, MAX(%s) as estimated_one_rep_max", period, summaryOneRepMaxColumn(formula))).
+		Where("daily_exercise_summary.user_id = ?", userID)
+	query = filter.applyExercise(query, "daily_exercise_summary.exercise_id", exerciseID)
+	if err := filter.applyOn(query, "daily_exercise_summary.date").
+		Group(period).
+		Order(period + " ASC").
+		Scan(&progress).Error; err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+// GetBestOneRepMaxDays は種目ごとに推定1RMが最も高い日（同じ値の場合は最初の日）を取得
+// filter.RollUpVariations の場合はバリエーションの記録を親種目に含める
+// daily_exercise_summary から指定した種目をまとめて集計する
+func (r *WorkoutRepository) GetBestOneRepMaxDays(userID uint64, exerciseIDs []uint64, formula model.OneRepMaxFormula, filter StatsFilter) ([]ExerciseOneRepMax, error) {
+	var bests []ExerciseOneRepMax
+	if len(exerciseIDs) == 0 {
+		return bests, nil
+	}
+	column := summaryOneRepMaxColumn(formula)
+	query := r.db.Table("daily_exercise_summary").
+		Select(fmt.Sprintf("DISTINCT ON (exercises.id) exercises.id as exercise_id, exercises.name as exercise_name, exercises.muscle_group, daily_exercise_summary.date, %s as estimated_one_rep_max", column)).
+		Joins(filter.exerciseJoin("daily_exercise_summary.exercise_id")).
+		Where("daily_exercise_summary.user_id = ? AND exercises.id IN ?", userID, exerciseIDs)
+	if err := filter.applyOn(query, "daily_exercise_summary.date").
+		Order(fmt.Sprintf("exercises.id, %s DESC, daily_exercise_summary.date ASC", column)).
+		Scan(&bests).Error; err != nil {
+		return nil, err
+	}
+	return bests, nil
+}
+
+// GetActiveOneRepMaxHistories は since 以降にも行った種目の日ごとの推定1RMを種目・日付順に取得
+// 停滞の判定に使うため、全種目の推移を daily_exercise_summary から1回で取得する
+func (r *WorkoutRepository) GetActiveOneRepMaxHistories(userID uint64, formula model.OneRepMaxFormula, since time.Time) ([]ExerciseOneRepMax, error) {
+	active := r.db.Table("daily_exercise_summary").
+		Select("exercise_id").
+		Where("user_id = ?", userID).
+		Group("exercise_id").
+		Having("MAX(date) >= ? AND MAX(max_weight) > 0", since.Format("2006-01-02"))
+
+	var history []ExerciseOneRepMax
+	if err := r.db.Table("daily_exercise_summary").
+		Select(fmt.Sprintf("exercises.id as exercise_id, exercises.name as exercise_name, exercises.muscle_group, daily_exercise_summary.date, %s as estimated_one_rep_max", summaryOneRepMaxColumn(formula))).
+		Joins("JOIN exercises ON exercises.id = daily_exercise_summary.exercise_id").
+		Where("daily_exercise_summary.user_id = ? AND daily_exercise_summary.exercise_id IN (?)", userID, active).
+		Order("exercises.muscle_group, exercises.name, exercises.id, daily_exercise_summary.date ASC").
+		Scan(&history).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// GetRepMaxes はレップ数ごとの最高重量と、その重量を初めて記録した日を取得
+func (r *WorkoutRepository) GetRepMaxes(userID uint64, exerciseID uint64, maxReps int) ([]RepMax, error) {
+	var repMaxes []RepMax
 	if err := r.db.Table("workout_sets").
-		Select("workouts.date, MAX(workout_sets.weight) as max_weight, SUM(workout_sets.weight * workout_sets.reps) as total_volume").
+		Select("DISTINCT ON (workout_sets.reps) workout_sets.reps, workout_sets.weight, workouts.date").
 		Joins("JOIN workouts ON workouts.id = workout_sets.workout_id").
 		Where("workouts.user_id = ? AND workout_sets.exercise_id = ?", userID, exerciseID).
-		Group("workouts.date").
-		Order("workouts.date ASC").
-		Scan(&progress).Error; err != nil {
+		Where("workout_sets.reps BETWEEN 1 AND ? AND workout_sets.weight > 0", maxReps).
+		Order("workout_sets.reps, workout_sets.weight DESC, workouts.date ASC").
+		Scan(&repMaxes).Error; err != nil {
 		return nil, err
 	}
-	return progress, nil
+	return repMaxes, nil
+}
+
+// oneRepMaxExpr は model.OneRepMaxFormula.Estimate と同じ計算を行うSQL式を返す
+func oneRepMaxExpr(formula model.OneRepMaxFormula) string {
+	const epley = "workout_sets.weight * (1 + workout_sets.reps / 30.0)"
+	var expr string
+	switch formula {
+	case model.OneRepMaxFormulaBrzycki:
+		expr = "CASE WHEN workout_sets.reps < 37 THEN workout_sets.weight * 36.0 / (37 - workout_sets.reps) ELSE " + epley + " END"
+	case model.OneRepMaxFormulaLombardi:
+		expr = "workout_sets.weight * POWER(workout_sets.reps, 0.10)"
+	default:
+		expr = epley
+	}
+	return "CASE WHEN workout_sets.reps <= 1 THEN workout_sets.weight ELSE " + expr + " END"
+}
+
+type TrainingTotals struct {
+	Sessions int64   `json:"sessions"`
+	Sets     int64   `json:"sets"`
+	Reps     int64   `json:"reps"`
+	Volume   float64 `json:"volume"`
+}
+
+type DailyActivity struct {
+	Date     time.Time `json:"date"`
+	Sessions int       `json:"sessions"`
+	Sets     int       `json:"sets"`
+	Volume   float64   `json:"volume"`
 }
 
+type WorkoutVolume struct {
+	WorkoutID uint64    `json:"workout_id"`
+	Date      time.Time `json:"date"`
+	Volume    float64   `json:"volume"`
+}
+
+type DailyMuscleGroupLoad struct {
+	Date        time.Time         `json:"date"`
+	MuscleGroup model.MuscleGroup `json:"muscle_group"`
+	Sets        int               `json:"sets"`
+	RPESets     int               `json:"rpe_sets"`
+	Volume      float64           `json:"volume"`
+	RPETotal    float64           `json:"rpe_total"`
+}
+
+// MuscleGroupStat の WorkoutCount・SetCount・TotalVolume は種目の主な部位（exercises.muscle_group）で数えた値
+// WeightedSetCount・WeightedVolume は種目が関与する筋肉の比率で各部位に按分した値
 type MuscleGroupStat struct {
-	MuscleGroup  string `json:"muscle_group"`
-	WorkoutCount int    `json:"workout_count"`
-	SetCount     int    `json:"set_count"`
+	Period           *time.Time `json:"period,omitempty"`
+	MuscleGroup      string     `json:"muscle_group"`
+	WorkoutCount     int        `json:"workout_count"`
+	SetCount         int        `json:"set_count"`
+	TotalVolume      float64    `json:"total_volume"`
+	WeightedSetCount float64    `json:"weighted_set_count"`
+	WeightedVolume   float64    `json:"weighted_volume"`
+}
+
+type MuscleStat struct {
+	Period       *time.Time        `json:"period,omitempty"`
+	MuscleID     uint64            `json:"muscle_id"`
+	MuscleKey    string            `json:"muscle_key"`
+	MuscleName   string            `json:"muscle_name"`
+	ParentID     *uint64           `json:"parent_id"`
+	MuscleGroup  model.MuscleGroup `json:"muscle_group"`
+	WorkoutCount int               `json:"workout_count"`
+	SetCount     int               `json:"set_count"`
+	TotalVolume  float64           `json:"total_volume"`
+
+	WeightedSetCount float64 `json:"weighted_set_count"` // 筋肉に按分したセット数
+	WeightedVolume   float64 `json:"weighted_volume"`
 }
 
 type PersonalBest struct {
-	ExerciseID   uint64  `json:"exercise_id"`
-	ExerciseName string  `json:"exercise_name"`
-	MuscleGroup  string  `json:"muscle_group"`
-	MaxWeight    float64 `json:"max_weight"`
+	Period             *time.Time `json:"period,omitempty"`
+	ExerciseID         uint64     `json:"exercise_id"`
+	ExerciseName       string     `json:"exercise_name"`
+	MuscleGroup        string     `json:"muscle_group"`
+	MaxWeight          float64    `json:"max_weight"`
+	EstimatedOneRepMax float64    `json:"estimated_one_rep_max"`
 }
 
 type ExerciseProgress struct {
-	Date        time.Time `json:"date"`
-	MaxWeight   float64   `json:"max_weight"`
-	TotalVolume float64   `json:"total_volume"`
+	Date               time.Time `json:"date"`
+	MaxWeight          float64   `json:"max_weight"`
+	TotalVolume        float64   `json:"total_volume"`
+	EstimatedOneRepMax float64   `json:"estimated_one_rep_max"`
+}
+
+type ExerciseOneRepMax struct {
+	ExerciseID         uint64    `json:"exercise_id"`
+	ExerciseName       string    `json:"exercise_name"`
+	MuscleGroup        string    `json:"muscle_group"`
+	Date               time.Time `json:"date"`
+	EstimatedOneRepMax float64   `json:"estimated_one_rep_max"`
 }
 
+type SetHistory struct {
+	WorkoutID uint64    `json:"workout_id"`
+	SetID     uint64    `json:"set_id"`
+	Date      time.Time `json:"date"`
+	SetNumber uint8     `json:"set_number"`
+	Weight    float64   `json:"weight"`
+	Reps      uint16    `json:"reps"`
+}
+
+type RepMax struct {
+	Reps   uint16    `json:"reps"`
+	Weight float64   `json:"weight"`
+	Date   time.Time `json:"date"`
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/repository"
+)
+
+// AchievementMetric は実績の判定に使う指標
+// どの指標も日付とともに単調に増える値として算出する
+type AchievementMetric string
+
+const (
+	AchievementMetricWorkouts        AchievementMetric = "workouts"          // ワークアウトの累計回数
+	AchievementMetricWeeklyStreak    AchievementMetric = "weekly_streak"     // 毎週ワークアウトした最長の連続週数
+	AchievementMetricSessionVolume   AchievementMetric = "session_volume"    // 1回のワークアウトの最大ボリューム（kg）
+	AchievementMetricLifetimeVolume  AchievementMetric = "lifetime_volume"   // 累計ボリューム（kg）
+	AchievementMetricBenchBodyWeight AchievementMetric = "bench_body_weight" // ベンチプレスの最大重量の体重比
+)
+
+// AchievementRule は実績の定義（指標が Threshold 以上になった日に獲得する）
+type AchievementRule struct {
+	Key         string            `json:"key"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Metric      AchievementMetric `json:"metric"`
+	Threshold   float64           `json:"threshold"`
+}
+
+// DefaultAchievementRules は実績の一覧
+// 既存の指標を使う実績はここに追加するだけでよい
+var DefaultAchievementRules = []AchievementRule{
+	{Key: "first_workout", Name: "はじめの一歩", Description: "初めてワークアウトを記録した", Metric: AchievementMetricWorkouts, Threshold: 1},
+	{Key: "workouts_100", Name: "100回達成", Description: "ワークアウトを100回記録した", Metric: AchievementMetricWorkouts, Threshold: 100},
+	{Key: "weekly_streak_10", Name: "10週連続", Description: "10週連続でワークアウトした", Metric: AchievementMetricWeeklyStreak, Threshold: 10},
+	{Key: "session_volume_1000", Name: "1トンの日", Description: "1回のワークアウトで総ボリューム1000kgを達成した", Metric: AchievementMetricSessionVolume, Threshold: 1000},
+	{Key: "lifetime_volume_100t", Name: "100トンクラブ", Description: "累計ボリューム100トンを達成した", Metric: AchievementMetricLifetimeVolume, Threshold: 100000},
+	{Key: "bench_body_weight", Name: "自重ベンチ", Description: "体重と同じ重量でベンチプレスを挙げた", Metric: AchievementMetricBenchBodyWeight, Threshold: 1},
+}
+
+// AchievementStatus は実績ごとの獲得状況
+type AchievementStatus struct {
+	AchievementRule
+	Earned   bool       `json:"earned"`
+	EarnedOn *time.Time `json:"earned_on"`
+	Current  float64    `json:"current"`
+}
+
+// AchievementService は実績ルールを評価して獲得した実績を保存する
+type AchievementService struct {
+	achievementRepo AchievementRepository
+	workoutRepo     WorkoutRepository
+	exerciseRepo    ExerciseRepository
+	bodyWeightRepo  BodyWeightRepository
+	rules           []AchievementRule
+}
+
+func NewAchievementService(achievementRepo AchievementRepository, workoutRepo WorkoutRepository, exerciseRepo ExerciseRepository, bodyWeightRepo BodyWeightRepository, rules []AchievementRule) *AchievementService {
+	return &AchievementService{
+		achievementRepo: achievementRepo,
+		workoutRepo:     workoutRepo,
+		exerciseRepo:    exerciseRepo,
+		bodyWeightRepo:  bodyWeightRepo,
+		rules:           rules,
+	}
+}
+
+// Evaluate は未獲得の実績を判定し、新たに獲得した実績を返す
+// 獲得日は指標が基準に達した記録の日付とする（過去の記録を後から入力した場合も同様）
+func (s *AchievementService) Evaluate(userID uint64) ([]model.UserAchievement, error) {
+	earned, err := s.earnedKeys(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make(map[AchievementMetric][]trendPoint)
+	var awarded []model.UserAchievement
+	for _, rule := range s.rules {
+		if _, ok := earned[rule.Key]; ok {
+			continue
+		}
+		points, err := s.metricSeries(userID, rule.Metric, series)
+		if err != nil {
+			return nil, err
+		}
+		earnedOn := firstReached(points, rule.Threshold)
+		if earnedOn == nil {
+			continue
+		}
+
+		achievement := model.UserAchievement{
+			UserID:         userID,
+			AchievementKey: rule.Key,
+			EarnedOn:       *earnedOn,
+		}
+		if err := s.achievementRepo.Create(&achievement); err != nil {
+			return nil, fmt.Errorf("saving achievement: %w", err)
+		}
+		awarded = append(awarded, achievement)
+	}
+	return awarded, nil
+}
+
+// evaluateAfterSave は記録の保存後に実績と目標を判定し、新たに獲得した実績を返す
+// 記録はすでに保存されているため、判定に失敗してもエラーにせずログに残す（未獲得・未達成のものは次の保存で判定し直される）
+func evaluateAfterSave(achievements *AchievementService, goals *GoalService, userID uint64) []model.UserAchievement {
+	awarded, err := achievements.Evaluate(userID)
+	if err != nil {
+		log.Printf("WARNING: Failed to evaluate achievements for user %d: %v", userID, err)
+	}
+	if err := goals.EvaluateGoals(userID); err != nil {
+		log.Printf("WARNING: Failed to evaluate goals for user %d: %v", userID, err)
+	}
+	return awarded
+}
+
+// GetAchievements はすべての実績の獲得状況と現在の値を返す
+// 実績はワークアウト・体重の記録時に Evaluate で保存するため、ここでは判定しない
+func (s *AchievementService) GetAchievements(userID uint64) ([]AchievementStatus, error) {
+	earned, err := s.earnedKeys(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make(map[AchievementMetric][]trendPoint)
+	statuses := make([]AchievementStatus, 0, len(s.rules))
+	for _, rule := range s.rules {
+		points, err := s.metricSeries(userID, rule.Metric, series)
+		if err != nil {
+			return nil, err
+		}
+		status := AchievementStatus{AchievementRule: rule}
+		if len(points) > 0 {
+			status.Current = points[len(points)-1].Value
+		}
+		if achievement, ok := earned[rule.Key]; ok {
+			earnedOn := achievement.EarnedOn
+			status.Earned = true
+			status.EarnedOn = &earnedOn
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (s *AchievementService) earnedKeys(userID uint64) (map[string]model.UserAchievement, error) {
+	achievements, err := s.achievementRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("finding achievements: %w", err)
+	}
+	earned := make(map[string]model.UserAchievement, len(achievements))
+	for _, a := range achievements {
+		earned[a.AchievementKey] = a
+	}
+	return earned, nil
+}
+
+// metricSeries は指標の推移を返す（同じ評価の中では cache を使い回す）
+func (s *AchievementService) metricSeries(userID uint64, metric AchievementMetric, cache map[AchievementMetric][]trendPoint) ([]trendPoint, error) {
+	if points, ok := cache[metric]; ok {
+		return points, nil
+	}
+
+	var points []trendPoint
+	switch metric {
+	case AchievementMetricWorkouts, AchievementMetricWeeklyStreak, AchievementMetricLifetimeVolume:
+		activity, err := s.workoutRepo.GetDailyActivity(userID, repository.StatsFilter{})
+		if err != nil {
+			return nil, err
+		}
+		points = activitySeries(metric, activity)
+
+	case AchievementMetricSessionVolume:
+		volumes, err := s.workoutRepo.GetWorkoutVolumes(userID)
+		if err != nil {
+			return nil, err
+		}
+		var best float64
+		for _, v := range volumes {
+			best = math.Max(best, v.Volume)
+			points = append(points, trendPoint{Date: toDate(v.Date), Value: best})
+		}
+
+	case AchievementMetricBenchBodyWeight:
+		var err error
+		if points, err = s.benchBodyWeightSeries(userID); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown achievement metric: %s", metric)
+	}
+
+	cache[metric] = points
+	return points, nil
+}
+
+// activitySeries は日別実績から累計回数・連続週数・累計ボリュームの推移を求める
+func activitySeries(metric AchievementMetric, activity []repository.DailyActivity) []trendPoint {
+	var points []trendPoint
+	var total, longest float64
+	var run int
+	var lastWeek time.Time
+	for _, day := range activity {
+		date := toDate(day.Date)
+		switch metric {
+		case AchievementMetricWorkouts:
+			total += float64(day.Sessions)
+		case AchievementMetricLifetimeVolume:
+			total += day.Volume
+		case AchievementMetricWeeklyStreak:
+			week := weekStart(date)
+			switch {
+			case run > 0 && week.Equal(lastWeek):
+				continue
+			case run > 0 && week.Equal(lastWeek.AddDate(0, 0, 7)):
+				run++
+			default:
+				run = 1
+			}
+			lastWeek = week
+			longest = math.Max(longest, float64(run))
+			total = longest
+		}
+		points = append(points, trendPoint{Date: date, Value: total})
+	}
+	return points
+}
+
+// benchBodyWeightSeries はベンチプレスの最大重量を、その日に最も近い体重で割った値の最大値の推移を求める
+func (s *AchievementService) benchBodyWeightSeries(userID uint64) ([]trendPoint, error) {
+	exercises, err := s.exerciseRepo.FindAll(userID)
+	if err != nil {
+		return nil, fmt.Errorf("finding exercises: %w", err)
+	}
+	var benchID uint64
+	for _, e := range exercises {
+		if lift, ok := bigLiftOf(e); ok && lift == BigLiftBenchPress {
+			benchID = e.ID
+			break
+		}
+	}
+	if benchID == 0 {
+		return nil, nil
+	}
+
+	bodyWeights, err := s.bodyWeightRepo.FindByUserID(userID, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(bodyWeights) == 0 {
+		return nil, nil
+	}
+	progress, err := s.workoutRepo.GetExerciseProgress(userID, benchID, model.OneRepMaxFormulaEpley, repository.StatsFilter{Bucket: repository.StatsBucketDay})
+	if err != nil {
+		return nil, err
+	}
+
+	var points []trendPoint
+	var best float64
+	for _, p := range progress {
+		date := toDate(p.Date)
+		nearest := nearestBodyWeight(bodyWeights, date)
+		best = math.Max(best, p.MaxWeight/nearest.Weight)
+		points = append(points, trendPoint{Date: date, Value: best})
+	}
+	return points, nil
+}
+
+// firstReached は値が threshold 以上になった最初の日を返す
+func firstReached(points []trendPoint, threshold float64) *time.Time {
+	for _, p := range points {
+		if p.Value >= threshold {
+			d := p.Date
+			return &d
+		}
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/repository"
+)
+
+// MockAchievementRepository はテスト用のモックリポジトリ
+type MockAchievementRepository struct {
+	achievements []model.UserAchievement
+	// createErr は保存で返すエラー
+	createErr error
+}
+
+func (r *MockAchievementRepository) Create(achievement *model.UserAchievement) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	for _, a := range r.achievements {
+		if a.UserID == achievement.UserID && a.AchievementKey == achievement.AchievementKey {
+			return nil
+		}
+	}
+	achievement.ID = uint64(len(r.achievements) + 1)
+	r.achievements = append(r.achievements, *achievement)
+	return nil
+}
+
+func (r *MockAchievementRepository) FindByUserID(userID uint64) ([]model.UserAchievement, error) {
+	var achievements []model.UserAchievement
+	for _, a := range r.achievements {
+		if a.UserID == userID {
+			achievements = append(achievements, a)
+		}
+	}
+	return achievements, nil
+}
+
+// MockBodyWeightRepository はテスト用のモックリポジトリ（実績の判定で使うメソッドのみ実装）
+type MockBodyWeightRepository struct {
+	BodyWeightRepository
+	records []model.BodyWeight
+}
+
+func (r *MockBodyWeightRepository) FindByUserID(userID uint64, limit int) ([]model.BodyWeight, error) {
+	var records []model.BodyWeight
+	for i := len(r.records) - 1; i >= 0; i-- {
+		if r.records[i].UserID == userID {
+			records = append(records, r.records[i])
+		}
+	}
+	return records, nil
+}
+
+func TestActivitySeries(t *testing.T) {
+	activity := []repository.DailyActivity{
+		{Date: date("2026-09-01"), Sessions: 1, Volume: 3000},
+		{Date: date("2026-09-03"), Sessions: 2, Volume: 5000},
+		{Date: date("2026-09-08"), Sessions: 1, Volume: 2000},
+		{Date: date("2026-09-29"), Sessions: 1, Volume: 4000},
+		{Date: date("2026-10-06"), Sessions: 1, Volume: 4000},
+	}
+
+	t.Run("累計回数と累計ボリューム", func(t *testing.T) {
+		workouts := activitySeries(AchievementMetricWorkouts, activity)
+		if got := workouts[len(workouts)-1].Value; got != 6 {
+			t.Errorf("期待される累計回数: 6, 実際: %v", got)
+		}
+		volume := activitySeries(AchievementMetricLifetimeVolume, activity)
+		if got := firstReached(volume, 10000); got == nil || !got.Equal(date("2026-09-08")) {
+			t.Errorf("期待される到達日: 2026-09-08, 実際: %v", got)
+		}
+	})
+
+	t.Run("連続週数は途切れた後も最長を保つ", func(t *testing.T) {
+		streak := activitySeries(AchievementMetricWeeklyStreak, activity)
+		if got := streak[len(streak)-1].Value; got != 2 {
+			t.Errorf("期待される最長の連続週数: 2, 実際: %v", got)
+		}
+		if got := firstReached(streak, 2); got == nil || !got.Equal(date("2026-09-08")) {
+			t.Errorf("期待される到達日: 2026-09-08, 実際: %v", got)
+		}
+	})
+}
+
+func TestAchievementService_Evaluate(t *testing.T) {
+	t.Run("ワークアウトの記録で実績を獲得し、二重に獲得しない", func(t *testing.T) {
+		workoutRepo := NewMockWorkoutRepository()
+		achievementRepo := &MockAchievementRepository{}
+		achievements := NewAchievementService(achievementRepo, workoutRepo, NewMockExerciseRepository(), &MockBodyWeightRepository{}, DefaultAchievementRules)
+		workoutService := NewWorkoutService(workoutRepo, NewMockExerciseRepository(), NewPersonalRecordTracker(workoutRepo, workoutRepo.records), achievements, NewGoalService(&MockGoalRepository{}, workoutRepo, NewMockExerciseRepository(), &MockBodyWeightRepository{}), nil)
+
+		workout, err := workoutService.CreateWorkout(1, &CreateWorkoutInput{
+			Date: "2026-10-01",
+			Sets: []CreateSetInput{
+				{ExerciseID: 2, SetNumber: 1, Weight: 100, Reps: 5},
+				{ExerciseID: 2, SetNumber: 2, Weight: 100, Reps: 5},
+			},
+		})
+		if err != nil {
+			t.Fatalf("ワークアウト作成に失敗: %v", err)
+		}
+
+		keys := make(map[string]bool)
+		for _, a := range workout.NewAchievements {
+			keys[a.AchievementKey] = true
+		}
+		if len(keys) != 2 || !keys["first_workout"] || !keys["session_volume_1000"] {
+			t.Errorf("期待される実績: first_workout, session_volume_1000, 実際: %v", keys)
+		}
+
+		awarded, err := achievements.Evaluate(1)
+		if err != nil {
+			t.Fatalf("判定に失敗: %v", err)
+		}
+		if len(awarded) != 0 || len(achievementRepo.achievements) != 2 {
+			t.Errorf("獲得済みの実績は再び獲得しないべき: %v", awarded)
+		}
+	})
+
+	t.Run("実績の判定に失敗しても保存したワークアウトを返す", func(t *testing.T) {
+		workoutRepo := NewMockWorkoutRepository()
+		achievementRepo := &MockAchievementRepository{createErr: errors.New("connection lost")}
+		achievements := NewAchievementService(achievementRepo, workoutRepo, NewMockExerciseRepository(), &MockBodyWeightRepository{}, DefaultAchievementRules)
+		workoutService := NewWorkoutService(workoutRepo, NewMockExerciseRepository(), NewPersonalRecordTracker(workoutRepo, workoutRepo.records), achievements, NewGoalService(&MockGoalRepository{}, workoutRepo, NewMockExerciseRepository(), &MockBodyWeightRepository{}), nil)
+
+		workout, err := workoutService.CreateWorkout(1, &CreateWorkoutInput{
+			Date: "2026-10-01",
+			Sets: []CreateSetInput{{ExerciseID: 2, SetNumber: 1, Weight: 100, Reps: 5}},
+		})
+		if err != nil {
+			t.Fatalf("保存済みのワークアウトはエラーにしないべき: %v", err)
+		}
+		if workout.ID == 0 || len(workout.NewAchievements) != 0 {
+			t.Errorf("新たな実績なしで保存したワークアウトを期待, 実際: %+v", workout)
+		}
+	})
+
+	t.Run("獲得状況に未獲得の実績と現在の値を含める", func(t *testing.T) {
+		achievements := NewAchievementService(&MockAchievementRepository{}, NewMockWorkoutRepository(), NewMockExerciseRepository(), &MockBodyWeightRepository{}, DefaultAchievementRules)
+
+		statuses, err := achievements.GetAchievements(1)
+		if err != nil {
+			t.Fatalf("取得に失敗: %v", err)
+		}
+		if len(statuses) != len(DefaultAchievementRules) {
+			t.Fatalf("期待される件数: %d, 実際: %d", len(DefaultAchievementRules), len(statuses))
+		}
+		for _, status := range statuses {
+			if status.Earned || status.Current != 0 {
+				t.Errorf("未獲得を期待: %+v", status)
+			}
+		}
+	})
+	t.Run("獲得状況の取得では実績を保存せず、削除時に判定する", func(t *testing.T) {
+		workoutRepo := NewMockWorkoutRepository()
+		achievementRepo := &MockAchievementRepository{}
+		achievements := NewAchievementService(achievementRepo, workoutRepo, NewMockExerciseRepository(), &MockBodyWeightRepository{}, DefaultAchievementRules)
+		workoutService := NewWorkoutService(workoutRepo, NewMockExerciseRepository(), NewPersonalRecordTracker(workoutRepo, workoutRepo.records), achievements, NewGoalService(&MockGoalRepository{}, workoutRepo, NewMockExerciseRepository(), &MockBodyWeightRepository{}), nil)
+		// 判定を通さずに記録したワークアウト
+		for _, d := range []string{"2026-10-01", "2026-10-03"} {
+			workoutRepo.CreateWithSets(
+				&model.Workout{UserID: 1, Date: date(d)},
+				[]*model.WorkoutSet{{ExerciseID: 1, SetNumber: 1, Weight: 60, Reps: 10}},
+				nil,
+			)
+		}
+
+		if _, err := achievements.GetAchievements(1); err != nil {
+			t.Fatalf("取得に失敗: %v", err)
+		}
+		if len(achievementRepo.achievements) != 0 {
+			t.Fatalf("取得で実績が保存されるべきではない: %+v", achievementRepo.achievements)
+		}
+
+		if err := workoutService.DeleteWorkout(1, 2); err != nil {
+			t.Fatalf("ワークアウト削除に失敗: %v", err)
+		}
+		if len(achievementRepo.achievements) != 1 || achievementRepo.achievements[0].AchievementKey != "first_workout" {
+			t.Errorf("削除後に first_workout を獲得するべき: %+v", achievementRepo.achievements)
+		}
+	})
+}
@@ -12,16 +12,21 @@ import (
 	"time"
 
 	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/repository"
 )
 
 // GenerateMenuInput はAIメニュー生成の入力パラメータ
 type GenerateMenuInput struct {
-	Goal               string   `json:"goal"`
-	FitnessLevel       string   `json:"fitness_level"`
-	DaysPerWeek        int      `json:"days_per_week"`
-	DurationMinutes    int      `json:"duration_minutes"`
-	TargetMuscleGroups []string `json:"target_muscle_groups,omitempty"`
-	Notes              string   `json:"notes,omitempty"`
+	Goal               string   `json:"goal" validate:"required,max=200"`
+	FitnessLevel       string   `json:"fitness_level" validate:"required,max=50"`
+	DaysPerWeek        int      `json:"days_per_week" validate:"required,min=1,max=7"`
+	DurationMinutes    int      `json:"duration_minutes" validate:"required,min=10,max=240"`
+	TargetMuscleGroups []string `json:"target_muscle_groups,omitempty" validate:"omitempty,dive,musclegroup"`
+	Notes              string   `json:"notes,omitempty" validate:"max=1000"`
+	// UseAvailableEquipment が true の場合、ユーザーの使える器具で実施できる種目のみを使う
+	UseAvailableEquipment bool `json:"use_available_equipment,omitempty"`
+	// Locale は種目名・部位名とメニューの文章の言語（リクエストから決める）
+	Locale model.Locale `json:"-"`
 }
 
 // GeneratedMenuItemOutput はAI生成メニューのアイテム（種目情報付き）
@@ -96,10 +101,21 @@ func (s *AIMenuService) GenerateMenu(ctx context.Context, userID uint64, input *
 	}
 
 	// 種目リストを取得
-	exercises, err := s.exerciseRepo.FindAll(userID)
+	exercises, err := s.exerciseRepo.FindFiltered(userID, repository.ExerciseFilter{AvailableOnly: input.UseAvailableEquipment})
 	if err != nil {
 		return nil, fmt.Errorf("種目の取得に失敗しました: %w", err)
 	}
+	locale := input.Locale
+	if !locale.IsValid() {
+		locale = model.DefaultLocale
+	}
+	var refs exerciseNameRefs
+	for i := range exercises {
+		refs.exercise(&exercises[i])
+	}
+	if err := translateExerciseNames(s.exerciseRepo, locale, refs); err != nil {
+		return nil, fmt.Errorf("種目名の翻訳に失敗しました: %w", err)
+	}
 
 	// 種目マップ（IDをキー）を作成（後でバリデーションに使う）
 	exerciseMap := make(map[uint64]*model.Exercise)
@@ -108,7 +124,7 @@ func (s *AIMenuService) GenerateMenu(ctx context.Context, userID uint64, input *
 	}
 
 	// プロンプト構築
-	systemPrompt := s.buildSystemPrompt(exercises)
+	systemPrompt := s.buildSystemPrompt(exercises, locale)
 	userMessage := s.buildUserMessage(input)
 
 	// OpenAI API呼び出し
@@ -195,12 +211,13 @@ func (s *AIMenuService) GenerateMenu(ctx context.Context, userID uint64, input *
 	return output, nil
 }
 
-func (s *AIMenuService) buildSystemPrompt(exercises []model.Exercise) string {
-	muscleGroupLabel := map[string]string{
-		"chest": "胸", "back": "背中", "shoulders": "肩",
-		"arms": "腕", "legs": "脚", "abs": "腹筋", "other": "その他",
-	}
+// menuLanguageNames はメニューの文章を書く言語の名前
+var menuLanguageNames = map[model.Locale]string{
+	model.LocaleJa: "日本語",
+	model.LocaleEn: "英語",
+}
 
+func (s *AIMenuService) buildSystemPrompt(exercises []model.Exercise, locale model.Locale) string {
 	var sb strings.Builder
 	sb.WriteString(`あなたはプロのパーソナルトレーナーです。ユーザーの情報に基づいて、最適なトレーニングメニューをJSON形式で作成してください。以下のルールを厳守してください：
 1. 必ず下記の「利用可能な種目リスト」に記載されているexercise_idのみを使用すること
@@ -220,12 +237,19 @@ func (s *AIMenuService) buildSystemPrompt(exercises []model.Exercise) string {
     }
   ]
 }
-
-利用可能な種目リスト（exercise_id: 種目名 (部位)）：
 `)
+	sb.WriteString(fmt.Sprintf("4. name・description・note は%sで書くこと\n\n", menuLanguageNames[locale]))
+	sb.WriteString("利用可能な種目リスト（exercise_id: 種目名 (部位、器具)）：\n")
 
 	for _, e := range exercises {
-		label := muscleGroupLabel[string(e.MuscleGroup)]
+		label := MuscleGroupLabel(locale, e.MuscleGroup)
+		equipment := make([]string, len(e.Equipment))
+		for i, eq := range e.Equipment {
+			equipment[i] = EquipmentLabel(locale, eq)
+		}
+		if len(equipment) > 0 {
+			label += "、" + strings.Join(equipment, "・")
+		}
 		sb.WriteString(fmt.Sprintf("- %d: %s (%s)\n", e.ID, e.Name, label))
 	}
 
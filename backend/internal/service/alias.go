@@ -0,0 +1,145 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// exerciseSearchLimit は検索結果の最大件数
+	exerciseSearchLimit = 20
+	// maxAliasLength は別名の最大文字数
+	maxAliasLength = 100
+)
+
+// ExerciseSearchResult は検索に一致した種目とその一致度
+type ExerciseSearchResult struct {
+	model.Exercise
+	Score float64 `json:"score"`
+}
+
+type CreateAliasInput struct {
+	Alias string `json:"alias" validate:"required,max=100"`
+}
+
+// normalizeSearchText は検索語・別名の表記ゆれを吸収する
+// 全角英数字は半角に、ひらがなはカタカナに揃え、英字は小文字にして空白を1つにまとめる
+// 漢字を含む場合は送り仮名を崩さないようひらがなをそのまま残す
+func normalizeSearchText(s string) string {
+	toKatakana := !strings.ContainsFunc(s, func(r rune) bool { return unicode.Is(unicode.Han, r) })
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '　':
+			r = ' '
+		case r >= '！' && r <= '～':
+			r -= 0xFEE0
+		case toKatakana && r >= 'ぁ' && r <= 'ゖ':
+			r += 0x60
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// SearchExercises は種目名と別名から種目を検索し、一致度の高い順に返す
+func (s *WorkoutService) SearchExercises(userID uint64, q string) ([]ExerciseSearchResult, error) {
+	query := normalizeSearchText(q)
+	if query == "" {
+		return []ExerciseSearchResult{}, nil
+	}
+
+	matches, err := s.exerciseRepo.Search(userID, query, exerciseSearchLimit)
+	if err != nil {
+		return nil, fmt.Errorf("searching exercises: %w", err)
+	}
+	if len(matches) == 0 {
+		return []ExerciseSearchResult{}, nil
+	}
+
+	// お気に入りや使用履歴を含めて返すため一覧と同じ取得方法を使う
+	exercises, err := s.exerciseRepo.FindFiltered(userID, repository.ExerciseFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("finding exercises: %w", err)
+	}
+	byID := make(map[uint64]model.Exercise, len(exercises))
+	for _, e := range exercises {
+		byID[e.ID] = e
+	}
+
+	results := make([]ExerciseSearchResult, 0, len(matches))
+	for _, m := range matches {
+		exercise, ok := byID[m.ExerciseID]
+		if !ok {
+			continue
+		}
+		results = append(results, ExerciseSearchResult{Exercise: exercise, Score: m.Score})
+	}
+	return results, nil
+}
+
+// GetAliases は種目の別名（共通のものとユーザーが登録したもの）を返す
+func (s *WorkoutService) GetAliases(userID, exerciseID uint64) ([]model.ExerciseAlias, error) {
+	if _, err := s.findVisibleExercise(userID, exerciseID); err != nil {
+		return nil, err
+	}
+	return s.exerciseRepo.FindAliases(userID, exerciseID)
+}
+
+// CreateAlias はユーザー独自の別名を種目に登録する
+func (s *WorkoutService) CreateAlias(userID, exerciseID uint64, input *CreateAliasInput) (*model.ExerciseAlias, error) {
+	if _, err := s.findVisibleExercise(userID, exerciseID); err != nil {
+		return nil, err
+	}
+
+	alias := normalizeSearchText(input.Alias)
+	if alias == "" {
+		return nil, fmt.Errorf("%w: alias is required", ErrInvalidAlias)
+	}
+	if utf8.RuneCountInString(alias) > maxAliasLength {
+		return nil, fmt.Errorf("%w: alias must be at most %d characters", ErrInvalidAlias, maxAliasLength)
+	}
+
+	existing, err := s.exerciseRepo.FindAliases(userID, exerciseID)
+	if err != nil {
+		return nil, fmt.Errorf("finding aliases: %w", err)
+	}
+	for _, a := range existing {
+		if a.Alias == alias {
+			return nil, ErrAliasAlreadyExists
+		}
+	}
+
+	created := &model.ExerciseAlias{
+		ExerciseID: exerciseID,
+		UserID:     &userID,
+		Alias:      alias,
+	}
+	if err := s.exerciseRepo.CreateAlias(created); err != nil {
+		return nil, fmt.Errorf("creating alias: %w", err)
+	}
+	return created, nil
+}
+
+// DeleteAlias はユーザーが登録した別名を削除する（共通の別名は削除できない）
+func (s *WorkoutService) DeleteAlias(userID, aliasID uint64) error {
+	alias, err := s.exerciseRepo.FindAliasByID(aliasID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAliasNotFound
+		}
+		return fmt.Errorf("finding alias: %w", err)
+	}
+	if alias.UserID == nil || *alias.UserID != userID {
+		return ErrAliasNotFound
+	}
+	return s.exerciseRepo.DeleteAlias(aliasID)
+}
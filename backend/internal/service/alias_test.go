@@ -0,0 +1,88 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeSearchText(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"BP", "bp"},
+		{"ＯＨＰ", "ohp"},
+		{"べんち　ぷれす", "ベンチ プレス"},
+		{"  Bench   Press ", "bench press"},
+		{"腕立て伏せ", "腕立て伏せ"},
+	}
+	for _, tt := range tests {
+		if got := normalizeSearchText(tt.input); got != tt.want {
+			t.Errorf("normalizeSearchText(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestWorkoutService_Alias(t *testing.T) {
+	t.Run("登録した別名で検索できる", func(t *testing.T) {
+		service := NewWorkoutService(NewMockWorkoutRepository(), NewMockExerciseRepository(), nil, nil, nil, nil)
+		alias, err := service.CreateAlias(1, 1, &CreateAliasInput{Alias: "ＢＰ"})
+		if err != nil {
+			t.Fatalf("登録に失敗: %v", err)
+		}
+		if alias.Alias != "bp" {
+			t.Errorf("正規化された別名を期待, 実際: %s", alias.Alias)
+		}
+
+		results, err := service.SearchExercises(1, "bp")
+		if err != nil {
+			t.Fatalf("検索に失敗: %v", err)
+		}
+		if len(results) != 1 || results[0].ID != 1 {
+			t.Errorf("ベンチプレスのみを期待, 実際: %+v", results)
+		}
+
+		results, _ = service.SearchExercises(2, "bp")
+		if len(results) != 0 {
+			t.Errorf("他のユーザーの別名では一致しないことを期待, 実際: %+v", results)
+		}
+	})
+
+	t.Run("ひらがなでカタカナの種目名を検索できる", func(t *testing.T) {
+		service := NewWorkoutService(NewMockWorkoutRepository(), NewMockExerciseRepository(), nil, nil, nil, nil)
+		results, err := service.SearchExercises(1, "すくわっと")
+		if err != nil {
+			t.Fatalf("検索に失敗: %v", err)
+		}
+		if len(results) != 1 || results[0].ID != 2 {
+			t.Errorf("スクワットのみを期待, 実際: %+v", results)
+		}
+	})
+
+	t.Run("重複や空の別名は登録できない", func(t *testing.T) {
+		service := NewWorkoutService(NewMockWorkoutRepository(), NewMockExerciseRepository(), nil, nil, nil, nil)
+		if _, err := service.CreateAlias(1, 1, &CreateAliasInput{Alias: "bp"}); err != nil {
+			t.Fatalf("登録に失敗: %v", err)
+		}
+		if _, err := service.CreateAlias(1, 1, &CreateAliasInput{Alias: " BP "}); !errors.Is(err, ErrAliasAlreadyExists) {
+			t.Errorf("ErrAliasAlreadyExists を期待, 実際: %v", err)
+		}
+		if _, err := service.CreateAlias(1, 1, &CreateAliasInput{Alias: "　"}); !errors.Is(err, ErrInvalidAlias) {
+			t.Errorf("ErrInvalidAlias を期待, 実際: %v", err)
+		}
+	})
+
+	t.Run("他のユーザーの別名は削除できない", func(t *testing.T) {
+		service := NewWorkoutService(NewMockWorkoutRepository(), NewMockExerciseRepository(), nil, nil, nil, nil)
+		alias, err := service.CreateAlias(1, 1, &CreateAliasInput{Alias: "bp"})
+		if err != nil {
+			t.Fatalf("登録に失敗: %v", err)
+		}
+		if err := service.DeleteAlias(2, alias.ID); !errors.Is(err, ErrAliasNotFound) {
+			t.Errorf("ErrAliasNotFound を期待, 実際: %v", err)
+		}
+		if err := service.DeleteAlias(1, alias.ID); err != nil {
+			t.Errorf("削除に失敗: %v", err)
+		}
+	})
+}
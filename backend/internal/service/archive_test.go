@@ -0,0 +1,71 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWorkoutService_ArchiveCustomExercise(t *testing.T) {
+	t.Run("アーカイブした種目は一覧と検索に出ないが取得はできる", func(t *testing.T) {
+		service := NewWorkoutService(NewMockWorkoutRepository(), NewMockExerciseRepository(), nil, nil, nil, nil)
+		exercise, err := service.CreateCustomExercise(1, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest"})
+		if err != nil {
+			t.Fatalf("作成に失敗: %v", err)
+		}
+
+		archived, err := service.ArchiveCustomExercise(1, exercise.ID)
+		if err != nil {
+			t.Fatalf("アーカイブに失敗: %v", err)
+		}
+		if archived.ArchivedAt == nil {
+			t.Fatal("archived_at が設定されていない")
+		}
+
+		exercises, _ := service.GetExercises(1, ExerciseQuery{})
+		for _, e := range exercises {
+			if e.ID == exercise.ID {
+				t.Errorf("アーカイブした種目が一覧に含まれている: %+v", e)
+			}
+		}
+		if results, _ := service.SearchExercises(1, "ベンチ"); len(results) != 1 || results[0].ID != 1 {
+			t.Errorf("ベンチプレスのみを期待, 実際: %+v", results)
+		}
+		if custom, _ := service.GetCustomExercises(1); len(custom) != 1 {
+			t.Errorf("カスタム種目の一覧にはアーカイブ済みも含むことを期待, 実際: %+v", custom)
+		}
+
+		if _, err := service.UnarchiveCustomExercise(1, exercise.ID); err != nil {
+			t.Fatalf("アーカイブ解除に失敗: %v", err)
+		}
+		exercises, _ = service.GetExercises(1, ExerciseQuery{})
+		if len(exercises) != 4 {
+			t.Errorf("解除後は一覧に戻ることを期待, 実際: %+v", exercises)
+		}
+	})
+
+	t.Run("再度アーカイブしても日時は変わらない", func(t *testing.T) {
+		service := NewWorkoutService(NewMockWorkoutRepository(), NewMockExerciseRepository(), nil, nil, nil, nil)
+		exercise, _ := service.CreateCustomExercise(1, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest"})
+		first, _ := service.ArchiveCustomExercise(1, exercise.ID)
+		archivedAt := *first.ArchivedAt
+
+		second, err := service.ArchiveCustomExercise(1, exercise.ID)
+		if err != nil {
+			t.Fatalf("アーカイブに失敗: %v", err)
+		}
+		if !second.ArchivedAt.Equal(archivedAt) {
+			t.Errorf("日時が変わらないことを期待, 実際: %v → %v", archivedAt, second.ArchivedAt)
+		}
+	})
+
+	t.Run("プリセット種目と他のユーザーの種目はアーカイブできない", func(t *testing.T) {
+		service := NewWorkoutService(NewMockWorkoutRepository(), NewMockExerciseRepository(), nil, nil, nil, nil)
+		if _, err := service.ArchiveCustomExercise(1, 1); !errors.Is(err, ErrNotCustomExercise) {
+			t.Errorf("ErrNotCustomExercise を期待, 実際: %v", err)
+		}
+		exercise, _ := service.CreateCustomExercise(2, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest"})
+		if _, err := service.ArchiveCustomExercise(1, exercise.ID); !errors.Is(err, ErrNotCustomExercise) {
+			t.Errorf("ErrNotCustomExercise を期待, 実際: %v", err)
+		}
+	})
+}
@@ -14,10 +14,12 @@ import (
 
 type AuthService struct {
 	userRepo UserRepository
+	storage  MediaStorage
 }
 
-func NewAuthService(userRepo UserRepository) *AuthService {
-	return &AuthService{userRepo: userRepo}
+// NewAuthService の storage はアカウント削除時にカスタム種目の画像・動画を消す保存先（nil の場合は消さない）
+func NewAuthService(userRepo UserRepository, storage MediaStorage) *AuthService {
+	return &AuthService{userRepo: userRepo, storage: storage}
 }
 
 type RegisterInput struct {
@@ -98,11 +100,23 @@ func (s *AuthService) Login(input *LoginInput) (*AuthResponse, error) {
 }
 
 func (s *AuthService) GetUserByID(userID uint64) (*model.User, error) {
-	return s.userRepo.FindByID(userID)
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("finding user: %w", err)
+	}
+	return user, nil
 }
 
 func (s *AuthService) DeleteAccount(userID uint64) error {
-	return s.userRepo.DeleteWithAllData(userID)
+	mediaKeys, err := s.userRepo.DeleteWithAllData(userID)
+	if err != nil {
+		return err
+	}
+	deleteMediaFiles(s.storage, mediaKeys)
+	return nil
 }
 
 func (s *AuthService) generateToken(user *model.User) (string, error) {
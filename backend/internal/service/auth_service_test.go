@@ -59,14 +59,14 @@ func (r *MockUserRepository) ExistsByEmail(email string) (bool, error) {
 	return ok, nil
 }
 
-func (r *MockUserRepository) DeleteWithAllData(userID uint64) error {
+func (r *MockUserRepository) DeleteWithAllData(userID uint64) ([]string, error) {
 	for email, user := range r.users {
 		if user.ID == userID {
 			delete(r.users, email)
-			return nil
+			return nil, nil
 		}
 	}
-	return ErrUserNotFound
+	return nil, ErrUserNotFound
 }
 
 func TestAuthService_Register(t *testing.T) {
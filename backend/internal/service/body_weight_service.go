@@ -11,18 +11,22 @@ import (
 
 type BodyWeightService struct {
 	bodyWeightRepo BodyWeightRepository
+	achievements   *AchievementService
+	goals          *GoalService
 }
 
-func NewBodyWeightService(bodyWeightRepo BodyWeightRepository) *BodyWeightService {
+func NewBodyWeightService(bodyWeightRepo BodyWeightRepository, achievements *AchievementService, goals *GoalService) *BodyWeightService {
 	return &BodyWeightService{
 		bodyWeightRepo: bodyWeightRepo,
+		achievements:   achievements,
+		goals:          goals,
 	}
 }
 
 type CreateBodyWeightInput struct {
-	Date              string   `json:"date" validate:"required"`
+	Date              string   `json:"date" validate:"required,isodate"`
 	Weight            float64  `json:"weight" validate:"required,min=0.1,max=500"`
-	BodyFatPercentage *float64 `json:"body_fat_percentage"`
+	BodyFatPercentage *float64 `json:"body_fat_percentage" validate:"omitempty,gt=0,lt=100"`
 }
 
 type BodyWeightListResponse struct {
@@ -50,7 +54,7 @@ func (s *BodyWeightService) CreateOrUpdate(userID uint64, input *CreateBodyWeigh
 		if err := s.bodyWeightRepo.Create(record); err != nil {
 			return nil, err
 		}
-		return record, nil
+		return s.withNewAchievements(userID, record)
 	}
 
 	// found → update
@@ -59,7 +63,14 @@ func (s *BodyWeightService) CreateOrUpdate(userID uint64, input *CreateBodyWeigh
 	if err := s.bodyWeightRepo.Update(existing); err != nil {
 		return nil, err
 	}
-	return existing, nil
+	return s.withNewAchievements(userID, existing)
+}
+
+// withNewAchievements は体重の変化で新たに獲得した実績（自重ベンチなど）を記録に設定する
+// あわせて体重・体脂肪率の目標の達成を判定する
+func (s *BodyWeightService) withNewAchievements(userID uint64, record *model.BodyWeight) (*model.BodyWeight, error) {
+	record.NewAchievements = evaluateAfterSave(s.achievements, s.goals, userID)
+	return record, nil
 }
 
 func (s *BodyWeightService) GetRecords(userID uint64, limit int) ([]model.BodyWeight, error) {
@@ -80,7 +91,14 @@ func (s *BodyWeightService) GetRecordsByDateRange(userID uint64, startDate, endD
 }
 
 func (s *BodyWeightService) GetLatest(userID uint64) (*model.BodyWeight, error) {
-	return s.bodyWeightRepo.GetLatest(userID)
+	record, err := s.bodyWeightRepo.GetLatest(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBodyWeightNotFound
+		}
+		return nil, fmt.Errorf("finding latest body weight record: %w", err)
+	}
+	return record, nil
 }
 
 func (s *BodyWeightService) Delete(userID uint64, recordID uint64) error {
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ExerciseQuery は種目一覧の絞り込み条件と並び順（クエリパラメータの値）
+type ExerciseQuery struct {
+	MuscleGroup   string // 部位
+	Equipment     string // カンマ区切りの器具。いずれかを使う種目に絞り込む
+	AvailableOnly bool   // ユーザーの使える器具で実施できる種目のみ
+	Sort          string // recent|frequent|favorite（空の場合は部位・名前順）
+}
+
+// UpdateEquipmentInput は使える器具の設定（Equipment が null の場合は制限しない）
+type UpdateEquipmentInput struct {
+	Equipment *[]string `json:"equipment"`
+}
+
+// EquipmentProfile はユーザーの使える器具の設定
+type EquipmentProfile struct {
+	AvailableEquipment model.EquipmentList `json:"available_equipment"` // null の場合は制限なし
+	AllEquipment       []model.Equipment   `json:"all_equipment"`
+}
+
+func newEquipmentProfile(user *model.User) *EquipmentProfile {
+	return &EquipmentProfile{AvailableEquipment: user.AvailableEquipment, AllEquipment: model.AllEquipment}
+}
+
+// ParseEquipment は器具の一覧を検証する
+func ParseEquipment(values []string) (model.EquipmentList, error) {
+	list := make(model.EquipmentList, 0, len(values))
+	for _, v := range values {
+		e := model.Equipment(strings.TrimSpace(v))
+		if !e.IsValid() {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidEquipment, v)
+		}
+		if !list.Contains(e) {
+			list = append(list, e)
+		}
+	}
+	return list, nil
+}
+
+func (q ExerciseQuery) filter() (repository.ExerciseFilter, error) {
+	filter := repository.ExerciseFilter{
+		MuscleGroup:   model.MuscleGroup(q.MuscleGroup),
+		AvailableOnly: q.AvailableOnly,
+		Sort:          repository.ExerciseSort(q.Sort),
+	}
+	if !filter.Sort.IsValid() {
+		return filter, fmt.Errorf("%w: %s", ErrInvalidExerciseSort, q.Sort)
+	}
+	if q.Equipment != "" {
+		equipment, err := ParseEquipment(strings.Split(q.Equipment, ","))
+		if err != nil {
+			return filter, err
+		}
+		filter.Equipment = equipment
+	}
+	return filter, nil
+}
+
+// GetEquipmentProfile はユーザーの使える器具を返す
+func (s *AuthService) GetEquipmentProfile(userID uint64) (*EquipmentProfile, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("finding user: %w", err)
+	}
+	return newEquipmentProfile(user), nil
+}
+
+// UpdateEquipmentProfile はユーザーの使える器具を設定する
+func (s *AuthService) UpdateEquipmentProfile(userID uint64, input *UpdateEquipmentInput) (*EquipmentProfile, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("finding user: %w", err)
+	}
+
+	user.AvailableEquipment = nil
+	if input.Equipment != nil {
+		if user.AvailableEquipment, err = ParseEquipment(*input.Equipment); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+	return newEquipmentProfile(user), nil
+}
+
+// checkAvailableExercises は種目がすべてユーザーの使える器具で実施できるかを検証する
+func checkAvailableExercises(exerciseRepo ExerciseRepository, userID uint64, exerciseIDs []uint64) error {
+	available, err := exerciseRepo.FindFiltered(userID, repository.ExerciseFilter{AvailableOnly: true})
+	if err != nil {
+		return fmt.Errorf("finding available exercises: %w", err)
+	}
+	ids := make(map[uint64]bool, len(available))
+	for _, e := range available {
+		ids[e.ID] = true
+	}
+	for _, id := range exerciseIDs {
+		if !ids[id] {
+			return fmt.Errorf("%w: exercise %d", ErrExerciseNotAvailable, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/training-memo/backend/internal/model"
+)
+
+func TestParseEquipment(t *testing.T) {
+	t.Run("重複を除いて器具の一覧にする", func(t *testing.T) {
+		got, err := ParseEquipment([]string{"dumbbell", " band", "dumbbell"})
+		if err != nil {
+			t.Fatalf("解釈に失敗: %v", err)
+		}
+		if len(got) != 2 || got[0] != model.EquipmentDumbbell || got[1] != model.EquipmentBand {
+			t.Errorf("期待される器具: [dumbbell band], 実際: %v", got)
+		}
+	})
+
+	t.Run("未対応の器具はエラー", func(t *testing.T) {
+		if _, err := ParseEquipment([]string{"smith_machine"}); !errors.Is(err, ErrInvalidEquipment) {
+			t.Errorf("ErrInvalidEquipment を期待, 実際: %v", err)
+		}
+	})
+}
+
+func TestWorkoutService_GetExercises_Equipment(t *testing.T) {
+	newService := func(available model.EquipmentList) *WorkoutService {
+		exerciseRepo := NewMockExerciseRepository()
+		exerciseRepo.availableEquipment = available
+		service := NewWorkoutService(NewMockWorkoutRepository(), exerciseRepo, nil, nil, nil, nil)
+		for _, input := range []CreateExerciseInput{
+			{Name: "ダンベルプレス", MuscleGroup: "chest", Equipment: []string{"dumbbell"}},
+			{Name: "ディップス", MuscleGroup: "chest", Equipment: []string{"bodyweight"}},
+		} {
+			input := input
+			if _, err := service.CreateCustomExercise(1, &input); err != nil {
+				t.Fatalf("種目の作成に失敗: %v", err)
+			}
+		}
+		return service
+	}
+	names := func(exercises []model.Exercise) []string {
+		var result []string
+		for _, e := range exercises {
+			result = append(result, e.Name)
+		}
+		return result
+	}
+
+	t.Run("いずれかの器具を使う種目に絞り込む", func(t *testing.T) {
+		got, err := newService(nil).GetExercises(1, ExerciseQuery{MuscleGroup: "chest", Equipment: "dumbbell,bodyweight"})
+		if err != nil {
+			t.Fatalf("取得に失敗: %v", err)
+		}
+		if n := names(got); len(n) != 2 || n[0] != "ダンベルプレス" || n[1] != "ディップス" {
+			t.Errorf("期待される種目: [ダンベルプレス ディップス], 実際: %v", n)
+		}
+	})
+
+	t.Run("使える器具で実施できる種目のみ（自重は常に使える）", func(t *testing.T) {
+		got, err := newService(model.EquipmentList{model.EquipmentDumbbell}).GetExercises(1, ExerciseQuery{AvailableOnly: true})
+		if err != nil {
+			t.Fatalf("取得に失敗: %v", err)
+		}
+		if n := names(got); len(n) != 2 || n[0] != "ダンベルプレス" || n[1] != "ディップス" {
+			t.Errorf("バーベル種目を除くことを期待, 実際: %v", n)
+		}
+	})
+
+	t.Run("器具の設定がない場合は制限しない", func(t *testing.T) {
+		got, err := newService(nil).GetExercises(1, ExerciseQuery{AvailableOnly: true})
+		if err != nil {
+			t.Fatalf("取得に失敗: %v", err)
+		}
+		if len(got) != 5 {
+			t.Errorf("期待される種目数: 5, 実際: %d", len(got))
+		}
+	})
+
+	t.Run("使えない器具の種目を含むメニューはエラー", func(t *testing.T) {
+		exerciseRepo := NewMockExerciseRepository()
+		exerciseRepo.availableEquipment = model.EquipmentList{model.EquipmentDumbbell}
+		if err := checkAvailableExercises(exerciseRepo, 1, []uint64{1}); !errors.Is(err, ErrExerciseNotAvailable) {
+			t.Errorf("ErrExerciseNotAvailable を期待, 実際: %v", err)
+		}
+	})
+}
+
+func TestAuthService_UpdateEquipmentProfile(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	user := &model.User{Email: "home@example.com", Name: "Home"}
+	_ = userRepo.Create(user)
+	service := NewAuthService(userRepo, nil)
+
+	t.Run("使える器具を設定できる", func(t *testing.T) {
+		equipment := []string{"dumbbell", "band"}
+		profile, err := service.UpdateEquipmentProfile(user.ID, &UpdateEquipmentInput{Equipment: &equipment})
+		if err != nil {
+			t.Fatalf("更新に失敗: %v", err)
+		}
+		if len(profile.AvailableEquipment) != 2 || len(profile.AllEquipment) != len(model.AllEquipment) {
+			t.Errorf("設定した器具を期待, 実際: %+v", profile)
+		}
+	})
+
+	t.Run("null を指定すると制限をなくす", func(t *testing.T) {
+		profile, err := service.UpdateEquipmentProfile(user.ID, &UpdateEquipmentInput{})
+		if err != nil {
+			t.Fatalf("更新に失敗: %v", err)
+		}
+		if profile.AvailableEquipment != nil {
+			t.Errorf("制限なし（nil）を期待, 実際: %v", profile.AvailableEquipment)
+		}
+	})
+}
@@ -10,18 +10,59 @@ var (
 	ErrInvalidCredentials = errors.New("invalid email or password")
 	ErrEmailAlreadyExists = errors.New("email already exists")
 	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidLocale      = errors.New("invalid locale")
 
 	// Workout errors
 	ErrWorkoutNotFound = errors.New("workout not found")
+	ErrInvalidRPE      = errors.New("invalid rpe")
 
 	// Exercise errors
-	ErrExerciseNotFound  = errors.New("exercise not found")
-	ErrExerciseInUse     = errors.New("exercise is in use")
-	ErrNotCustomExercise = errors.New("cannot modify preset exercise")
+	ErrExerciseNotFound     = errors.New("exercise not found")
+	ErrExerciseInUse        = errors.New("exercise is in use")
+	ErrNotCustomExercise    = errors.New("cannot modify preset exercise")
+	ErrInvalidMuscle        = errors.New("invalid exercise muscle")
+	ErrInvalidEquipment     = errors.New("invalid equipment")
+	ErrExerciseNotAvailable = errors.New("exercise requires unavailable equipment")
+	ErrInvalidExerciseSort  = errors.New("invalid exercise sort")
+	ErrInvalidAlias         = errors.New("invalid alias")
+	ErrAliasNotFound        = errors.New("alias not found")
+	ErrAliasAlreadyExists   = errors.New("alias already exists")
+	ErrInvalidMergeTarget   = errors.New("invalid merge target")
+	ErrInvalidContent       = errors.New("invalid exercise content")
+	ErrInvalidVariation     = errors.New("invalid exercise variation")
+	ErrInvalidProgression   = errors.New("invalid exercise progression")
+	// ErrInvalidExerciseReference は ExerciseReferenceError でラップして返す
+	ErrInvalidExerciseReference = errors.New("invalid exercise reference")
+
+	// Exercise media errors
+	ErrMediaNotFound        = errors.New("media not found")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrMediaTooLarge        = errors.New("media too large")
+	ErrTooManyMedia         = errors.New("too many media")
+	ErrMediaUnavailable     = errors.New("media storage unavailable")
 
 	// Menu errors
-	ErrMenuNotFound = errors.New("menu not found")
+	ErrMenuNotFound      = errors.New("menu not found")
+	ErrInvalidMenuTarget = errors.New("invalid menu item target")
+
+	// Stats errors
+	ErrInvalidFormula          = errors.New("invalid one rep max formula")
+	ErrInvalidBucket           = errors.New("invalid bucket")
+	ErrInvalidDateRange        = errors.New("invalid date range")
+	ErrInvalidWeeklyTarget     = errors.New("invalid weekly target")
+	ErrInvalidIntensity        = errors.New("invalid intensity metric")
+	ErrInvalidLoadMetric       = errors.New("invalid load metric")
+	ErrInvalidPlateauThreshold = errors.New("invalid plateau threshold")
+	ErrInvalidSex              = errors.New("invalid sex")
+	ErrInvalidRollUp           = errors.New("invalid rollup")
+
+	// Report errors
+	ErrInvalidReportPeriod = errors.New("invalid report period")
 
 	// Body weight errors
 	ErrBodyWeightNotFound = errors.New("body weight record not found")
+
+	// Goal errors
+	ErrGoalNotFound = errors.New("goal not found")
+	ErrInvalidGoal  = errors.New("invalid goal")
 )
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/training-memo/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxContentItems は手順・ポイント・よくある間違いそれぞれの最大件数
+	maxContentItems = 20
+	// maxContentLength は手順・ポイント・よくある間違いの1件あたりの最大文字数
+	maxContentLength = 500
+	// maxMediaPerExercise は1種目あたりの添付ファイルの最大数
+	maxMediaPerExercise = 10
+	// maxImageSize・maxVideoSize は添付ファイルの最大サイズ（バイト）
+	maxImageSize = 5 << 20
+	maxVideoSize = 50 << 20
+)
+
+// mediaFormats は添付できるファイル形式と保存時の拡張子
+// 形式はアップロード時の Content-Type ではなくファイルの先頭から判定する
+var mediaFormats = map[string]struct {
+	mediaType model.MediaType
+	ext       string
+}{
+	"image/jpeg": {model.MediaTypeImage, ".jpg"},
+	"image/png":  {model.MediaTypeImage, ".png"},
+	"image/gif":  {model.MediaTypeImage, ".gif"},
+	"image/webp": {model.MediaTypeImage, ".webp"},
+	"video/mp4":  {model.MediaTypeVideo, ".mp4"},
+	"video/webm": {model.MediaTypeVideo, ".webm"},
+}
+
+// ExerciseContentService は種目の詳細（解説と画像・動画）を扱う
+// storage が nil の場合（起動時に保存先を用意できなかった場合）は画像・動画の追加・削除・取得が ErrMediaUnavailable になる
+type ExerciseContentService struct {
+	exerciseRepo ExerciseRepository
+	storage      MediaStorage
+}
+
+func NewExerciseContentService(exerciseRepo ExerciseRepository, storage MediaStorage) *ExerciseContentService {
+	return &ExerciseContentService{
+		exerciseRepo: exerciseRepo,
+		storage:      storage,
+	}
+}
+
+// UploadMediaInput はアップロードされたファイル（Size は申告されたサイズ）
+type UploadMediaInput struct {
+	Size int64
+	Body io.Reader
+}
+
+// GetExercise は種目を解説・画像・動画を含めて返す
+func (s *ExerciseContentService) GetExercise(userID, exerciseID uint64) (*model.Exercise, error) {
+	exercise, err := findVisibleExercise(s.exerciseRepo, userID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range exercise.Media {
+		exercise.Media[i].URL = mediaURL(&exercise.Media[i])
+	}
+	return exercise, nil
+}
+
+// OpenMedia は種目の画像・動画を開く（閲覧できる種目のもののみ）
+func (s *ExerciseContentService) OpenMedia(userID, exerciseID, mediaID uint64) (*model.ExerciseMedia, io.ReadCloser, error) {
+	if s.storage == nil {
+		return nil, nil, ErrMediaUnavailable
+	}
+	if _, err := findVisibleExercise(s.exerciseRepo, userID, exerciseID); err != nil {
+		return nil, nil, err
+	}
+	media, err := s.findMedia(exerciseID, mediaID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := s.storage.Open(media.StorageKey)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, ErrMediaNotFound
+		}
+		return nil, nil, fmt.Errorf("opening media: %w", err)
+	}
+	return media, body, nil
+}
+
+// UploadMedia はユーザーのカスタム種目に画像・動画を追加する
+func (s *ExerciseContentService) UploadMedia(userID, exerciseID uint64, input *UploadMediaInput) (*model.ExerciseMedia, error) {
+	if s.storage == nil {
+		return nil, ErrMediaUnavailable
+	}
+	if _, err := s.findOwnCustomExercise(userID, exerciseID); err != nil {
+		return nil, err
+	}
+
+	count, err := s.exerciseRepo.CountMedia(exerciseID)
+	if err != nil {
+		return nil, fmt.Errorf("counting media: %w", err)
+	}
+	if count >= maxMediaPerExercise {
+		return nil, fmt.Errorf("%w: at most %d files per exercise", ErrTooManyMedia, maxMediaPerExercise)
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(input.Body, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("%w: empty file", ErrUnsupportedMediaType)
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	format, ok := mediaFormats[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedMediaType, contentType)
+	}
+	limit := int64(maxImageSize)
+	if format.mediaType == model.MediaTypeVideo {
+		limit = maxVideoSize
+	}
+	if input.Size > limit {
+		return nil, fmt.Errorf("%w: %s must be at most %d bytes", ErrMediaTooLarge, format.mediaType, limit)
+	}
+
+	key, err := mediaKey(exerciseID, format.ext)
+	if err != nil {
+		return nil, err
+	}
+	// 申告されたサイズが正しくない場合に備え、保存中も上限を確認する
+	body := &sizeLimitedReader{r: io.MultiReader(bytes.NewReader(head), input.Body), remaining: limit}
+	if err := s.storage.Save(key, body); err != nil {
+		s.storage.Delete(key)
+		if errors.Is(err, ErrMediaTooLarge) {
+			return nil, fmt.Errorf("%w: %s must be at most %d bytes", ErrMediaTooLarge, format.mediaType, limit)
+		}
+		return nil, fmt.Errorf("saving media: %w", err)
+	}
+
+	media := &model.ExerciseMedia{
+		ExerciseID:  exerciseID,
+		MediaType:   format.mediaType,
+		StorageKey:  key,
+		ContentType: contentType,
+		Size:        limit - body.remaining,
+	}
+	if err := s.exerciseRepo.CreateMedia(media); err != nil {
+		s.storage.Delete(key)
+		return nil, fmt.Errorf("creating media: %w", err)
+	}
+	media.URL = mediaURL(media)
+	return media, nil
+}
+
+// DeleteMedia はユーザーのカスタム種目から画像・動画を削除する
+func (s *ExerciseContentService) DeleteMedia(userID, exerciseID, mediaID uint64) error {
+	if s.storage == nil {
+		return ErrMediaUnavailable
+	}
+	if _, err := s.findOwnCustomExercise(userID, exerciseID); err != nil {
+		return err
+	}
+	media, err := s.findMedia(exerciseID, mediaID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.exerciseRepo.DeleteMedia(mediaID); err != nil {
+		return fmt.Errorf("deleting media: %w", err)
+	}
+	deleteMediaFiles(s.storage, []string{media.StorageKey})
+	return nil
+}
+
+// findMedia は種目 exerciseID の画像・動画を取得する
+func (s *ExerciseContentService) findMedia(exerciseID, mediaID uint64) (*model.ExerciseMedia, error) {
+	media, err := s.exerciseRepo.FindMediaByID(mediaID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMediaNotFound
+		}
+		return nil, fmt.Errorf("finding media: %w", err)
+	}
+	if media.ExerciseID != exerciseID {
+		return nil, ErrMediaNotFound
+	}
+	return media, nil
+}
+
+// findOwnCustomExercise はユーザー自身のカスタム種目を取得する
+func (s *ExerciseContentService) findOwnCustomExercise(userID, exerciseID uint64) (*model.Exercise, error) {
+	exercise, err := s.exerciseRepo.FindByID(exerciseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExerciseNotFound
+		}
+		return nil, fmt.Errorf("finding exercise: %w", err)
+	}
+	if !exercise.IsCustom || exercise.UserID == nil || *exercise.UserID != userID {
+		return nil, ErrNotCustomExercise
+	}
+	return exercise, nil
+}
+
+// applyExerciseContent は入力された解説を検証して種目に設定する（省略された項目は変更しない）
+func applyExerciseContent(exercise *model.Exercise, input *CreateExerciseInput) error {
+	fields := []struct {
+		name   string
+		values []string
+		dest   *model.TextList
+	}{
+		{"instructions", input.Instructions, &exercise.Instructions},
+		{"cues", input.Cues, &exercise.Cues},
+		{"common_mistakes", input.CommonMistakes, &exercise.CommonMistakes},
+	}
+	for _, f := range fields {
+		if f.values == nil {
+			continue
+		}
+		list, err := parseTextList(f.name, f.values)
+		if err != nil {
+			return err
+		}
+		*f.dest = list
+	}
+	return nil
+}
+
+// parseTextList は前後の空白を除いた文章の一覧を返す
+func parseTextList(name string, values []string) (model.TextList, error) {
+	if len(values) > maxContentItems {
+		return nil, fmt.Errorf("%w: %s must have at most %d items", ErrInvalidContent, name, maxContentItems)
+	}
+	list := make(model.TextList, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return nil, fmt.Errorf("%w: %s must not contain empty items", ErrInvalidContent, name)
+		}
+		if utf8.RuneCountInString(v) > maxContentLength {
+			return nil, fmt.Errorf("%w: each item of %s must be at most %d characters", ErrInvalidContent, name, maxContentLength)
+		}
+		list = append(list, v)
+	}
+	return list, nil
+}
+
+// deleteMediaFiles は削除したレコードの画像・動画のファイルを消す（storage が nil の場合は何もしない）
+// レコードを消した後はファイルが残っても参照されないため、削除の失敗は無視する
+func deleteMediaFiles(storage MediaStorage, keys []string) {
+	if storage == nil {
+		return
+	}
+	for _, key := range keys {
+		storage.Delete(key)
+	}
+}
+
+// mediaURL は画像・動画を配信するURL（認証が必要な API）を返す
+func mediaURL(media *model.ExerciseMedia) string {
+	return fmt.Sprintf("/api/v1/exercises/%d/media/%d", media.ExerciseID, media.ID)
+}
+
+// mediaKey は種目の添付ファイルの保存先のキーを生成する
+func mediaKey(exerciseID uint64, ext string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating media key: %w", err)
+	}
+	return fmt.Sprintf("exercises/%d/%s%s", exerciseID, hex.EncodeToString(b), ext), nil
+}
+
+// sizeLimitedReader は remaining バイトを超えて読み込もうとすると ErrMediaTooLarge を返す
+type sizeLimitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, ErrMediaTooLarge
+	}
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, ErrMediaTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/training-memo/backend/internal/model"
+)
+
+// MockMediaStorage はテスト用のメモリ上のストレージ
+type MockMediaStorage struct {
+	files map[string][]byte
+}
+
+func NewMockMediaStorage() *MockMediaStorage {
+	return &MockMediaStorage{files: make(map[string][]byte)}
+}
+
+func (s *MockMediaStorage) Save(key string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.files[key] = data
+	return nil
+}
+
+func (s *MockMediaStorage) Delete(key string) error {
+	delete(s.files, key)
+	return nil
+}
+
+func (s *MockMediaStorage) Open(key string) (io.ReadCloser, error) {
+	data, ok := s.files[key]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// pngHeader は PNG として判定されるファイルの先頭
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+func TestWorkoutService_ExerciseContent(t *testing.T) {
+	t.Run("解説を登録し、省略した項目は更新しない", func(t *testing.T) {
+		service := NewWorkoutService(NewMockWorkoutRepository(), NewMockExerciseRepository(), nil, nil, nil, nil)
+		exercise, err := service.CreateCustomExercise(1, &CreateExerciseInput{
+			Name:         "ベンチ",
+			MuscleGroup:  "chest",
+			Instructions: []string{" ベンチに寝る ", "バーを押し上げる"},
+			Cues:         []string{"肩甲骨を寄せる"},
+		})
+		if err != nil {
+			t.Fatalf("作成に失敗: %v", err)
+		}
+		if len(exercise.Instructions) != 2 || exercise.Instructions[0] != "ベンチに寝る" {
+			t.Errorf("前後の空白を除いた手順を期待, 実際: %v", exercise.Instructions)
+		}
+
+		updated, err := service.UpdateCustomExercise(1, exercise.ID, &UpdateExerciseInput{
+			Name:           "ベンチ",
+			MuscleGroup:    "chest",
+			CommonMistakes: []string{"お尻が浮く"},
+		})
+		if err != nil {
+			t.Fatalf("更新に失敗: %v", err)
+		}
+		if len(updated.Instructions) != 2 || len(updated.Cues) != 1 || len(updated.CommonMistakes) != 1 {
+			t.Errorf("省略した項目は変わらないことを期待, 実際: %+v", updated)
+		}
+	})
+
+	t.Run("空の項目は登録できない", func(t *testing.T) {
+		service := NewWorkoutService(NewMockWorkoutRepository(), NewMockExerciseRepository(), nil, nil, nil, nil)
+		_, err := service.CreateCustomExercise(1, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest", Cues: []string{"　"}})
+		if !errors.Is(err, ErrInvalidContent) {
+			t.Errorf("ErrInvalidContent を期待, 実際: %v", err)
+		}
+	})
+}
+
+func TestExerciseContentService_Media(t *testing.T) {
+	newService := func() (*ExerciseContentService, *WorkoutService, *MockMediaStorage) {
+		exerciseRepo := NewMockExerciseRepository()
+		storage := NewMockMediaStorage()
+		return NewExerciseContentService(exerciseRepo, storage), NewWorkoutService(NewMockWorkoutRepository(), exerciseRepo, nil, nil, nil, nil), storage
+	}
+
+	t.Run("画像を追加して種目詳細で取得できる", func(t *testing.T) {
+		service, workoutService, storage := newService()
+		exercise, _ := workoutService.CreateCustomExercise(1, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest"})
+
+		body := append(append([]byte{}, pngHeader...), make([]byte, 100)...)
+		media, err := service.UploadMedia(1, exercise.ID, &UploadMediaInput{Size: int64(len(body)), Body: bytes.NewReader(body)})
+		if err != nil {
+			t.Fatalf("追加に失敗: %v", err)
+		}
+		if media.MediaType != "image" || media.ContentType != "image/png" || media.Size != int64(len(body)) {
+			t.Errorf("PNG画像として保存されることを期待, 実際: %+v", media)
+		}
+		if !bytes.Equal(storage.files[media.StorageKey], body) {
+			t.Error("ファイルが保存されていない")
+		}
+
+		detail, err := service.GetExercise(1, exercise.ID)
+		if err != nil {
+			t.Fatalf("取得に失敗: %v", err)
+		}
+		if len(detail.Media) != 1 || detail.Media[0].URL != fmt.Sprintf("/api/v1/exercises/%d/media/%d", exercise.ID, media.ID) {
+			t.Errorf("URL付きの画像を期待, 実際: %+v", detail.Media)
+		}
+
+		_, file, err := service.OpenMedia(1, exercise.ID, media.ID)
+		if err != nil {
+			t.Fatalf("読み出しに失敗: %v", err)
+		}
+		data, _ := io.ReadAll(file)
+		file.Close()
+		if !bytes.Equal(data, body) {
+			t.Error("保存した内容を読み出せていない")
+		}
+		// 他のユーザーのカスタム種目の画像は配信しない
+		if _, _, err := service.OpenMedia(2, exercise.ID, media.ID); !errors.Is(err, ErrExerciseNotFound) {
+			t.Errorf("ErrExerciseNotFound を期待, 実際: %v", err)
+		}
+
+		if err := service.DeleteMedia(1, exercise.ID, media.ID); err != nil {
+			t.Fatalf("削除に失敗: %v", err)
+		}
+		if _, ok := storage.files[media.StorageKey]; ok {
+			t.Error("ファイルが削除されていない")
+		}
+	})
+
+	t.Run("対応していない形式やサイズ超過は追加できない", func(t *testing.T) {
+		service, workoutService, storage := newService()
+		exercise, _ := workoutService.CreateCustomExercise(1, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest"})
+
+		text := []byte("just some text")
+		if _, err := service.UploadMedia(1, exercise.ID, &UploadMediaInput{Size: int64(len(text)), Body: bytes.NewReader(text)}); !errors.Is(err, ErrUnsupportedMediaType) {
+			t.Errorf("ErrUnsupportedMediaType を期待, 実際: %v", err)
+		}
+
+		// 申告されたサイズが小さくても実際のサイズで判定する
+		large := append(append([]byte{}, pngHeader...), make([]byte, maxImageSize)...)
+		if _, err := service.UploadMedia(1, exercise.ID, &UploadMediaInput{Size: 100, Body: bytes.NewReader(large)}); !errors.Is(err, ErrMediaTooLarge) {
+			t.Errorf("ErrMediaTooLarge を期待, 実際: %v", err)
+		}
+		if len(storage.files) != 0 {
+			t.Errorf("ファイルが残っていないことを期待, 実際: %d件", len(storage.files))
+		}
+	})
+
+	t.Run("プリセット種目や他のユーザーの種目には追加できない", func(t *testing.T) {
+		service, workoutService, _ := newService()
+		other, _ := workoutService.CreateCustomExercise(2, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest"})
+
+		for _, id := range []uint64{1, other.ID} {
+			_, err := service.UploadMedia(1, id, &UploadMediaInput{Body: bytes.NewReader(pngHeader)})
+			if !errors.Is(err, ErrNotCustomExercise) {
+				t.Errorf("種目%d: ErrNotCustomExercise を期待, 実際: %v", id, err)
+			}
+		}
+		if _, err := service.GetExercise(1, other.ID); !errors.Is(err, ErrExerciseNotFound) {
+			t.Errorf("ErrExerciseNotFound を期待, 実際: %v", err)
+		}
+	})
+
+	t.Run("保存先がない場合は画像・動画を扱えない", func(t *testing.T) {
+		exerciseRepo := NewMockExerciseRepository()
+		service := NewExerciseContentService(exerciseRepo, nil)
+		exercise, _ := NewWorkoutService(NewMockWorkoutRepository(), exerciseRepo, nil, nil, nil, nil).
+			CreateCustomExercise(1, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest"})
+
+		if _, err := service.UploadMedia(1, exercise.ID, &UploadMediaInput{Body: bytes.NewReader(pngHeader)}); !errors.Is(err, ErrMediaUnavailable) {
+			t.Errorf("ErrMediaUnavailable を期待, 実際: %v", err)
+		}
+		if _, err := service.GetExercise(1, exercise.ID); err != nil {
+			t.Errorf("種目詳細は取得できるはず: %v", err)
+		}
+	})
+}
+
+func TestWorkoutService_CustomExerciseMediaFiles(t *testing.T) {
+	newService := func() (*ExerciseContentService, *WorkoutService, *MockMediaStorage) {
+		workoutRepo := NewMockWorkoutRepository()
+		exerciseRepo := NewMockExerciseRepository()
+		exerciseRepo.workouts = workoutRepo
+		storage := NewMockMediaStorage()
+		tracker := NewPersonalRecordTracker(workoutRepo, workoutRepo.records)
+		return NewExerciseContentService(exerciseRepo, storage), NewWorkoutService(workoutRepo, exerciseRepo, tracker, nil, nil, storage), storage
+	}
+	upload := func(t *testing.T, service *ExerciseContentService, exerciseID uint64) *model.ExerciseMedia {
+		t.Helper()
+		media, err := service.UploadMedia(1, exerciseID, &UploadMediaInput{Size: int64(len(pngHeader)), Body: bytes.NewReader(pngHeader)})
+		if err != nil {
+			t.Fatalf("追加に失敗: %v", err)
+		}
+		return media
+	}
+
+	t.Run("カスタム種目を削除するとファイルも削除する", func(t *testing.T) {
+		contentService, workoutService, storage := newService()
+		exercise, _ := workoutService.CreateCustomExercise(1, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest"})
+		upload(t, contentService, exercise.ID)
+
+		if err := workoutService.DeleteCustomExercise(1, exercise.ID); err != nil {
+			t.Fatalf("削除に失敗: %v", err)
+		}
+		if len(storage.files) != 0 {
+			t.Errorf("ファイルが残っていないことを期待, 実際: %d件", len(storage.files))
+		}
+	})
+
+	t.Run("自分のカスタム種目への統合では画像を移し、プリセット種目への統合では削除する", func(t *testing.T) {
+		contentService, workoutService, storage := newService()
+		source, _ := workoutService.CreateCustomExercise(1, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest"})
+		target, _ := workoutService.CreateCustomExercise(1, &CreateExerciseInput{Name: "ベンチプレス", MuscleGroup: "chest"})
+		moved := upload(t, contentService, source.ID)
+
+		if _, err := workoutService.MergeCustomExercise(1, source.ID, target.ID); err != nil {
+			t.Fatalf("統合に失敗: %v", err)
+		}
+		detail, _ := contentService.GetExercise(1, target.ID)
+		if len(detail.Media) != 1 || detail.Media[0].ID != moved.ID {
+			t.Errorf("統合先に画像が移ることを期待, 実際: %+v", detail.Media)
+		}
+		if _, ok := storage.files[moved.StorageKey]; !ok {
+			t.Error("移した画像のファイルが削除されている")
+		}
+
+		removed := upload(t, contentService, target.ID)
+		if _, err := workoutService.MergeCustomExercise(1, target.ID, 1); err != nil {
+			t.Fatalf("統合に失敗: %v", err)
+		}
+		if len(storage.files) != 0 {
+			t.Errorf("プリセット種目に統合した画像のファイルは削除されるはず, 実際: %d件 (%s)", len(storage.files), removed.StorageKey)
+		}
+		preset, _ := contentService.GetExercise(1, 1)
+		if len(preset.Media) != 0 {
+			t.Errorf("プリセット種目に画像が移っている: %+v", preset.Media)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InvalidExerciseReference は入力のうち使えない種目を参照している項目
+// 他のユーザーのカスタム種目は存在を明かさないよう、存在しない種目と同じく not_found とする
+type InvalidExerciseReference struct {
+	Field      string `json:"field"` // 例: sets[2].exercise_id
+	ExerciseID uint64 `json:"exercise_id"`
+	Reason     string `json:"reason"`
+}
+
+// ExerciseReferenceError は入力が使えない種目を参照している場合のエラー
+type ExerciseReferenceError struct {
+	Invalid []InvalidExerciseReference
+}
+
+func (e *ExerciseReferenceError) Error() string {
+	fields := make([]string, len(e.Invalid))
+	for i, ref := range e.Invalid {
+		fields[i] = ref.Field
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidExerciseReference, strings.Join(fields, ", "))
+}
+
+func (e *ExerciseReferenceError) Unwrap() error {
+	return ErrInvalidExerciseReference
+}
+
+// exerciseReference は入力の項目と参照している種目
+type exerciseReference struct {
+	field      string
+	exerciseID uint64
+}
+
+// checkExerciseReferences は参照している種目をまとめて取得し、プリセット種目かユーザーのカスタム種目であることを確認する
+// アーカイブ済みのカスタム種目は過去の記録を編集できるよう参照を認める
+func checkExerciseReferences(exerciseRepo ExerciseRepository, userID uint64, refs []exerciseReference) error {
+	seen := make(map[uint64]bool, len(refs))
+	ids := make([]uint64, 0, len(refs))
+	for _, ref := range refs {
+		if !seen[ref.exerciseID] {
+			seen[ref.exerciseID] = true
+			ids = append(ids, ref.exerciseID)
+		}
+	}
+
+	exercises, err := exerciseRepo.FindByIDs(ids)
+	if err != nil {
+		return fmt.Errorf("finding exercises: %w", err)
+	}
+	visible := make(map[uint64]bool, len(exercises))
+	for _, e := range exercises {
+		if !e.IsCustom || (e.UserID != nil && *e.UserID == userID) {
+			visible[e.ID] = true
+		}
+	}
+
+	var invalid []InvalidExerciseReference
+	for _, ref := range refs {
+		if !visible[ref.exerciseID] {
+			invalid = append(invalid, InvalidExerciseReference{
+				Field:      ref.field,
+				ExerciseID: ref.exerciseID,
+				Reason:     "not_found",
+			})
+		}
+	}
+	if len(invalid) > 0 {
+		return &ExerciseReferenceError{Invalid: invalid}
+	}
+	return nil
+}
+
+// setExerciseReferences はワークアウトのセットが参照している種目
+func setExerciseReferences(sets []CreateSetInput) []exerciseReference {
+	refs := make([]exerciseReference, len(sets))
+	for i, set := range sets {
+		refs[i] = exerciseReference{field: fmt.Sprintf("sets[%d].exercise_id", i), exerciseID: set.ExerciseID}
+	}
+	return refs
+}
+
+// itemExerciseReferences はメニュー項目が参照している種目
+func itemExerciseReferences(items []CreateItemInput) []exerciseReference {
+	refs := make([]exerciseReference, len(items))
+	for i, item := range items {
+		refs[i] = exerciseReference{field: fmt.Sprintf("items[%d].exercise_id", i), exerciseID: item.ExerciseID}
+	}
+	return refs
+}
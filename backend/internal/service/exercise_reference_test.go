@@ -0,0 +1,79 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckExerciseReferences(t *testing.T) {
+	newService := func() (*WorkoutService, *MockExerciseRepository) {
+		workoutRepo := NewMockWorkoutRepository()
+		exerciseRepo := NewMockExerciseRepository()
+		tracker := NewPersonalRecordTracker(workoutRepo, workoutRepo.records)
+		achievements := NewAchievementService(&MockAchievementRepository{}, workoutRepo, exerciseRepo, &MockBodyWeightRepository{}, DefaultAchievementRules)
+		return NewWorkoutService(workoutRepo, exerciseRepo, tracker, achievements, NewGoalService(&MockGoalRepository{}, workoutRepo, exerciseRepo, &MockBodyWeightRepository{}), nil), exerciseRepo
+	}
+
+	t.Run("他のユーザーのカスタム種目と存在しない種目を項目ごとに返す", func(t *testing.T) {
+		service, _ := newService()
+		other, _ := service.CreateCustomExercise(2, &CreateExerciseInput{Name: "秘密の種目", MuscleGroup: "chest"})
+
+		_, err := service.CreateWorkout(1, &CreateWorkoutInput{
+			Date: "2026-10-01",
+			Sets: []CreateSetInput{
+				{ExerciseID: 1, SetNumber: 1, Weight: 100, Reps: 5},
+				{ExerciseID: other.ID, SetNumber: 1, Weight: 50, Reps: 10},
+				{ExerciseID: 999, SetNumber: 1, Weight: 50, Reps: 10},
+			},
+		})
+
+		var refErr *ExerciseReferenceError
+		if !errors.As(err, &refErr) {
+			t.Fatalf("ExerciseReferenceError が返されるべき, 実際: %v", err)
+		}
+		if !errors.Is(err, ErrInvalidExerciseReference) {
+			t.Error("ErrInvalidExerciseReference として判定できるべき")
+		}
+		if len(refErr.Invalid) != 2 {
+			t.Fatalf("2件の不正な項目を期待, 実際: %+v", refErr.Invalid)
+		}
+		if refErr.Invalid[0].Field != "sets[1].exercise_id" || refErr.Invalid[0].ExerciseID != other.ID {
+			t.Errorf("1件目: 期待: sets[1].exercise_id, 実際: %+v", refErr.Invalid[0])
+		}
+		if refErr.Invalid[1].Field != "sets[2].exercise_id" || refErr.Invalid[1].Reason != "not_found" {
+			t.Errorf("2件目: 期待: sets[2].exercise_id, 実際: %+v", refErr.Invalid[1])
+		}
+	})
+
+	t.Run("自分のアーカイブ済みのカスタム種目は記録できる", func(t *testing.T) {
+		service, exerciseRepo := newService()
+		own, _ := service.CreateCustomExercise(1, &CreateExerciseInput{Name: "マイ種目", MuscleGroup: "chest"})
+		archivedAt := time.Now()
+		exerciseRepo.exercises[own.ID].ArchivedAt = &archivedAt
+
+		if _, err := service.CreateWorkout(1, &CreateWorkoutInput{
+			Date: "2026-10-01",
+			Sets: []CreateSetInput{{ExerciseID: own.ID, SetNumber: 1, Weight: 50, Reps: 10}},
+		}); err != nil {
+			t.Errorf("予期しないエラー: %v", err)
+		}
+	})
+
+	t.Run("メニューの項目も検証する", func(t *testing.T) {
+		_, exerciseRepo := newService()
+		menuService := NewMenuService(nil, exerciseRepo, nil)
+
+		_, err := menuService.CreateMenu(1, &CreateMenuInput{
+			Name: "胸の日",
+			Items: []CreateItemInput{
+				{ExerciseID: 999, OrderNumber: 1, TargetSets: 3, TargetReps: 10},
+			},
+		})
+
+		var refErr *ExerciseReferenceError
+		if !errors.As(err, &refErr) || len(refErr.Invalid) != 1 || refErr.Invalid[0].Field != "items[0].exercise_id" {
+			t.Errorf("items[0].exercise_id の ExerciseReferenceError を期待, 実際: %v", err)
+		}
+	})
+}
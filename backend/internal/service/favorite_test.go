@@ -0,0 +1,49 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWorkoutService_Favorite(t *testing.T) {
+	t.Run("お気に入りの種目を先頭に並べる", func(t *testing.T) {
+		service := NewWorkoutService(NewMockWorkoutRepository(), NewMockExerciseRepository(), nil, nil, nil, nil)
+		if err := service.AddFavorite(1, 3); err != nil {
+			t.Fatalf("登録に失敗: %v", err)
+		}
+
+		exercises, err := service.GetExercises(1, ExerciseQuery{Sort: "favorite"})
+		if err != nil {
+			t.Fatalf("取得に失敗: %v", err)
+		}
+		if exercises[0].ID != 3 || !exercises[0].IsFavorite || exercises[1].IsFavorite {
+			t.Errorf("デッドリフトが先頭でお気に入りであることを期待, 実際: %+v", exercises)
+		}
+
+		if err := service.RemoveFavorite(1, 3); err != nil {
+			t.Fatalf("解除に失敗: %v", err)
+		}
+		exercises, _ = service.GetExercises(1, ExerciseQuery{Sort: "favorite"})
+		if exercises[0].ID != 1 {
+			t.Errorf("解除後は元の順を期待, 実際: %+v", exercises)
+		}
+	})
+
+	t.Run("他のユーザーのカスタム種目は登録できない", func(t *testing.T) {
+		service := NewWorkoutService(NewMockWorkoutRepository(), NewMockExerciseRepository(), nil, nil, nil, nil)
+		exercise, err := service.CreateCustomExercise(2, &CreateExerciseInput{Name: "種目", MuscleGroup: "chest"})
+		if err != nil {
+			t.Fatalf("作成に失敗: %v", err)
+		}
+		if err := service.AddFavorite(1, exercise.ID); !errors.Is(err, ErrExerciseNotFound) {
+			t.Errorf("ErrExerciseNotFound を期待, 実際: %v", err)
+		}
+	})
+
+	t.Run("未対応の並び順はエラー", func(t *testing.T) {
+		service := NewWorkoutService(NewMockWorkoutRepository(), NewMockExerciseRepository(), nil, nil, nil, nil)
+		if _, err := service.GetExercises(1, ExerciseQuery{Sort: "alphabetical"}); !errors.Is(err, ErrInvalidExerciseSort) {
+			t.Errorf("ErrInvalidExerciseSort を期待, 実際: %v", err)
+		}
+	})
+}
@@ -0,0 +1,383 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// goalTrendDays は見込みの算出に使う直近の日数
+const goalTrendDays = 84
+
+type GoalService struct {
+	goalRepo       GoalRepository
+	workoutRepo    WorkoutRepository
+	exerciseRepo   ExerciseRepository
+	bodyWeightRepo BodyWeightRepository
+}
+
+func NewGoalService(goalRepo GoalRepository, workoutRepo WorkoutRepository, exerciseRepo ExerciseRepository, bodyWeightRepo BodyWeightRepository) *GoalService {
+	return &GoalService{
+		goalRepo:       goalRepo,
+		workoutRepo:    workoutRepo,
+		exerciseRepo:   exerciseRepo,
+		bodyWeightRepo: bodyWeightRepo,
+	}
+}
+
+type CreateGoalInput struct {
+	GoalType    string  `json:"goal_type" validate:"required,oneof=lift body_weight body_fat frequency"`
+	ExerciseID  *uint64 `json:"exercise_id"`
+	TargetValue float64 `json:"target_value" validate:"required,gt=0"`
+	Deadline    *string `json:"deadline" validate:"omitempty,isodate"`
+}
+
+type UpdateGoalInput struct {
+	TargetValue float64 `json:"target_value" validate:"required,gt=0"`
+	Deadline    *string `json:"deadline" validate:"omitempty,isodate"`
+}
+
+func (s *GoalService) CreateGoal(userID uint64, input *CreateGoalInput) (*model.Goal, error) {
+	goalType := model.GoalType(input.GoalType)
+	if !goalType.IsValid() {
+		return nil, fmt.Errorf("%w: unknown goal type %s", ErrInvalidGoal, input.GoalType)
+	}
+
+	goal := &model.Goal{
+		UserID:   userID,
+		GoalType: goalType,
+	}
+
+	if goalType == model.GoalTypeLift {
+		if input.ExerciseID == nil {
+			return nil, fmt.Errorf("%w: exercise_id is required for lift goals", ErrInvalidGoal)
+		}
+		exercise, err := s.exerciseRepo.FindByID(*input.ExerciseID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrExerciseNotFound
+			}
+			return nil, fmt.Errorf("finding exercise: %w", err)
+		}
+		if exercise.IsCustom && (exercise.UserID == nil || *exercise.UserID != userID) {
+			return nil, ErrExerciseNotFound
+		}
+		goal.ExerciseID = input.ExerciseID
+	}
+
+	if err := applyGoalTarget(goal, input.TargetValue, input.Deadline); err != nil {
+		return nil, err
+	}
+
+	points, err := s.goalPoints(goal)
+	if err != nil {
+		return nil, err
+	}
+	goal.StartValue = currentGoalValue(goal.GoalType, points)
+
+	if err := s.goalRepo.Create(goal); err != nil {
+		return nil, err
+	}
+	// 作成時点で目標に届いている場合は達成日を保存する
+	if err := s.evaluate(goal); err != nil {
+		return nil, err
+	}
+
+	return s.GetGoal(userID, goal.ID)
+}
+
+// GetGoals は目標と進捗を返す
+// 達成日はワークアウト・体重の記録時に EvaluateGoals で保存するため、ここでは保存しない
+func (s *GoalService) GetGoals(userID uint64) ([]model.Goal, error) {
+	goals, err := s.goalRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range goals {
+		if err := s.setProgress(&goals[i]); err != nil {
+			return nil, err
+		}
+	}
+	return goals, nil
+}
+
+func (s *GoalService) GetGoal(userID, goalID uint64) (*model.Goal, error) {
+	goal, err := s.findOwnGoal(userID, goalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.setProgress(goal); err != nil {
+		return nil, err
+	}
+	return goal, nil
+}
+
+// EvaluateGoals は未達成の目標を判定し、目標に届いていれば達成日を保存する
+// ワークアウト・体重の記録を保存したときに呼び出す
+func (s *GoalService) EvaluateGoals(userID uint64) error {
+	goals, err := s.goalRepo.FindByUserID(userID)
+	if err != nil {
+		return err
+	}
+	for i := range goals {
+		if goals[i].AchievedOn != nil {
+			continue
+		}
+		if err := s.evaluate(&goals[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateGoal は目標値と期限を変更する（種類と種目は変更できない）
+func (s *GoalService) UpdateGoal(userID, goalID uint64, input *UpdateGoalInput) (*model.Goal, error) {
+	goal, err := s.findOwnGoal(userID, goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyGoalTarget(goal, input.TargetValue, input.Deadline); err != nil {
+		return nil, err
+	}
+	// 目標値が変わるため達成状況を判定し直す
+	goal.AchievedOn = nil
+
+	if err := s.goalRepo.Update(goal); err != nil {
+		return nil, err
+	}
+	if err := s.evaluate(goal); err != nil {
+		return nil, err
+	}
+
+	return s.GetGoal(userID, goalID)
+}
+
+func (s *GoalService) DeleteGoal(userID, goalID uint64) error {
+	if _, err := s.findOwnGoal(userID, goalID); err != nil {
+		return err
+	}
+	return s.goalRepo.Delete(goalID)
+}
+
+func (s *GoalService) findOwnGoal(userID, goalID uint64) (*model.Goal, error) {
+	goal, err := s.goalRepo.FindByID(goalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGoalNotFound
+		}
+		return nil, fmt.Errorf("finding goal: %w", err)
+	}
+	if goal.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+	return goal, nil
+}
+
+// applyGoalTarget は目標値と期限を検証して設定する
+func applyGoalTarget(goal *model.Goal, target float64, deadline *string) error {
+	if target <= 0 {
+		return fmt.Errorf("%w: target_value must be positive", ErrInvalidGoal)
+	}
+	if goal.GoalType == model.GoalTypeFrequency && target > 7 {
+		return fmt.Errorf("%w: frequency must be at most 7 sessions per week", ErrInvalidGoal)
+	}
+	if goal.GoalType == model.GoalTypeBodyFat && target >= 100 {
+		return fmt.Errorf("%w: body fat percentage must be below 100", ErrInvalidGoal)
+	}
+	goal.TargetValue = target
+
+	goal.Deadline = nil
+	if deadline != nil && *deadline != "" {
+		d, err := time.Parse("2006-01-02", *deadline)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidDateFormat, *deadline)
+		}
+		goal.Deadline = &d
+	}
+	return nil
+}
+
+// setProgress は進捗を算出する（達成日は保存しない）
+func (s *GoalService) setProgress(goal *model.Goal) error {
+	points, err := s.goalPoints(goal)
+	if err != nil {
+		return err
+	}
+	goal.Progress, _ = evaluateGoal(goal, points, time.Now())
+	return nil
+}
+
+// evaluate は進捗を算出し、目標に届いていれば達成日を保存する
+func (s *GoalService) evaluate(goal *model.Goal) error {
+	points, err := s.goalPoints(goal)
+	if err != nil {
+		return err
+	}
+
+	progress, achievedOn := evaluateGoal(goal, points, time.Now())
+	goal.Progress = progress
+
+	if goal.AchievedOn == nil && achievedOn != nil {
+		goal.AchievedOn = achievedOn
+		if err := s.goalRepo.Update(goal); err != nil {
+			return fmt.Errorf("marking goal achieved: %w", err)
+		}
+	}
+	return nil
+}
+
+// goalPoints は目標の種類に応じた記録の推移を日付順に返す
+// 回数の目標は、今週を除く週ごとのワークアウト数を週の初日の値とする
+func (s *GoalService) goalPoints(goal *model.Goal) ([]trendPoint, error) {
+	var points []trendPoint
+
+	switch goal.GoalType {
+	case model.GoalTypeLift:
+		progress, err := s.workoutRepo.GetExerciseProgress(goal.UserID, *goal.ExerciseID, model.OneRepMaxFormulaEpley, repository.StatsFilter{Bucket: repository.StatsBucketDay})
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range progress {
+			points = append(points, trendPoint{Date: toDate(p.Date), Value: p.EstimatedOneRepMax})
+		}
+
+	case model.GoalTypeBodyWeight, model.GoalTypeBodyFat:
+		records, err := s.bodyWeightRepo.FindByUserID(goal.UserID, 0)
+		if err != nil {
+			return nil, err
+		}
+		// 新しい順に返るため古い順に並べ替える
+		for i := len(records) - 1; i >= 0; i-- {
+			record := records[i]
+			value := record.Weight
+			if goal.GoalType == model.GoalTypeBodyFat {
+				if record.BodyFatPercentage == nil {
+					continue
+				}
+				value = *record.BodyFatPercentage
+			}
+			points = append(points, trendPoint{Date: toDate(record.Date), Value: value})
+		}
+
+	case model.GoalTypeFrequency:
+		activity, err := s.workoutRepo.GetDailyActivity(goal.UserID, repository.StatsFilter{})
+		if err != nil {
+			return nil, err
+		}
+		currentWeek := weekStart(toDate(time.Now()))
+		for _, day := range activity {
+			week := weekStart(toDate(day.Date))
+			if !week.Before(currentWeek) {
+				break
+			}
+			if n := len(points); n > 0 && points[n-1].Date.Equal(week) {
+				points[n-1].Value += float64(day.Sessions)
+			} else {
+				points = append(points, trendPoint{Date: week, Value: float64(day.Sessions)})
+			}
+		}
+	}
+
+	return points, nil
+}
+
+// currentGoalValue は現在の値を返す（挙上重量はこれまでの最高、それ以外は最新の値）
+func currentGoalValue(goalType model.GoalType, points []trendPoint) *float64 {
+	if len(points) == 0 {
+		return nil
+	}
+	value := points[len(points)-1].Value
+	if goalType == model.GoalTypeLift {
+		for _, p := range points {
+			value = math.Max(value, p.Value)
+		}
+	}
+	return &value
+}
+
+// evaluateGoal は記録の推移から進捗・見込み・達成日を求める
+// 作成日以降の記録が目標に届いた最初の日を達成日とする
+func evaluateGoal(goal *model.Goal, points []trendPoint, now time.Time) (*model.GoalProgress, *time.Time) {
+	progress := &model.GoalProgress{}
+	target := goal.TargetValue
+	createdOn := toDate(goal.CreatedAt)
+
+	start := goal.StartValue
+	if start == nil && len(points) > 0 {
+		start = &points[0].Value
+	}
+	// 体重・体脂肪率は作成時点より低い目標なら減らす目標とみなす
+	increasing := goal.GoalType == model.GoalTypeLift || goal.GoalType == model.GoalTypeFrequency ||
+		start == nil || target >= *start
+	reached := func(v float64) bool {
+		if increasing {
+			return v >= target
+		}
+		return v <= target
+	}
+
+	var achievedOn *time.Time
+	if goal.StartValue != nil && reached(*goal.StartValue) {
+		achievedOn = &createdOn
+	}
+	for _, p := range points {
+		if achievedOn != nil {
+			break
+		}
+		if !p.Date.Before(createdOn) && reached(p.Value) {
+			d := p.Date
+			achievedOn = &d
+		}
+	}
+	if goal.AchievedOn != nil {
+		achievedOn = goal.AchievedOn
+	}
+
+	progress.CurrentValue = currentGoalValue(goal.GoalType, points)
+	if current := progress.CurrentValue; current != nil {
+		switch {
+		case achievedOn != nil || reached(*current):
+			progress.Percent = 100
+		case start != nil && *start != target:
+			progress.Percent = math.Max(0, math.Min(100, (*current-*start)/(target-*start)*100))
+		}
+	}
+
+	// 回数の目標は週ごとの結果のため見込みは算出しない
+	if goal.GoalType != model.GoalTypeFrequency && len(points) >= 2 {
+		from := toDate(now).AddDate(0, 0, -goalTrendDays)
+		var recent []trendPoint
+		for _, p := range points {
+			if !p.Date.Before(from) {
+				recent = append(recent, p)
+			}
+		}
+		if len(recent) >= 2 {
+			slope := linearSlope(recent)
+			perWeek := slope * 7
+			progress.TrendPerWeek = &perWeek
+
+			current := *progress.CurrentValue
+			towardTarget := (increasing && slope > 0) || (!increasing && slope < 0)
+			if achievedOn == nil && towardTarget {
+				days := math.Ceil((target - current) / slope)
+				projected := recent[len(recent)-1].Date.AddDate(0, 0, int(days))
+				progress.ProjectedDate = &projected
+			}
+		}
+	}
+
+	if goal.Deadline != nil {
+		onTrack := achievedOn != nil ||
+			(progress.ProjectedDate != nil && !progress.ProjectedDate.After(*goal.Deadline))
+		progress.OnTrack = &onTrack
+	}
+
+	return progress, achievedOn
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/training-memo/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// MockGoalRepository はテスト用のモックリポジトリ
+type MockGoalRepository struct {
+	goals []model.Goal
+	// updates は Update の呼び出し回数
+	updates int
+}
+
+func (r *MockGoalRepository) Create(goal *model.Goal) error {
+	goal.ID = uint64(len(r.goals) + 1)
+	goal.CreatedAt = time.Now()
+	r.goals = append(r.goals, *goal)
+	return nil
+}
+
+func (r *MockGoalRepository) FindByID(id uint64) (*model.Goal, error) {
+	for _, goal := range r.goals {
+		if goal.ID == id {
+			return &goal, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MockGoalRepository) FindByUserID(userID uint64) ([]model.Goal, error) {
+	var goals []model.Goal
+	for _, goal := range r.goals {
+		if goal.UserID == userID {
+			goals = append(goals, goal)
+		}
+	}
+	return goals, nil
+}
+
+func (r *MockGoalRepository) Update(goal *model.Goal) error {
+	r.updates++
+	for i := range r.goals {
+		if r.goals[i].ID == goal.ID {
+			r.goals[i] = *goal
+		}
+	}
+	return nil
+}
+
+func (r *MockGoalRepository) Delete(id uint64) error {
+	for i := range r.goals {
+		if r.goals[i].ID == id {
+			r.goals = append(r.goals[:i], r.goals[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func TestEvaluateGoal(t *testing.T) {
+	now := date("2026-10-14")
+
+	t.Run("挙上重量の進捗と到達見込みを求める", func(t *testing.T) {
+		start := 80.0
+		goal := &model.Goal{GoalType: model.GoalTypeLift, TargetValue: 100, StartValue: &start, CreatedAt: date("2026-09-01")}
+		points := []trendPoint{
+			{Date: date("2026-09-01"), Value: 80},
+			{Date: date("2026-09-15"), Value: 84},
+			{Date: date("2026-09-29"), Value: 88},
+			{Date: date("2026-10-13"), Value: 92},
+		}
+
+		progress, achievedOn := evaluateGoal(goal, points, now)
+		if achievedOn != nil {
+			t.Fatalf("未達成を期待, 実際: %v", achievedOn)
+		}
+		if progress.Percent != 60 {
+			t.Errorf("期待される進捗: 60%%, 実際: %v", progress.Percent)
+		}
+		if progress.TrendPerWeek == nil || *progress.TrendPerWeek != 2 {
+			t.Errorf("期待される傾向: 2kg/週, 実際: %v", progress.TrendPerWeek)
+		}
+		// 残り8kgを週2kgで4週間
+		if progress.ProjectedDate == nil || !progress.ProjectedDate.Equal(date("2026-11-10")) {
+			t.Errorf("期待される見込み日: 2026-11-10, 実際: %v", progress.ProjectedDate)
+		}
+	})
+
+	t.Run("期限までに届かない見込みなら on_track は false", func(t *testing.T) {
+		deadline := date("2026-10-31")
+		goal := &model.Goal{GoalType: model.GoalTypeLift, TargetValue: 100, Deadline: &deadline, CreatedAt: date("2026-09-01")}
+		points := []trendPoint{
+			{Date: date("2026-09-15"), Value: 84},
+			{Date: date("2026-10-13"), Value: 92},
+		}
+
+		progress, _ := evaluateGoal(goal, points, now)
+		if progress.OnTrack == nil || *progress.OnTrack {
+			t.Errorf("on_track は false を期待, 実際: %v", progress.OnTrack)
+		}
+	})
+
+	t.Run("減量の目標は作成後に目標を下回った日を達成日とする", func(t *testing.T) {
+		start := 75.0
+		goal := &model.Goal{GoalType: model.GoalTypeBodyWeight, TargetValue: 72, StartValue: &start, CreatedAt: date("2026-09-01")}
+		points := []trendPoint{
+			{Date: date("2026-08-01"), Value: 71},
+			{Date: date("2026-09-01"), Value: 75},
+			{Date: date("2026-09-20"), Value: 73},
+			{Date: date("2026-10-05"), Value: 71.8},
+		}
+
+		progress, achievedOn := evaluateGoal(goal, points, now)
+		if achievedOn == nil || !achievedOn.Equal(date("2026-10-05")) {
+			t.Errorf("期待される達成日: 2026-10-05, 実際: %v", achievedOn)
+		}
+		if progress.Percent != 100 || progress.ProjectedDate != nil {
+			t.Errorf("達成済みの進捗を期待, 実際: %+v", progress)
+		}
+	})
+
+	t.Run("記録がない場合は進捗0", func(t *testing.T) {
+		goal := &model.Goal{GoalType: model.GoalTypeFrequency, TargetValue: 4, CreatedAt: date("2026-09-01")}
+
+		progress, achievedOn := evaluateGoal(goal, nil, now)
+		if achievedOn != nil || progress.CurrentValue != nil || progress.Percent != 0 {
+			t.Errorf("進捗なしを期待, 実際: %+v", progress)
+		}
+	})
+}
+
+func TestApplyGoalTarget(t *testing.T) {
+	t.Run("週の回数は7回まで", func(t *testing.T) {
+		goal := &model.Goal{GoalType: model.GoalTypeFrequency}
+		if err := applyGoalTarget(goal, 8, nil); !errors.Is(err, ErrInvalidGoal) {
+			t.Errorf("ErrInvalidGoal を期待, 実際: %v", err)
+		}
+	})
+
+	t.Run("期限の形式が不正な場合はエラー", func(t *testing.T) {
+		goal := &model.Goal{GoalType: model.GoalTypeLift}
+		deadline := "2027/03/01"
+		if err := applyGoalTarget(goal, 100, &deadline); !errors.Is(err, ErrInvalidDateFormat) {
+			t.Errorf("ErrInvalidDateFormat を期待, 実際: %v", err)
+		}
+	})
+}
+
+func TestGoalService_Evaluation(t *testing.T) {
+	newServices := func() (*GoalService, *WorkoutService, *MockGoalRepository) {
+		workoutRepo := NewMockWorkoutRepository()
+		exerciseRepo := NewMockExerciseRepository()
+		goalRepo := &MockGoalRepository{}
+		goals := NewGoalService(goalRepo, workoutRepo, exerciseRepo, &MockBodyWeightRepository{})
+		tracker := NewPersonalRecordTracker(workoutRepo, workoutRepo.records)
+		achievements := NewAchievementService(&MockAchievementRepository{}, workoutRepo, exerciseRepo, &MockBodyWeightRepository{}, DefaultAchievementRules)
+		return goals, NewWorkoutService(workoutRepo, exerciseRepo, tracker, achievements, goals, nil), goalRepo
+	}
+	exerciseID := uint64(1)
+
+	t.Run("ワークアウトの記録で達成日を保存し、取得では保存しない", func(t *testing.T) {
+		goalService, workoutService, goalRepo := newServices()
+		goal, err := goalService.CreateGoal(1, &CreateGoalInput{GoalType: "lift", ExerciseID: &exerciseID, TargetValue: 100})
+		if err != nil {
+			t.Fatalf("目標の作成に失敗: %v", err)
+		}
+
+		if _, err := workoutService.CreateWorkout(1, &CreateWorkoutInput{
+			Date: time.Now().Format("2006-01-02"),
+			Sets: []CreateSetInput{{ExerciseID: exerciseID, SetNumber: 1, Weight: 100, Reps: 1}},
+		}); err != nil {
+			t.Fatalf("ワークアウト作成に失敗: %v", err)
+		}
+		saved, _ := goalRepo.FindByID(goal.ID)
+		if saved.AchievedOn == nil {
+			t.Fatal("ワークアウトの記録で達成日が保存されるべき")
+		}
+
+		updates := goalRepo.updates
+		goals, err := goalService.GetGoals(1)
+		if err != nil {
+			t.Fatalf("目標の取得に失敗: %v", err)
+		}
+		if len(goals) != 1 || goals[0].Progress == nil || goals[0].Progress.Percent != 100 {
+			t.Errorf("達成済みの進捗を期待, 実際: %+v", goals)
+		}
+		if goalRepo.updates != updates {
+			t.Error("目標の取得で保存されるべきではない")
+		}
+	})
+}
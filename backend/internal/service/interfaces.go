@@ -1,6 +1,7 @@
 package service
 
 import (
+	"io"
 	"time"
 
 	"github.com/training-memo/backend/internal/model"
@@ -13,33 +14,67 @@ type UserRepository interface {
 	FindByEmail(email string) (*model.User, error)
 	Update(user *model.User) error
 	ExistsByEmail(email string) (bool, error)
-	DeleteWithAllData(userID uint64) error
+	DeleteWithAllData(userID uint64) ([]string, error)
 }
 
 type WorkoutRepository interface {
 	FindByID(id uint64) (*model.Workout, error)
-	CreateWithSets(workout *model.Workout, sets []*model.WorkoutSet) error
+	CreateWithSets(workout *model.Workout, sets []*model.WorkoutSet, rebuild *repository.RecordRebuild) error
 	FindByUserIDAndDate(userID uint64, date time.Time) (*model.Workout, error)
 	FindByUserID(userID uint64, limit, offset int) ([]model.Workout, error)
 	CountByUserID(userID uint64) (int64, error)
 	Update(workout *model.Workout) error
-	ReplaceSetsByWorkoutID(workoutID uint64, sets []*model.WorkoutSet) error
-	Delete(id uint64) error
+	ReplaceSetsByWorkoutID(workoutID uint64, sets []*model.WorkoutSet, rebuild *repository.RecordRebuild) error
+	Delete(id uint64, rebuild *repository.RecordRebuild) error
 	FindByUserIDAndMonth(userID uint64, year, month int) ([]model.Workout, error)
-	GetMuscleGroupStats(userID uint64) ([]repository.MuscleGroupStat, error)
-	GetPersonalBests(userID uint64) ([]repository.PersonalBest, error)
-	GetExerciseProgress(userID uint64, exerciseID uint64) ([]repository.ExerciseProgress, error)
+	GetTrainingTotals(userID uint64, filter repository.StatsFilter) (*repository.TrainingTotals, error)
+	GetDailyActivity(userID uint64, filter repository.StatsFilter) ([]repository.DailyActivity, error)
+	GetDailyMuscleGroupLoad(userID uint64, filter repository.StatsFilter) ([]repository.DailyMuscleGroupLoad, error)
+	GetWorkoutVolumes(userID uint64) ([]repository.WorkoutVolume, error)
+	GetMuscleGroupStats(userID uint64, filter repository.StatsFilter) ([]repository.MuscleGroupStat, error)
+	GetMuscleStats(userID uint64, filter repository.StatsFilter) ([]repository.MuscleStat, error)
+	GetPersonalBests(userID uint64, formula model.OneRepMaxFormula, filter repository.StatsFilter) ([]repository.PersonalBest, error)
+	GetExerciseProgress(userID uint64, exerciseID uint64, formula model.OneRepMaxFormula, filter repository.StatsFilter) ([]repository.ExerciseProgress, error)
+	GetBestOneRepMaxDays(userID uint64, exerciseIDs []uint64, formula model.OneRepMaxFormula, filter repository.StatsFilter) ([]repository.ExerciseOneRepMax, error)
+	GetActiveOneRepMaxHistories(userID uint64, formula model.OneRepMaxFormula, since time.Time) ([]repository.ExerciseOneRepMax, error)
+	GetRepMaxes(userID uint64, exerciseID uint64, maxReps int) ([]repository.RepMax, error)
+	GetRecentSets(userID uint64, exerciseIDs []uint64, limit int) ([]model.WorkoutSet, error)
+}
+
+type PersonalRecordRepository interface {
+	FindByWorkoutID(workoutID uint64) ([]model.PersonalRecord, error)
+	FindByUserID(userID uint64, exerciseID uint64) ([]model.PersonalRecord, error)
+	FindByUserIDAndDateRange(userID uint64, startDate, endDate time.Time) ([]model.PersonalRecord, error)
 }
 
 type ExerciseRepository interface {
 	FindAll(userID uint64) ([]model.Exercise, error)
 	FindByMuscleGroup(muscleGroup model.MuscleGroup, userID uint64) ([]model.Exercise, error)
+	FindFiltered(userID uint64, filter repository.ExerciseFilter) ([]model.Exercise, error)
 	FindByID(id uint64) (*model.Exercise, error)
+	FindByIDs(ids []uint64) ([]model.Exercise, error)
 	Create(exercise *model.Exercise) error
 	Update(exercise *model.Exercise) error
 	Delete(id uint64) error
 	FindCustomByUserID(userID uint64) ([]model.Exercise, error)
 	IsUsedInWorkouts(exerciseID uint64) (bool, error)
+	Merge(userID, sourceID, targetID uint64, rebuild *repository.RecordRebuild) ([]string, error)
+	ReplaceMuscles(exerciseID uint64, muscles []model.ExerciseMuscle) error
+	FindMuscles() ([]model.Muscle, error)
+	AddFavorite(userID, exerciseID uint64) error
+	RemoveFavorite(userID, exerciseID uint64) error
+	Search(userID uint64, query string, limit int) ([]repository.ExerciseMatch, error)
+	FindAliases(userID, exerciseID uint64) ([]model.ExerciseAlias, error)
+	FindAliasByID(id uint64) (*model.ExerciseAlias, error)
+	CreateAlias(alias *model.ExerciseAlias) error
+	DeleteAlias(id uint64) error
+	FindMediaByID(id uint64) (*model.ExerciseMedia, error)
+	CreateMedia(media *model.ExerciseMedia) error
+	DeleteMedia(id uint64) error
+	CountMedia(exerciseID uint64) (int64, error)
+	FindTranslations(locale model.Locale, exerciseIDs []uint64) ([]model.ExerciseTranslation, error)
+	FindVariations(userID, parentID uint64) ([]model.Exercise, error)
+	FindProgressionChain(userID, exerciseID uint64) ([]model.Exercise, error)
 }
 
 type MenuRepository interface {
@@ -60,7 +95,28 @@ type BodyWeightRepository interface {
 	FindByUserIDAndDate(userID uint64, date time.Time) (*model.BodyWeight, error)
 	FindByUserID(userID uint64, limit int) ([]model.BodyWeight, error)
 	FindByUserIDAndDateRange(userID uint64, startDate, endDate time.Time) ([]model.BodyWeight, error)
+	FindNearest(userID uint64, date time.Time) (*model.BodyWeight, error)
 	Update(record *model.BodyWeight) error
 	Delete(id uint64) error
 	GetLatest(userID uint64) (*model.BodyWeight, error)
 }
+
+type GoalRepository interface {
+	Create(goal *model.Goal) error
+	FindByID(id uint64) (*model.Goal, error)
+	FindByUserID(userID uint64) ([]model.Goal, error)
+	Update(goal *model.Goal) error
+	Delete(id uint64) error
+}
+
+type AchievementRepository interface {
+	Create(achievement *model.UserAchievement) error
+	FindByUserID(userID uint64) ([]model.UserAchievement, error)
+}
+
+// MediaStorage は種目の画像・動画の保存先
+type MediaStorage interface {
+	Save(key string, r io.Reader) error
+	Delete(key string) error
+	Open(key string) (io.ReadCloser, error)
+}
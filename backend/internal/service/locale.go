@@ -0,0 +1,292 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// muscleGroupLabels は部位の表示名
+var muscleGroupLabels = map[model.Locale]map[model.MuscleGroup]string{
+	model.LocaleJa: {
+		model.MuscleGroupChest: "胸", model.MuscleGroupBack: "背中", model.MuscleGroupShoulders: "肩",
+		model.MuscleGroupArms: "腕", model.MuscleGroupLegs: "脚", model.MuscleGroupAbs: "腹筋", model.MuscleGroupOther: "その他",
+	},
+	model.LocaleEn: {
+		model.MuscleGroupChest: "chest", model.MuscleGroupBack: "back", model.MuscleGroupShoulders: "shoulders",
+		model.MuscleGroupArms: "arms", model.MuscleGroupLegs: "legs", model.MuscleGroupAbs: "abs", model.MuscleGroupOther: "other",
+	},
+}
+
+// equipmentLabels は器具の表示名
+var equipmentLabels = map[model.Locale]map[model.Equipment]string{
+	model.LocaleJa: {
+		model.EquipmentBarbell: "バーベル", model.EquipmentDumbbell: "ダンベル", model.EquipmentCable: "ケーブル",
+		model.EquipmentMachine: "マシン", model.EquipmentBodyweight: "自重", model.EquipmentKettlebell: "ケトルベル",
+		model.EquipmentBand: "チューブ",
+	},
+	model.LocaleEn: {
+		model.EquipmentBarbell: "barbell", model.EquipmentDumbbell: "dumbbell", model.EquipmentCable: "cable",
+		model.EquipmentMachine: "machine", model.EquipmentBodyweight: "bodyweight", model.EquipmentKettlebell: "kettlebell",
+		model.EquipmentBand: "band",
+	},
+}
+
+// MuscleGroupLabel は部位の表示名を返す（表示名がない言語では基本の言語の表示名）
+func MuscleGroupLabel(locale model.Locale, group model.MuscleGroup) string {
+	if label, ok := muscleGroupLabels[locale][group]; ok {
+		return label
+	}
+	return muscleGroupLabels[model.DefaultLocale][group]
+}
+
+// EquipmentLabel は器具の表示名を返す（表示名がない言語では基本の言語の表示名）
+func EquipmentLabel(locale model.Locale, equipment model.Equipment) string {
+	if label, ok := equipmentLabels[locale][equipment]; ok {
+		return label
+	}
+	return equipmentLabels[model.DefaultLocale][equipment]
+}
+
+// NegotiateLocale は Accept-Language から対応している言語のうち最も優先度の高いものを選ぶ
+// 対応している言語が含まれない場合は false を返す
+func NegotiateLocale(acceptLanguage string) (model.Locale, bool) {
+	var (
+		best    model.Locale
+		bestQ   float64
+		matched bool
+	)
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		// en-US などは主言語のみで判定する
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		locale := model.Locale(primary)
+		if q <= 0 || !locale.IsValid() {
+			continue
+		}
+		if !matched || q > bestQ {
+			best, bestQ, matched = locale, q, true
+		}
+	}
+	return best, matched
+}
+
+// UpdateLocaleInput は表示言語の設定（Locale が nil の場合は Accept-Language に従う）
+type UpdateLocaleInput struct {
+	Locale *string `json:"locale"`
+}
+
+// UpdateLocale はユーザーの表示言語を設定する
+func (s *AuthService) UpdateLocale(userID uint64, input *UpdateLocaleInput) (*model.User, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("finding user: %w", err)
+	}
+
+	user.Locale = nil
+	if input.Locale != nil {
+		locale := model.Locale(strings.ToLower(*input.Locale))
+		if !locale.IsValid() {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidLocale, *input.Locale)
+		}
+		user.Locale = &locale
+	}
+
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Localizer は応答に含まれる種目名・説明を表示言語に合わせる
+type Localizer struct {
+	exerciseRepo ExerciseRepository
+	userRepo     UserRepository
+}
+
+func NewLocalizer(exerciseRepo ExerciseRepository, userRepo UserRepository) *Localizer {
+	return &Localizer{
+		exerciseRepo: exerciseRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// ResolveLocale はユーザーの設定、Accept-Language、基本の言語の順に表示言語を決める
+// ユーザーを取得できない場合は設定がないものとして扱う
+func (l *Localizer) ResolveLocale(userID uint64, acceptLanguage string) model.Locale {
+	if user, err := l.userRepo.FindByID(userID); err == nil && user.Locale != nil && user.Locale.IsValid() {
+		return *user.Locale
+	}
+	if locale, ok := NegotiateLocale(acceptLanguage); ok {
+		return locale
+	}
+	return model.DefaultLocale
+}
+
+// Localize は応答に含まれる種目名・説明を翻訳で置き換える（翻訳がない種目はそのまま）
+func (l *Localizer) Localize(locale model.Locale, v interface{}) error {
+	var refs exerciseNameRefs
+	switch v := v.(type) {
+	case *model.Exercise:
+		refs.exercise(v)
+	case []model.Exercise:
+		for i := range v {
+			refs.exercise(&v[i])
+		}
+	case []ExerciseSearchResult:
+		for i := range v {
+			refs.exercise(&v[i].Exercise)
+		}
+	case *model.Workout:
+		refs.workout(v)
+	case []model.Workout:
+		for i := range v {
+			refs.workout(&v[i])
+		}
+	case *WorkoutListResponse:
+		for i := range v.Workouts {
+			refs.workout(&v.Workouts[i])
+		}
+	case *model.Menu:
+		refs.menu(v)
+	case []model.Menu:
+		for i := range v {
+			refs.menu(&v[i])
+		}
+	case *WorkoutPlan:
+		for i := range v.Sets {
+			refs.exercise(v.Sets[i].Exercise)
+		}
+	case *GenerateMenuOutput:
+		for i := range v.Items {
+			refs.exercise(v.Items[i].Exercise)
+		}
+	case *Progression:
+		for i := range v.Steps {
+			refs.exercise(&v.Steps[i].Exercise)
+		}
+		for i := range v.Next {
+			refs.exercise(&v.Next[i])
+		}
+	case *model.Goal:
+		refs.exercise(v.Exercise)
+	case []model.Goal:
+		for i := range v {
+			refs.exercise(v[i].Exercise)
+		}
+	case []model.PersonalRecord:
+		refs.records(v)
+	case []repository.PersonalBest:
+		for i := range v {
+			refs.add(v[i].ExerciseID, &v[i].ExerciseName, nil)
+		}
+	case *TrainingReport:
+		refs.records(v.NewPersonalRecords)
+	case *PeriodComparison:
+		for i := range v.Exercises {
+			refs.add(v.Exercises[i].ExerciseID, &v.Exercises[i].ExerciseName, nil)
+		}
+	case []StalledLift:
+		for i := range v {
+			refs.add(v[i].ExerciseID, &v[i].ExerciseName, nil)
+		}
+	case *StrengthScores:
+		for i := range v.Lifts {
+			refs.add(v.Lifts[i].ExerciseID, &v.Lifts[i].ExerciseName, nil)
+		}
+	default:
+		return fmt.Errorf("cannot localize %T", v)
+	}
+	return translateExerciseNames(l.exerciseRepo, locale, refs)
+}
+
+// exerciseNameRef は応答に含まれる種目名・説明の参照（description が nil の場合は名前のみ）
+type exerciseNameRef struct {
+	exerciseID  uint64
+	name        *string
+	description *string
+}
+
+type exerciseNameRefs []exerciseNameRef
+
+func (r *exerciseNameRefs) add(exerciseID uint64, name, description *string) {
+	*r = append(*r, exerciseNameRef{exerciseID: exerciseID, name: name, description: description})
+}
+
+func (r *exerciseNameRefs) exercise(e *model.Exercise) {
+	if e != nil {
+		r.add(e.ID, &e.Name, &e.Description)
+	}
+}
+
+func (r *exerciseNameRefs) records(records []model.PersonalRecord) {
+	for i := range records {
+		r.exercise(records[i].Exercise)
+	}
+}
+
+func (r *exerciseNameRefs) workout(w *model.Workout) {
+	for i := range w.Sets {
+		r.exercise(w.Sets[i].Exercise)
+	}
+	r.records(w.NewPersonalRecords)
+}
+
+func (r *exerciseNameRefs) menu(m *model.Menu) {
+	for i := range m.Items {
+		r.exercise(m.Items[i].Exercise)
+	}
+}
+
+// translateExerciseNames は参照している種目名・説明を翻訳で置き換える
+func translateExerciseNames(exerciseRepo ExerciseRepository, locale model.Locale, refs exerciseNameRefs) error {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	seen := make(map[uint64]bool, len(refs))
+	ids := make([]uint64, 0, len(refs))
+	for _, ref := range refs {
+		if !seen[ref.exerciseID] {
+			seen[ref.exerciseID] = true
+			ids = append(ids, ref.exerciseID)
+		}
+	}
+
+	translations, err := exerciseRepo.FindTranslations(locale, ids)
+	if err != nil {
+		return fmt.Errorf("finding translations: %w", err)
+	}
+	byID := make(map[uint64]model.ExerciseTranslation, len(translations))
+	for _, t := range translations {
+		byID[t.ExerciseID] = t
+	}
+
+	for _, ref := range refs {
+		t, ok := byID[ref.exerciseID]
+		if !ok {
+			continue
+		}
+		*ref.name = t.Name
+		if ref.description != nil && t.Description != nil {
+			*ref.description = *t.Description
+		}
+	}
+	return nil
+}
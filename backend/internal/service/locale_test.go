@@ -0,0 +1,165 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/training-memo/backend/internal/model"
+)
+
+func TestNegotiateLocale(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           model.Locale
+		wantOK         bool
+	}{
+		{"地域付きの言語は主言語で判定する", "en-US,en;q=0.9,ja;q=0.8", model.LocaleEn, true},
+		{"q値の高い言語を選ぶ", "en;q=0.5,ja;q=0.9", model.LocaleJa, true},
+		{"対応していない言語は飛ばす", "fr-FR,fr;q=0.9,en;q=0.3", model.LocaleEn, true},
+		{"q=0の言語は選ばない", "en;q=0,fr", "", false},
+		{"対応している言語がない場合はfalse", "fr", "", false},
+		{"空の場合はfalse", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NegotiateLocale(tt.acceptLanguage)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("期待: %q, %v, 実際: %q, %v", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
+
+func TestLocalizer_ResolveLocale(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	en := model.LocaleEn
+	withPreference := &model.User{Email: "en@example.com", Locale: &en}
+	withoutPreference := &model.User{Email: "none@example.com"}
+	userRepo.Create(withPreference)
+	userRepo.Create(withoutPreference)
+	localizer := NewLocalizer(NewMockExerciseRepository(), userRepo)
+
+	t.Run("ユーザーの設定をAccept-Languageより優先する", func(t *testing.T) {
+		if got := localizer.ResolveLocale(withPreference.ID, "ja"); got != model.LocaleEn {
+			t.Errorf("期待: en, 実際: %s", got)
+		}
+	})
+
+	t.Run("設定がない場合はAccept-Languageに従う", func(t *testing.T) {
+		if got := localizer.ResolveLocale(withoutPreference.ID, "en-GB"); got != model.LocaleEn {
+			t.Errorf("期待: en, 実際: %s", got)
+		}
+	})
+
+	t.Run("どちらもない場合は基本の言語", func(t *testing.T) {
+		if got := localizer.ResolveLocale(withoutPreference.ID, "fr"); got != model.DefaultLocale {
+			t.Errorf("期待: %s, 実際: %s", model.DefaultLocale, got)
+		}
+	})
+}
+
+func TestLocalizer_Localize(t *testing.T) {
+	newLocalizer := func() *Localizer {
+		exerciseRepo := NewMockExerciseRepository()
+		description := "Lie on a flat bench and press the bar from the chest."
+		exerciseRepo.translations = []model.ExerciseTranslation{
+			{ExerciseID: 1, Locale: model.LocaleEn, Name: "Bench Press", Description: &description},
+			{ExerciseID: 2, Locale: model.LocaleEn, Name: "Squat"},
+		}
+		return NewLocalizer(exerciseRepo, NewMockUserRepository())
+	}
+
+	t.Run("ワークアウトのセットと自己ベストの種目名を置き換える", func(t *testing.T) {
+		localizer := newLocalizer()
+		workout := &model.Workout{
+			Sets: []model.WorkoutSet{
+				{ExerciseID: 1, Exercise: &model.Exercise{ID: 1, Name: "ベンチプレス"}},
+				{ExerciseID: 2, Exercise: &model.Exercise{ID: 2, Name: "スクワット"}},
+			},
+			NewPersonalRecords: []model.PersonalRecord{
+				{ExerciseID: 1, Exercise: &model.Exercise{ID: 1, Name: "ベンチプレス"}},
+			},
+		}
+
+		if err := localizer.Localize(model.LocaleEn, workout); err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if workout.Sets[0].Exercise.Name != "Bench Press" || workout.Sets[1].Exercise.Name != "Squat" {
+			t.Errorf("セットの種目名が翻訳されていない: %s, %s", workout.Sets[0].Exercise.Name, workout.Sets[1].Exercise.Name)
+		}
+		if !strings.HasPrefix(workout.Sets[0].Exercise.Description, "Lie on") {
+			t.Errorf("説明が翻訳されていない: %q", workout.Sets[0].Exercise.Description)
+		}
+		if workout.NewPersonalRecords[0].Exercise.Name != "Bench Press" {
+			t.Errorf("自己ベストの種目名が翻訳されていない: %s", workout.NewPersonalRecords[0].Exercise.Name)
+		}
+	})
+
+	t.Run("翻訳がない種目は元の名前のまま", func(t *testing.T) {
+		localizer := newLocalizer()
+		exercises := []model.Exercise{
+			{ID: 1, Name: "ベンチプレス"},
+			{ID: 100, Name: "マイ種目", IsCustom: true},
+		}
+
+		if err := localizer.Localize(model.LocaleEn, exercises); err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if exercises[0].Name != "Bench Press" {
+			t.Errorf("期待: Bench Press, 実際: %s", exercises[0].Name)
+		}
+		if exercises[1].Name != "マイ種目" {
+			t.Errorf("期待: マイ種目, 実際: %s", exercises[1].Name)
+		}
+	})
+
+	t.Run("対応していない型はエラー", func(t *testing.T) {
+		localizer := newLocalizer()
+		if err := localizer.Localize(model.LocaleEn, map[string]string{}); err == nil {
+			t.Error("エラーが返されるべき")
+		}
+	})
+}
+
+func TestAuthService_UpdateLocale(t *testing.T) {
+	newService := func() (*AuthService, *model.User) {
+		userRepo := NewMockUserRepository()
+		user := &model.User{Email: "test@example.com"}
+		userRepo.Create(user)
+		return NewAuthService(userRepo, nil), user
+	}
+
+	t.Run("表示言語を設定・解除できる", func(t *testing.T) {
+		service, user := newService()
+		locale := "EN"
+
+		updated, err := service.UpdateLocale(user.ID, &UpdateLocaleInput{Locale: &locale})
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if updated.Locale == nil || *updated.Locale != model.LocaleEn {
+			t.Errorf("期待: en, 実際: %v", updated.Locale)
+		}
+
+		updated, err = service.UpdateLocale(user.ID, &UpdateLocaleInput{})
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if updated.Locale != nil {
+			t.Errorf("設定が解除されていない: %s", *updated.Locale)
+		}
+	})
+
+	t.Run("対応していない言語はエラー", func(t *testing.T) {
+		service, user := newService()
+		locale := "fr"
+
+		_, err := service.UpdateLocale(user.ID, &UpdateLocaleInput{Locale: &locale})
+		if !errors.Is(err, ErrInvalidLocale) {
+			t.Errorf("ErrInvalidLocale が返されるべき, 実際: %v", err)
+		}
+	})
+}
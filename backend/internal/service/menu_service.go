@@ -9,34 +9,59 @@ import (
 )
 
 type MenuService struct {
-	menuRepo     MenuRepository
-	exerciseRepo ExerciseRepository
+	menuRepo       MenuRepository
+	exerciseRepo   ExerciseRepository
+	targetResolver *TargetWeightResolver
 }
 
-func NewMenuService(menuRepo MenuRepository, exerciseRepo ExerciseRepository) *MenuService {
+func NewMenuService(menuRepo MenuRepository, exerciseRepo ExerciseRepository, targetResolver *TargetWeightResolver) *MenuService {
 	return &MenuService{
-		menuRepo:     menuRepo,
-		exerciseRepo: exerciseRepo,
+		menuRepo:       menuRepo,
+		exerciseRepo:   exerciseRepo,
+		targetResolver: targetResolver,
 	}
 }
 
+// CreateMenuInput の UseAvailableEquipment が true の場合、ユーザーの使える器具で実施できる種目のみを許可する
 type CreateMenuInput struct {
-	Name        string            `json:"name" validate:"required,min=1,max=100"`
-	Description *string           `json:"description"`
-	Items       []CreateItemInput `json:"items" validate:"required,min=1,dive"`
+	Name                  string            `json:"name" validate:"required,min=1,max=100"`
+	Description           *string           `json:"description"`
+	Items                 []CreateItemInput `json:"items" validate:"required,min=1,dive"`
+	UseAvailableEquipment bool              `json:"use_available_equipment"`
 }
 
+// CreateItemInput の TargetType が percent_e1rm の場合は TargetPercentage、rpe の場合は TargetRPE が必須
+// 省略した場合は fixed（TargetWeight をそのまま使う）とする
 type CreateItemInput struct {
-	ExerciseID   uint64   `json:"exercise_id" validate:"required"`
-	OrderNumber  uint8    `json:"order_number" validate:"required,min=1"`
-	TargetSets   uint8    `json:"target_sets" validate:"required,min=1"`
-	TargetReps   uint16   `json:"target_reps" validate:"required,min=1"`
-	TargetWeight *float64 `json:"target_weight"`
-	Note         *string  `json:"note"`
+	ExerciseID       uint64   `json:"exercise_id" validate:"required"`
+	OrderNumber      uint8    `json:"order_number" validate:"required,min=1"`
+	TargetSets       uint8    `json:"target_sets" validate:"required,min=1"`
+	TargetReps       uint16   `json:"target_reps" validate:"required,min=1"`
+	TargetType       string   `json:"target_type" validate:"omitempty,oneof=fixed percent_e1rm rpe"`
+	TargetWeight     *float64 `json:"target_weight"`
+	TargetPercentage *float64 `json:"target_percentage" validate:"required_if=TargetType percent_e1rm,omitempty,gt=0,lte=100"`
+	TargetRPE        *float64 `json:"target_rpe" validate:"required_if=TargetType rpe,omitempty,min=5,max=10"`
+	Note             *string  `json:"note"`
 }
 
 type UpdateMenuInput = CreateMenuInput
 
+// WorkoutPlan はメニューからワークアウトを開始する際の初期セット
+type WorkoutPlan struct {
+	MenuID   uint64       `json:"menu_id"`
+	MenuName string       `json:"menu_name"`
+	Sets     []PlannedSet `json:"sets"`
+}
+
+// PlannedSet は目標重量を確定させた予定セット
+type PlannedSet struct {
+	ExerciseID uint64          `json:"exercise_id"`
+	SetNumber  uint8           `json:"set_number"`
+	Weight     *float64        `json:"weight"`
+	Reps       uint16          `json:"reps"`
+	Exercise   *model.Exercise `json:"exercise,omitempty"`
+}
+
 func (s *MenuService) CreateMenu(userID uint64, input *CreateMenuInput) (*model.Menu, error) {
 	menu := &model.Menu{
 		UserID:      userID,
@@ -44,23 +69,22 @@ func (s *MenuService) CreateMenu(userID uint64, input *CreateMenuInput) (*model.
 		Description: input.Description,
 	}
 
-	items := make([]*model.MenuItem, len(input.Items))
-	for i, itemInput := range input.Items {
-		items[i] = &model.MenuItem{
-			ExerciseID:   itemInput.ExerciseID,
-			OrderNumber:  itemInput.OrderNumber,
-			TargetSets:   itemInput.TargetSets,
-			TargetReps:   itemInput.TargetReps,
-			TargetWeight: itemInput.TargetWeight,
-			Note:         itemInput.Note,
-		}
+	items, err := buildMenuItems(0, input.Items)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkExerciseReferences(s.exerciseRepo, userID, itemExerciseReferences(input.Items)); err != nil {
+		return nil, err
+	}
+	if err := s.checkEquipment(userID, input); err != nil {
+		return nil, err
 	}
 
 	if err := s.menuRepo.CreateWithItems(menu, items); err != nil {
 		return nil, err
 	}
 
-	return s.menuRepo.FindByID(menu.ID)
+	return s.findResolvedMenu(userID, menu.ID)
 }
 
 func (s *MenuService) GetMenu(userID, menuID uint64) (*model.Menu, error) {
@@ -76,11 +100,54 @@ func (s *MenuService) GetMenu(userID, menuID uint64) (*model.Menu, error) {
 		return nil, ErrUnauthorized
 	}
 
+	if err := s.targetResolver.Resolve(userID, menu.Items); err != nil {
+		return nil, err
+	}
+
 	return menu, nil
 }
 
 func (s *MenuService) GetMenus(userID uint64) ([]model.Menu, error) {
-	return s.menuRepo.FindByUserID(userID)
+	menus, err := s.menuRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.targetResolver.ResolveMenus(userID, menus); err != nil {
+		return nil, err
+	}
+
+	return menus, nil
+}
+
+// GetWorkoutPlan はメニューの各項目を目標セット数分のセットに展開し、重量を確定させる
+func (s *MenuService) GetWorkoutPlan(userID, menuID uint64) (*WorkoutPlan, error) {
+	menu, err := s.GetMenu(userID, menuID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &WorkoutPlan{
+		MenuID:   menu.ID,
+		MenuName: menu.Name,
+		Sets:     []PlannedSet{},
+	}
+
+	setNumbers := make(map[uint64]uint8)
+	for _, item := range menu.Items {
+		for i := uint8(0); i < item.TargetSets; i++ {
+			setNumbers[item.ExerciseID]++
+			plan.Sets = append(plan.Sets, PlannedSet{
+				ExerciseID: item.ExerciseID,
+				SetNumber:  setNumbers[item.ExerciseID],
+				Weight:     item.ResolvedWeight,
+				Reps:       item.TargetReps,
+				Exercise:   item.Exercise,
+			})
+		}
+	}
+
+	return plan, nil
 }
 
 func (s *MenuService) UpdateMenu(userID, menuID uint64, input *UpdateMenuInput) (*model.Menu, error) {
@@ -96,6 +163,17 @@ func (s *MenuService) UpdateMenu(userID, menuID uint64, input *UpdateMenuInput)
 		return nil, ErrUnauthorized
 	}
 
+	items, err := buildMenuItems(menuID, input.Items)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkExerciseReferences(s.exerciseRepo, userID, itemExerciseReferences(input.Items)); err != nil {
+		return nil, err
+	}
+	if err := s.checkEquipment(userID, input); err != nil {
+		return nil, err
+	}
+
 	menu.Name = input.Name
 	menu.Description = input.Description
 
@@ -103,24 +181,11 @@ func (s *MenuService) UpdateMenu(userID, menuID uint64, input *UpdateMenuInput)
 		return nil, err
 	}
 
-	items := make([]*model.MenuItem, len(input.Items))
-	for i, itemInput := range input.Items {
-		items[i] = &model.MenuItem{
-			MenuID:       menuID,
-			ExerciseID:   itemInput.ExerciseID,
-			OrderNumber:  itemInput.OrderNumber,
-			TargetSets:   itemInput.TargetSets,
-			TargetReps:   itemInput.TargetReps,
-			TargetWeight: itemInput.TargetWeight,
-			Note:         itemInput.Note,
-		}
-	}
-
 	if err := s.menuRepo.ReplaceItemsByMenuID(menuID, items); err != nil {
 		return nil, err
 	}
 
-	return s.menuRepo.FindByID(menuID)
+	return s.findResolvedMenu(userID, menuID)
 }
 
 func (s *MenuService) DeleteMenu(userID, menuID uint64) error {
@@ -138,3 +203,65 @@ func (s *MenuService) DeleteMenu(userID, menuID uint64) error {
 
 	return s.menuRepo.Delete(menuID)
 }
+
+func (s *MenuService) checkEquipment(userID uint64, input *CreateMenuInput) error {
+	if !input.UseAvailableEquipment {
+		return nil
+	}
+	ids := make([]uint64, len(input.Items))
+	for i, item := range input.Items {
+		ids[i] = item.ExerciseID
+	}
+	return checkAvailableExercises(s.exerciseRepo, userID, ids)
+}
+
+func (s *MenuService) findResolvedMenu(userID, menuID uint64) (*model.Menu, error) {
+	menu, err := s.menuRepo.FindByID(menuID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.targetResolver.Resolve(userID, menu.Items); err != nil {
+		return nil, err
+	}
+	return menu, nil
+}
+
+// buildMenuItems は入力をメニュー項目に変換し、目標重量の指定方法を検証する
+// ハンドラーを通さない呼び出しでも不正な目標を保存しないよう、入力の検証とは別に確認する
+func buildMenuItems(menuID uint64, inputs []CreateItemInput) ([]*model.MenuItem, error) {
+	items := make([]*model.MenuItem, len(inputs))
+	for i, itemInput := range inputs {
+		targetType := model.TargetType(itemInput.TargetType)
+		if targetType == "" {
+			targetType = model.TargetTypeFixed
+		}
+
+		switch targetType {
+		case model.TargetTypeFixed:
+		case model.TargetTypePercentE1RM:
+			if p := itemInput.TargetPercentage; p == nil || *p <= 0 || *p > 100 {
+				return nil, fmt.Errorf("%w: target_percentage must be between 0 and 100", ErrInvalidMenuTarget)
+			}
+		case model.TargetTypeRPE:
+			if r := itemInput.TargetRPE; r == nil || *r < 5 || *r > 10 {
+				return nil, fmt.Errorf("%w: target_rpe must be between 5 and 10", ErrInvalidMenuTarget)
+			}
+		default:
+			return nil, fmt.Errorf("%w: unknown target_type %q", ErrInvalidMenuTarget, itemInput.TargetType)
+		}
+
+		items[i] = &model.MenuItem{
+			MenuID:           menuID,
+			ExerciseID:       itemInput.ExerciseID,
+			OrderNumber:      itemInput.OrderNumber,
+			TargetSets:       itemInput.TargetSets,
+			TargetReps:       itemInput.TargetReps,
+			TargetType:       targetType,
+			TargetWeight:     itemInput.TargetWeight,
+			TargetPercentage: itemInput.TargetPercentage,
+			TargetRPE:        itemInput.TargetRPE,
+			Note:             itemInput.Note,
+		}
+	}
+	return items, nil
+}
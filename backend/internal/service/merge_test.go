@@ -0,0 +1,140 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/training-memo/backend/internal/model"
+)
+
+func TestWorkoutService_MergeCustomExercise(t *testing.T) {
+	newService := func() (*WorkoutService, *MockWorkoutRepository, *MockExerciseRepository, *MockPersonalRecordRepository) {
+		workoutRepo := NewMockWorkoutRepository()
+		exerciseRepo := NewMockExerciseRepository()
+		exerciseRepo.workouts = workoutRepo
+		recordRepo := workoutRepo.records
+		tracker := NewPersonalRecordTracker(workoutRepo, recordRepo)
+		return NewWorkoutService(workoutRepo, exerciseRepo, tracker, nil, nil, nil), workoutRepo, exerciseRepo, recordRepo
+	}
+
+	t.Run("セットを統合先に付け替えて自己ベストを再計算する", func(t *testing.T) {
+		service, workoutRepo, exerciseRepo, recordRepo := newService()
+		source, err := service.CreateCustomExercise(1, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest"})
+		if err != nil {
+			t.Fatalf("作成に失敗: %v", err)
+		}
+		workoutRepo.CreateWithSets(
+			&model.Workout{UserID: 1, Date: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)},
+			[]*model.WorkoutSet{{ExerciseID: source.ID, SetNumber: 1, Weight: 80, Reps: 5}},
+			nil,
+		)
+
+		target, err := service.MergeCustomExercise(1, source.ID, 1)
+		if err != nil {
+			t.Fatalf("統合に失敗: %v", err)
+		}
+		if target.ID != 1 {
+			t.Errorf("統合先の種目を返すことを期待, 実際: %+v", target)
+		}
+		if _, ok := exerciseRepo.exercises[source.ID]; ok {
+			t.Error("統合元の種目が削除されていない")
+		}
+		for _, set := range workoutRepo.sets {
+			if set.ExerciseID != 1 {
+				t.Errorf("セットが統合先に付け替えられていない: %+v", set)
+			}
+		}
+		found := false
+		for _, record := range recordRepo.records {
+			found = found || (record.ExerciseID == 1 && record.RecordType == model.RecordTypeMaxWeight && record.Value == 80)
+		}
+		if !found {
+			t.Errorf("統合先の自己ベストが再計算されていない: %+v", recordRepo.records)
+		}
+	})
+
+	t.Run("付け替えたセットは同じワークアウトの統合先のセットの後ろに番号を振る", func(t *testing.T) {
+		service, workoutRepo, _, _ := newService()
+		source, _ := service.CreateCustomExercise(1, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest"})
+		workout := &model.Workout{UserID: 1, Date: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)}
+		workoutRepo.CreateWithSets(workout, []*model.WorkoutSet{
+			{ExerciseID: 1, SetNumber: 1, Weight: 100, Reps: 5},
+			{ExerciseID: 1, SetNumber: 2, Weight: 100, Reps: 5},
+			{ExerciseID: source.ID, SetNumber: 1, Weight: 80, Reps: 8},
+			{ExerciseID: source.ID, SetNumber: 2, Weight: 80, Reps: 8},
+		}, nil)
+
+		if _, err := service.MergeCustomExercise(1, source.ID, 1); err != nil {
+			t.Fatalf("統合に失敗: %v", err)
+		}
+		numbers := make(map[uint8]float64)
+		for _, set := range workoutRepo.sets {
+			if _, ok := numbers[set.SetNumber]; ok {
+				t.Fatalf("セット番号が重複している: %+v", set)
+			}
+			numbers[set.SetNumber] = set.Weight
+		}
+		if numbers[3] != 80 || numbers[4] != 80 {
+			t.Errorf("統合元のセットは3・4番になるはず, 実際: %v", numbers)
+		}
+	})
+
+	t.Run("自己ベストの再計算に失敗した場合は統合しない", func(t *testing.T) {
+		service, workoutRepo, exerciseRepo, _ := newService()
+		source, _ := service.CreateCustomExercise(1, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest"})
+		workoutRepo.CreateWithSets(
+			&model.Workout{UserID: 1, Date: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)},
+			[]*model.WorkoutSet{{ExerciseID: source.ID, SetNumber: 1, Weight: 80, Reps: 5}},
+			nil,
+		)
+		workoutRepo.rebuildErr = errors.New("rebuild failed")
+
+		if _, err := service.MergeCustomExercise(1, source.ID, 1); err == nil {
+			t.Fatal("エラーを期待")
+		}
+		for _, set := range workoutRepo.sets {
+			if set.ExerciseID != source.ID || set.SetNumber != 1 {
+				t.Errorf("セットが付け替えられている: %+v", set)
+			}
+		}
+		if _, ok := exerciseRepo.exercises[source.ID]; !ok {
+			t.Error("統合元の種目が削除されている")
+		}
+	})
+
+	t.Run("アーカイブ済みの種目には統合できない", func(t *testing.T) {
+		service, _, exerciseRepo, _ := newService()
+		source, _ := service.CreateCustomExercise(1, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest"})
+		target, _ := service.CreateCustomExercise(1, &CreateExerciseInput{Name: "ベンチプレス", MuscleGroup: "chest"})
+		archivedAt := time.Now()
+		exerciseRepo.exercises[target.ID].ArchivedAt = &archivedAt
+
+		if _, err := service.MergeCustomExercise(1, source.ID, target.ID); !errors.Is(err, ErrInvalidMergeTarget) {
+			t.Errorf("ErrInvalidMergeTarget を期待, 実際: %v", err)
+		}
+		if _, ok := exerciseRepo.exercises[source.ID]; !ok {
+			t.Error("統合元の種目が削除されている")
+		}
+	})
+
+	t.Run("プリセット種目は統合元にできない", func(t *testing.T) {
+		service, _, _, _ := newService()
+		if _, err := service.MergeCustomExercise(1, 1, 2); !errors.Is(err, ErrNotCustomExercise) {
+			t.Errorf("ErrNotCustomExercise を期待, 実際: %v", err)
+		}
+	})
+
+	t.Run("自分自身や他のユーザーの種目には統合できない", func(t *testing.T) {
+		service, _, _, _ := newService()
+		source, _ := service.CreateCustomExercise(1, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest"})
+		other, _ := service.CreateCustomExercise(2, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest"})
+
+		if _, err := service.MergeCustomExercise(1, source.ID, source.ID); !errors.Is(err, ErrInvalidMergeTarget) {
+			t.Errorf("ErrInvalidMergeTarget を期待, 実際: %v", err)
+		}
+		if _, err := service.MergeCustomExercise(1, source.ID, other.ID); !errors.Is(err, ErrExerciseNotFound) {
+			t.Errorf("ErrExerciseNotFound を期待, 実際: %v", err)
+		}
+	})
+}
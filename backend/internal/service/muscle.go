@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/repository"
+)
+
+// ExerciseMuscleInput は種目が関与する筋肉の指定（Muscle は筋肉のキー）
+type ExerciseMuscleInput struct {
+	Muscle       string  `json:"muscle" validate:"required"`
+	Contribution float64 `json:"contribution" validate:"gt=0,lte=1"`
+}
+
+// GetMuscles は筋肉の階層を部位ごとに返す
+func (s *WorkoutService) GetMuscles() ([]model.Muscle, error) {
+	muscles, err := s.exerciseRepo.FindMuscles()
+	if err != nil {
+		return nil, err
+	}
+	return buildMuscleTree(muscles), nil
+}
+
+// 統計：筋肉別集計
+func (s *WorkoutService) GetMuscleStats(userID uint64, params StatsParams) ([]repository.MuscleStat, error) {
+	filter, err := params.filter()
+	if err != nil {
+		return nil, err
+	}
+	return s.workoutRepo.GetMuscleStats(userID, filter)
+}
+
+// exerciseMuscles は筋肉の指定を検証して種目との対応に変換する
+func (s *WorkoutService) exerciseMuscles(inputs []ExerciseMuscleInput) ([]model.ExerciseMuscle, error) {
+	muscles, err := s.exerciseRepo.FindMuscles()
+	if err != nil {
+		return nil, fmt.Errorf("finding muscles: %w", err)
+	}
+	byKey := make(map[string]model.Muscle, len(muscles))
+	for _, m := range muscles {
+		byKey[m.Key] = m
+	}
+
+	result := make([]model.ExerciseMuscle, 0, len(inputs))
+	seen := make(map[string]bool, len(inputs))
+	for _, input := range inputs {
+		muscle, ok := byKey[input.Muscle]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown muscle %s", ErrInvalidMuscle, input.Muscle)
+		}
+		if seen[input.Muscle] {
+			return nil, fmt.Errorf("%w: duplicate muscle %s", ErrInvalidMuscle, input.Muscle)
+		}
+		if input.Contribution <= 0 || input.Contribution > 1 {
+			return nil, fmt.Errorf("%w: contribution must be greater than 0 and at most 1", ErrInvalidMuscle)
+		}
+		seen[input.Muscle] = true
+		result = append(result, model.ExerciseMuscle{MuscleID: muscle.ID, Contribution: input.Contribution})
+	}
+	return result, nil
+}
+
+// buildMuscleTree は筋肉の一覧を親子関係に組み立てる（親のない筋肉が最上位）
+func buildMuscleTree(muscles []model.Muscle) []model.Muscle {
+	children := make(map[uint64][]model.Muscle)
+	for _, m := range muscles {
+		if m.ParentID != nil {
+			children[*m.ParentID] = append(children[*m.ParentID], m)
+		}
+	}
+
+	var attach func(m model.Muscle) model.Muscle
+	attach = func(m model.Muscle) model.Muscle {
+		for _, child := range children[m.ID] {
+			m.Children = append(m.Children, attach(child))
+		}
+		return m
+	}
+
+	roots := []model.Muscle{}
+	for _, m := range muscles {
+		if m.ParentID == nil {
+			roots = append(roots, attach(m))
+		}
+	}
+	return roots
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuildMuscleTree(t *testing.T) {
+	t.Run("個別の筋肉を部位の下に並べる", func(t *testing.T) {
+		muscles, _ := NewMockExerciseRepository().FindMuscles()
+
+		tree := buildMuscleTree(muscles)
+		if len(tree) != 3 {
+			t.Fatalf("期待される部位の数: 3, 実際: %d", len(tree))
+		}
+		arms := tree[1]
+		if arms.Key != "arms" || len(arms.Children) != 1 || arms.Children[0].Key != "triceps" {
+			t.Errorf("腕の下に上腕三頭筋を期待, 実際: %+v", arms)
+		}
+		if len(tree[0].Children) != 0 {
+			t.Errorf("胸には個別の筋肉がないことを期待, 実際: %+v", tree[0].Children)
+		}
+	})
+}
+
+func TestWorkoutService_ExerciseMuscles(t *testing.T) {
+	newService := func() *WorkoutService {
+		return NewWorkoutService(NewMockWorkoutRepository(), NewMockExerciseRepository(), nil, nil, nil, nil)
+	}
+
+	t.Run("関与する筋肉を指定してカスタム種目を作成できる", func(t *testing.T) {
+		service := newService()
+		exercise, err := service.CreateCustomExercise(1, &CreateExerciseInput{
+			Name:        "ナローベンチプレス",
+			MuscleGroup: "chest",
+			Muscles: []ExerciseMuscleInput{
+				{Muscle: "chest", Contribution: 1},
+				{Muscle: "triceps", Contribution: 0.75},
+			},
+		})
+		if err != nil {
+			t.Fatalf("作成に失敗: %v", err)
+		}
+		if len(exercise.Muscles) != 2 || exercise.Muscles[1].MuscleID != 3 || exercise.Muscles[1].Contribution != 0.75 {
+			t.Errorf("上腕三頭筋との対応を期待, 実際: %+v", exercise.Muscles)
+		}
+	})
+
+	t.Run("不正な筋肉の指定はエラー", func(t *testing.T) {
+		cases := map[string][]ExerciseMuscleInput{
+			"存在しない筋肉":    {{Muscle: "neck", Contribution: 1}},
+			"重複した筋肉":     {{Muscle: "chest", Contribution: 1}, {Muscle: "chest", Contribution: 0.5}},
+			"関与の度合いが範囲外": {{Muscle: "chest", Contribution: 1.5}},
+		}
+		for name, muscles := range cases {
+			_, err := newService().CreateCustomExercise(1, &CreateExerciseInput{Name: "種目", MuscleGroup: "chest", Muscles: muscles})
+			if !errors.Is(err, ErrInvalidMuscle) {
+				t.Errorf("%s: ErrInvalidMuscle を期待, 実際: %v", name, err)
+			}
+		}
+	})
+
+	t.Run("更新時に筋肉を省略した場合は対応を変更しない", func(t *testing.T) {
+		service := newService()
+		exercise, err := service.CreateCustomExercise(1, &CreateExerciseInput{
+			Name:        "フロントスクワット",
+			MuscleGroup: "legs",
+			Muscles:     []ExerciseMuscleInput{{Muscle: "quads", Contribution: 1}},
+		})
+		if err != nil {
+			t.Fatalf("作成に失敗: %v", err)
+		}
+
+		updated, err := service.UpdateCustomExercise(1, exercise.ID, &UpdateExerciseInput{Name: "フロントスクワット（ハイバー）", MuscleGroup: "legs"})
+		if err != nil {
+			t.Fatalf("更新に失敗: %v", err)
+		}
+		if len(updated.Muscles) != 1 || updated.Muscles[0].MuscleID != 5 {
+			t.Errorf("大腿四頭筋との対応が残ることを期待, 実際: %+v", updated.Muscles)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/training-memo/backend/internal/model"
+)
+
+// EstimateOneRepMax は重量とレップ数から推定1RMを算出する（Epley式）
+func EstimateOneRepMax(weight float64, reps uint16) float64 {
+	return model.OneRepMaxFormulaEpley.Estimate(weight, reps)
+}
+
+// ParseOneRepMaxFormula はクエリパラメータの算出式を解釈する
+// 未指定の場合は Epley 式を使う
+func ParseOneRepMaxFormula(s string) (model.OneRepMaxFormula, error) {
+	if s == "" {
+		return model.OneRepMaxFormulaEpley, nil
+	}
+	formula := model.OneRepMaxFormula(s)
+	if !formula.IsValid() {
+		return "", fmt.Errorf("%w: %s", ErrInvalidFormula, s)
+	}
+	return formula, nil
+}
+
+// rpePercentage は目標レップ数とRPEから推定1RMに対する割合（0〜1）を求める
+// 余力（10 - RPE）を加えたレップ数を限界レップ数とみなし、Epley式を逆算する
+func rpePercentage(reps uint16, rpe float64) float64 {
+	effectiveReps := float64(reps) + (10 - rpe)
+	if effectiveReps <= 1 {
+		return 1
+	}
+	return 1 / (1 + effectiveReps/30)
+}
+
+// roundToIncrement は重量をプレート刻みに丸める
+func roundToIncrement(weight, increment float64) float64 {
+	if increment <= 0 {
+		return weight
+	}
+	return math.Round(weight/increment) * increment
+}
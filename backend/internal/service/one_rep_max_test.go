@@ -0,0 +1,84 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/training-memo/backend/internal/model"
+)
+
+func TestOneRepMaxFormula_Estimate(t *testing.T) {
+	tests := []struct {
+		name    string
+		formula model.OneRepMaxFormula
+		weight  float64
+		reps    uint16
+		want    float64
+	}{
+		{"Epley式", model.OneRepMaxFormulaEpley, 100, 6, 120},
+		{"Brzycki式", model.OneRepMaxFormulaBrzycki, 100, 10, 133.333},
+		{"Lombardi式", model.OneRepMaxFormulaLombardi, 100, 10, 125.893},
+		{"1レップは重量そのまま", model.OneRepMaxFormulaBrzycki, 100, 1, 100},
+		{"0レップは0", model.OneRepMaxFormulaEpley, 100, 0, 0},
+		{"Brzycki式は37レップ以上でEpley式に切り替える", model.OneRepMaxFormulaBrzycki, 30, 40, 70},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.formula.Estimate(tt.weight, tt.reps)
+			if math.Abs(got-tt.want) > 0.001 {
+				t.Errorf("期待される1RM: %f, 実際: %f", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseOneRepMaxFormula(t *testing.T) {
+	t.Run("未指定はEpley式", func(t *testing.T) {
+		f, err := ParseOneRepMaxFormula("")
+		if err != nil || f != model.OneRepMaxFormulaEpley {
+			t.Errorf("期待される算出式: epley, 実際: %s (%v)", f, err)
+		}
+	})
+
+	t.Run("不明な算出式はエラー", func(t *testing.T) {
+		_, err := ParseOneRepMaxFormula("mayhew")
+		if !errors.Is(err, ErrInvalidFormula) {
+			t.Errorf("ErrInvalidFormula が返るべき, 実際: %v", err)
+		}
+	})
+}
+
+func TestWorkoutService_GetRepMaxes(t *testing.T) {
+	t.Run("レップ数ごとの最高重量を1〜12レップで返す", func(t *testing.T) {
+		workoutRepo := NewMockWorkoutRepository()
+		workoutService := NewWorkoutService(workoutRepo, NewMockExerciseRepository(), nil, nil, nil, nil)
+
+		userID := uint64(1)
+		date := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+		workout := &model.Workout{UserID: userID, Date: date}
+		workoutRepo.Create(workout)
+		workoutRepo.AddSet(&model.WorkoutSet{WorkoutID: workout.ID, ExerciseID: 1, SetNumber: 1, Weight: 100, Reps: 5})
+		workoutRepo.AddSet(&model.WorkoutSet{WorkoutID: workout.ID, ExerciseID: 1, SetNumber: 2, Weight: 90, Reps: 5})
+		workoutRepo.AddSet(&model.WorkoutSet{WorkoutID: workout.ID, ExerciseID: 1, SetNumber: 3, Weight: 110, Reps: 1})
+
+		entries, err := workoutService.GetRepMaxes(userID, 1, "")
+		if err != nil {
+			t.Fatalf("自己ベスト表の取得に失敗: %v", err)
+		}
+		if len(entries) != 12 {
+			t.Fatalf("期待される行数: 12, 実際: %d", len(entries))
+		}
+		if entries[4].Weight == nil || *entries[4].Weight != 100 {
+			t.Errorf("5レップの期待される重量: 100, 実際: %v", entries[4].Weight)
+		}
+		if entries[0].Weight == nil || *entries[0].Weight != 110 {
+			t.Errorf("1レップの期待される重量: 110, 実際: %v", entries[0].Weight)
+		}
+		if entries[2].Weight != nil {
+			t.Errorf("記録のない3レップはnilであるべき")
+		}
+	})
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/repository"
+)
+
+// ComparisonRange は比較する期間（両端を含む）
+type ComparisonRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+func (r ComparisonRange) filter() repository.StatsFilter {
+	from, to := r.From, r.To
+	return repository.StatsFilter{From: &from, To: &to}
+}
+
+// weeks は期間の週数（期間の長さが異なる場合に頻度をそろえるために使う）
+func (r ComparisonRange) weeks() float64 {
+	return (r.To.Sub(r.From).Hours()/24 + 1) / 7
+}
+
+// MuscleGroupComparison は部位ごとのボリュームの比較
+type MuscleGroupComparison struct {
+	MuscleGroup string `json:"muscle_group"`
+	Sets        Delta  `json:"sets"`
+	Volume      Delta  `json:"volume"`
+}
+
+// ExerciseComparison は種目ごとのトップセット（最大重量）と推定1RMの比較
+type ExerciseComparison struct {
+	ExerciseID         uint64 `json:"exercise_id"`
+	ExerciseName       string `json:"exercise_name"`
+	MuscleGroup        string `json:"muscle_group"`
+	MaxWeight          Delta  `json:"max_weight"`
+	EstimatedOneRepMax Delta  `json:"estimated_one_rep_max"`
+}
+
+// PeriodComparison は2つの期間の比較
+// Delta の previous が期間A、current が期間Bの値で、差は B − A
+type PeriodComparison struct {
+	A                 ComparisonRange         `json:"a"`
+	B                 ComparisonRange         `json:"b"`
+	Sessions          Delta                   `json:"sessions"`
+	SessionsPerWeek   Delta                   `json:"sessions_per_week"`
+	Sets              Delta                   `json:"sets"`
+	Reps              Delta                   `json:"reps"`
+	Volume            Delta                   `json:"volume"`
+	MuscleGroups      []MuscleGroupComparison `json:"muscle_groups"`
+	Exercises         []ExerciseComparison    `json:"exercises"`
+	AverageBodyWeight *Delta                  `json:"average_body_weight"` // どちらかの期間に記録がない場合は nil
+	BodyWeightA       *BodyWeightChange       `json:"body_weight_a"`
+	BodyWeightB       *BodyWeightChange       `json:"body_weight_b"`
+}
+
+// ParseComparisonRange は期間の開始日・終了日を検証する（どちらも必須）
+func ParseComparisonRange(from, to string) (ComparisonRange, error) {
+	if from == "" || to == "" {
+		return ComparisonRange{}, fmt.Errorf("%w: both from and to are required", ErrInvalidDateRange)
+	}
+	filter, err := StatsParams{From: from, To: to}.filter()
+	if err != nil {
+		return ComparisonRange{}, err
+	}
+	return ComparisonRange{From: *filter.From, To: *filter.To}, nil
+}
+
+// Compare は期間Aと期間Bの頻度・部位別ボリューム・種目別の記録・体重を比較する
+func (s *ReportService) Compare(userID uint64, a, b ComparisonRange, formula string) (*PeriodComparison, error) {
+	f, err := ParseOneRepMaxFormula(formula)
+	if err != nil {
+		return nil, err
+	}
+
+	totalsA, err := s.workoutRepo.GetTrainingTotals(userID, a.filter())
+	if err != nil {
+		return nil, fmt.Errorf("getting training totals: %w", err)
+	}
+	totalsB, err := s.workoutRepo.GetTrainingTotals(userID, b.filter())
+	if err != nil {
+		return nil, fmt.Errorf("getting training totals: %w", err)
+	}
+
+	muscleGroupsA, err := s.workoutRepo.GetMuscleGroupStats(userID, a.filter())
+	if err != nil {
+		return nil, fmt.Errorf("getting muscle group stats: %w", err)
+	}
+	muscleGroupsB, err := s.workoutRepo.GetMuscleGroupStats(userID, b.filter())
+	if err != nil {
+		return nil, fmt.Errorf("getting muscle group stats: %w", err)
+	}
+
+	bestsA, err := s.workoutRepo.GetPersonalBests(userID, f, a.filter())
+	if err != nil {
+		return nil, fmt.Errorf("getting personal bests: %w", err)
+	}
+	bestsB, err := s.workoutRepo.GetPersonalBests(userID, f, b.filter())
+	if err != nil {
+		return nil, fmt.Errorf("getting personal bests: %w", err)
+	}
+
+	bodyWeightsA, err := s.bodyWeightRepo.FindByUserIDAndDateRange(userID, a.From, a.To)
+	if err != nil {
+		return nil, fmt.Errorf("finding body weight records: %w", err)
+	}
+	bodyWeightsB, err := s.bodyWeightRepo.FindByUserIDAndDateRange(userID, b.From, b.To)
+	if err != nil {
+		return nil, fmt.Errorf("finding body weight records: %w", err)
+	}
+
+	comparison := &PeriodComparison{
+		A:               a,
+		B:               b,
+		Sessions:        newDelta(float64(totalsB.Sessions), float64(totalsA.Sessions)),
+		SessionsPerWeek: newDelta(float64(totalsB.Sessions)/b.weeks(), float64(totalsA.Sessions)/a.weeks()),
+		Sets:            newDelta(float64(totalsB.Sets), float64(totalsA.Sets)),
+		Reps:            newDelta(float64(totalsB.Reps), float64(totalsA.Reps)),
+		Volume:          newDelta(totalsB.Volume, totalsA.Volume),
+		MuscleGroups:    compareMuscleGroups(muscleGroupsA, muscleGroupsB),
+		Exercises:       compareExercises(bestsA, bestsB),
+		BodyWeightA:     bodyWeightChange(bodyWeightsA),
+		BodyWeightB:     bodyWeightChange(bodyWeightsB),
+	}
+	if len(bodyWeightsA) > 0 && len(bodyWeightsB) > 0 {
+		d := newDelta(averageBodyWeight(bodyWeightsB), averageBodyWeight(bodyWeightsA))
+		comparison.AverageBodyWeight = &d
+	}
+
+	return comparison, nil
+}
+
+// compareMuscleGroups は部位ごとに2つの期間の値を突き合わせる（片方にしかない部位は0として比較する）
+func compareMuscleGroups(a, b []repository.MuscleGroupStat) []MuscleGroupComparison {
+	statsA := make(map[string]repository.MuscleGroupStat)
+	statsB := make(map[string]repository.MuscleGroupStat)
+	var groups []string
+	for _, stat := range a {
+		statsA[stat.MuscleGroup] = stat
+		groups = append(groups, stat.MuscleGroup)
+	}
+	for _, stat := range b {
+		statsB[stat.MuscleGroup] = stat
+		if _, ok := statsA[stat.MuscleGroup]; !ok {
+			groups = append(groups, stat.MuscleGroup)
+		}
+	}
+	sort.Strings(groups)
+
+	comparisons := make([]MuscleGroupComparison, 0, len(groups))
+	for _, group := range groups {
+		comparisons = append(comparisons, MuscleGroupComparison{
+			MuscleGroup: group,
+			Sets:        newDelta(float64(statsB[group].SetCount), float64(statsA[group].SetCount)),
+			Volume:      newDelta(statsB[group].TotalVolume, statsA[group].TotalVolume),
+		})
+	}
+	return comparisons
+}
+
+// compareExercises は種目ごとに2つの期間の最大重量と推定1RMを突き合わせる
+func compareExercises(a, b []repository.PersonalBest) []ExerciseComparison {
+	bestsA := make(map[uint64]repository.PersonalBest)
+	bestsB := make(map[uint64]repository.PersonalBest)
+	var exercises []repository.PersonalBest
+	for _, best := range a {
+		bestsA[best.ExerciseID] = best
+		exercises = append(exercises, best)
+	}
+	for _, best := range b {
+		bestsB[best.ExerciseID] = best
+		if _, ok := bestsA[best.ExerciseID]; !ok {
+			exercises = append(exercises, best)
+		}
+	}
+	sort.SliceStable(exercises, func(i, j int) bool {
+		if exercises[i].MuscleGroup != exercises[j].MuscleGroup {
+			return exercises[i].MuscleGroup < exercises[j].MuscleGroup
+		}
+		return exercises[i].ExerciseName < exercises[j].ExerciseName
+	})
+
+	comparisons := make([]ExerciseComparison, 0, len(exercises))
+	for _, e := range exercises {
+		bestA, bestB := bestsA[e.ExerciseID], bestsB[e.ExerciseID]
+		comparisons = append(comparisons, ExerciseComparison{
+			ExerciseID:         e.ExerciseID,
+			ExerciseName:       e.ExerciseName,
+			MuscleGroup:        e.MuscleGroup,
+			MaxWeight:          newDelta(bestB.MaxWeight, bestA.MaxWeight),
+			EstimatedOneRepMax: newDelta(bestB.EstimatedOneRepMax, bestA.EstimatedOneRepMax),
+		})
+	}
+	return comparisons
+}
+
+func averageBodyWeight(records []model.BodyWeight) float64 {
+	var sum float64
+	for _, r := range records {
+		sum += r.Weight
+	}
+	return sum / float64(len(records))
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/training-memo/backend/internal/repository"
+)
+
+func TestParseComparisonRange(t *testing.T) {
+	t.Run("開始日と終了日は必須", func(t *testing.T) {
+		if _, err := ParseComparisonRange("2026-09-01", ""); !errors.Is(err, ErrInvalidDateRange) {
+			t.Errorf("ErrInvalidDateRange を期待, 実際: %v", err)
+		}
+	})
+
+	t.Run("期間の週数を求める", func(t *testing.T) {
+		r, err := ParseComparisonRange("2026-09-01", "2026-09-28")
+		if err != nil {
+			t.Fatalf("解釈に失敗: %v", err)
+		}
+		if r.weeks() != 4 {
+			t.Errorf("期待される週数: 4, 実際: %v", r.weeks())
+		}
+	})
+}
+
+func TestCompareMuscleGroups(t *testing.T) {
+	t.Run("片方の期間にしかない部位は0と比較する", func(t *testing.T) {
+		a := []repository.MuscleGroupStat{
+			{MuscleGroup: "chest", SetCount: 10, TotalVolume: 5000},
+			{MuscleGroup: "legs", SetCount: 8, TotalVolume: 8000},
+		}
+		b := []repository.MuscleGroupStat{
+			{MuscleGroup: "chest", SetCount: 12, TotalVolume: 6000},
+			{MuscleGroup: "back", SetCount: 6, TotalVolume: 4000},
+		}
+
+		got := compareMuscleGroups(a, b)
+		if len(got) != 3 || got[0].MuscleGroup != "back" || got[1].MuscleGroup != "chest" || got[2].MuscleGroup != "legs" {
+			t.Fatalf("期待される部位: back, chest, legs, 実際: %+v", got)
+		}
+		if got[0].Volume.ChangePercent != nil {
+			t.Error("期間Aにない部位の変化率は nil を期待")
+		}
+		if got[1].Volume.Change != 1000 || *got[1].Volume.ChangePercent != 20 {
+			t.Errorf("期待される変化: +1000（20%%）, 実際: %+v", got[1].Volume)
+		}
+		if got[2].Volume.Current != 0 || got[2].Volume.Change != -8000 {
+			t.Errorf("期待される変化: -8000, 実際: %+v", got[2].Volume)
+		}
+	})
+}
+
+func TestCompareExercises(t *testing.T) {
+	t.Run("種目ごとに最大重量と推定1RMを比較する", func(t *testing.T) {
+		a := []repository.PersonalBest{{ExerciseID: 1, ExerciseName: "ベンチプレス", MuscleGroup: "chest", MaxWeight: 80, EstimatedOneRepMax: 93.3}}
+		b := []repository.PersonalBest{{ExerciseID: 1, ExerciseName: "ベンチプレス", MuscleGroup: "chest", MaxWeight: 85, EstimatedOneRepMax: 99.2}}
+
+		got := compareExercises(a, b)
+		if len(got) != 1 {
+			t.Fatalf("期待される件数: 1, 実際: %d", len(got))
+		}
+		if got[0].MaxWeight.Change != 5 {
+			t.Errorf("期待される最大重量の変化: 5, 実際: %v", got[0].MaxWeight.Change)
+		}
+	})
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/repository"
+)
+
+// PersonalRecordTracker はワークアウトの保存・削除に合わせて自己ベスト履歴を作り直す
+type PersonalRecordTracker struct {
+	workoutRepo WorkoutRepository
+	recordRepo  PersonalRecordRepository
+}
+
+func NewPersonalRecordTracker(workoutRepo WorkoutRepository, recordRepo PersonalRecordRepository) *PersonalRecordTracker {
+	return &PersonalRecordTracker{
+		workoutRepo: workoutRepo,
+		recordRepo:  recordRepo,
+	}
+}
+
+// Rebuild は指定種目の自己ベスト履歴を from 以降作り直す内容を返す
+// ワークアウトの保存・削除や種目の統合と同じトランザクションで実行するためリポジトリに渡す
+// from より前の記録は変わらないため、それ以降のワークアウトだけを再計算する
+func (t *PersonalRecordTracker) Rebuild(userID uint64, exerciseIDs []uint64, from time.Time) *repository.RecordRebuild {
+	return &repository.RecordRebuild{
+		UserID:      userID,
+		ExerciseIDs: exerciseIDs,
+		From:        from,
+		Detect: func(exerciseID uint64, baseline repository.RecordBaseline, history []repository.SetHistory) []*model.PersonalRecord {
+			return detectPersonalRecords(userID, exerciseID, baseline, history)
+		},
+	}
+}
+
+// RecordsForWorkout はワークアウトで更新した自己ベストを返す
+func (t *PersonalRecordTracker) RecordsForWorkout(workoutID uint64) ([]model.PersonalRecord, error) {
+	return t.recordRepo.FindByWorkoutID(workoutID)
+}
+
+// History は自己ベストの更新履歴を返す（exerciseID が0の場合は全種目）
+func (t *PersonalRecordTracker) History(userID, exerciseID uint64) ([]model.PersonalRecord, error) {
+	return t.recordRepo.FindByUserID(userID, exerciseID)
+}
+
+// detectPersonalRecords は日付順のセット履歴から自己ベストの更新を検出する
+// baseline の記録を超えたものを更新とし、以降は履歴の中での最高値と比べる
+// 比較はワークアウト単位で行い、同じワークアウト内のセット同士では更新扱いにしない
+func detectPersonalRecords(userID, exerciseID uint64, baseline repository.RecordBaseline, history []repository.SetHistory) []*model.PersonalRecord {
+	var records []*model.PersonalRecord
+
+	bestWeight := baseline.BestWeight
+	bestE1RM := baseline.BestE1RM
+	bestVolume := baseline.BestVolume
+	bestReps := make(repBests, len(baseline.RepsByWeight))
+	for weight, reps := range baseline.RepsByWeight {
+		bestReps.add(weight, reps)
+	}
+
+	newRecord := func(recordType model.RecordType, set repository.SetHistory, value, prev float64) *model.PersonalRecord {
+		record := &model.PersonalRecord{
+			UserID:     userID,
+			ExerciseID: exerciseID,
+			WorkoutID:  set.WorkoutID,
+			RecordType: recordType,
+			Weight:     set.Weight,
+			Reps:       set.Reps,
+			Value:      value,
+			AchievedOn: set.Date,
+		}
+		if set.SetID != 0 {
+			setID := set.SetID
+			record.WorkoutSetID = &setID
+		}
+		if prev > 0 {
+			p := prev
+			record.PreviousValue = &p
+		}
+		return record
+	}
+
+	for start := 0; start < len(history); {
+		end := start
+		for end < len(history) && history[end].WorkoutID == history[start].WorkoutID {
+			end++
+		}
+		session := history[start:end]
+
+		var (
+			topWeight, topE1RM  repository.SetHistory
+			sessionE1RM, volume float64
+			hasWeight, hasE1RM  bool
+		)
+		for _, set := range session {
+			volume += set.Weight * float64(set.Reps)
+			if set.Weight > 0 && (!hasWeight || set.Weight > topWeight.Weight) {
+				topWeight, hasWeight = set, true
+			}
+			if e1rm := EstimateOneRepMax(set.Weight, set.Reps); e1rm > 0 && (!hasE1RM || e1rm > sessionE1RM) {
+				topE1RM, sessionE1RM, hasE1RM = set, e1rm, true
+			}
+		}
+
+		if hasWeight && topWeight.Weight > bestWeight {
+			records = append(records, newRecord(model.RecordTypeMaxWeight, topWeight, topWeight.Weight, bestWeight))
+			bestWeight = topWeight.Weight
+		}
+		if hasE1RM && sessionE1RM > bestE1RM {
+			records = append(records, newRecord(model.RecordTypeEstimatedOneRepMax, topE1RM, sessionE1RM, bestE1RM))
+			bestE1RM = sessionE1RM
+		}
+		if volume > bestVolume {
+			// ボリュームはワークアウト全体の記録なのでセットは紐づけない
+			set := session[0]
+			set.SetID, set.Weight, set.Reps = 0, 0, 0
+			records = append(records, newRecord(model.RecordTypeSessionVolume, set, volume, bestVolume))
+			bestVolume = volume
+		}
+		records = append(records, detectRepRecords(session, bestReps, newRecord)...)
+
+		for _, set := range session {
+			bestReps.add(set.Weight, set.Reps)
+		}
+		start = end
+	}
+
+	return records
+}
+
+// detectRepRecords は同じ重量以上で過去に行った最多レップ数を超えたセットを検出する
+// その重量以上の記録が過去にない場合は最大重量の更新として扱われるため対象外とする
+func detectRepRecords(
+	session []repository.SetHistory,
+	previous repBests,
+	newRecord func(model.RecordType, repository.SetHistory, float64, float64) *model.PersonalRecord,
+) []*model.PersonalRecord {
+	// 重量ごとにワークアウト内の最多レップのセットを求める
+	bestByWeight := make(map[float64]repository.SetHistory)
+	for _, set := range session {
+		if current, ok := bestByWeight[set.Weight]; !ok || set.Reps > current.Reps {
+			bestByWeight[set.Weight] = set
+		}
+	}
+
+	weights := make([]float64, 0, len(bestByWeight))
+	for w := range bestByWeight {
+		weights = append(weights, w)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(weights)))
+
+	var records []*model.PersonalRecord
+	var heavierReps uint16
+	for _, w := range weights {
+		set := bestByWeight[w]
+		// 同じワークアウト内のより重いセットで同等以上のレップ数をこなしていれば記録しない
+		dominated := set.Reps <= heavierReps
+		if set.Reps > heavierReps {
+			heavierReps = set.Reps
+		}
+		if dominated {
+			continue
+		}
+
+		prevReps, found := previous.atLeast(w)
+		if found && set.Reps > prevReps {
+			records = append(records, newRecord(model.RecordTypeMaxRepsAtWeight, set, float64(set.Reps), float64(prevReps)))
+		}
+	}
+	return records
+}
+
+// repBests はこれまでのワークアウトでの重量ごとの最多レップ数
+type repBests map[float64]uint16
+
+func (b repBests) add(weight float64, reps uint16) {
+	if current, ok := b[weight]; !ok || reps > current {
+		b[weight] = reps
+	}
+}
+
+// atLeast は weight 以上の重量での最多レップ数を返す（該当する記録がなければ found は false）
+func (b repBests) atLeast(weight float64) (reps uint16, found bool) {
+	for w, r := range b {
+		if w >= weight {
+			found = true
+			if r > reps {
+				reps = r
+			}
+		}
+	}
+	return reps, found
+}
+
+// newlyAchieved は records のうち before に同じ内容の記録がないものを返す
+// 編集ではセットが作り直されるため、セットIDではなく重量・レップ数・値で比べる
+func newlyAchieved(records, before []model.PersonalRecord) []model.PersonalRecord {
+	type key struct {
+		exerciseID uint64
+		recordType model.RecordType
+		weight     float64
+		reps       uint16
+		value      float64
+		achievedOn string
+	}
+	keyOf := func(r model.PersonalRecord) key {
+		return key{r.ExerciseID, r.RecordType, r.Weight, r.Reps, r.Value, r.AchievedOn.Format("2006-01-02")}
+	}
+	existed := make(map[key]bool, len(before))
+	for _, r := range before {
+		existed[keyOf(r)] = true
+	}
+	result := make([]model.PersonalRecord, 0, len(records))
+	for _, r := range records {
+		if !existed[keyOf(r)] {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// exerciseIDsOf はセットに含まれる種目IDを重複なく返す
+func exerciseIDsOf(sets []model.WorkoutSet, extra []*model.WorkoutSet) []uint64 {
+	seen := make(map[uint64]bool)
+	var ids []uint64
+	add := func(id uint64) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, set := range sets {
+		add(set.ExerciseID)
+	}
+	for _, set := range extra {
+		add(set.ExerciseID)
+	}
+	return ids
+}
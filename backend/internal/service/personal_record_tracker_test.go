@@ -0,0 +1,253 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/repository"
+)
+
+// MockPersonalRecordRepository はテスト用のモックリポジトリ
+// 記録は MockWorkoutRepository の再計算で作られる
+type MockPersonalRecordRepository struct {
+	records []model.PersonalRecord
+}
+
+func (r *MockPersonalRecordRepository) FindByWorkoutID(workoutID uint64) ([]model.PersonalRecord, error) {
+	var records []model.PersonalRecord
+	for _, record := range r.records {
+		if record.WorkoutID == workoutID {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (r *MockPersonalRecordRepository) FindByUserID(userID uint64, exerciseID uint64) ([]model.PersonalRecord, error) {
+	var records []model.PersonalRecord
+	for _, record := range r.records {
+		if record.UserID == userID && (exerciseID == 0 || record.ExerciseID == exerciseID) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (r *MockPersonalRecordRepository) FindByUserIDAndDateRange(userID uint64, startDate, endDate time.Time) ([]model.PersonalRecord, error) {
+	var records []model.PersonalRecord
+	for _, record := range r.records {
+		if record.UserID == userID && !record.AchievedOn.Before(startDate) && !record.AchievedOn.After(endDate) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func countRecords(records []model.PersonalRecord, recordType model.RecordType) int {
+	count := 0
+	for _, record := range records {
+		if record.RecordType == recordType {
+			count++
+		}
+	}
+	return count
+}
+
+func derefRecords(records []*model.PersonalRecord) []model.PersonalRecord {
+	result := make([]model.PersonalRecord, len(records))
+	for i, r := range records {
+		result[i] = *r
+	}
+	return result
+}
+
+func TestDetectPersonalRecords(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+
+	t.Run("ワークアウト単位で最大重量の更新を検出する", func(t *testing.T) {
+		history := []repository.SetHistory{
+			{WorkoutID: 1, SetID: 1, Date: day(1), SetNumber: 1, Weight: 80, Reps: 5},
+			{WorkoutID: 1, SetID: 2, Date: day(1), SetNumber: 2, Weight: 90, Reps: 3},
+			{WorkoutID: 2, SetID: 3, Date: day(3), SetNumber: 1, Weight: 85, Reps: 5},
+			{WorkoutID: 3, SetID: 4, Date: day(5), SetNumber: 1, Weight: 95, Reps: 1},
+		}
+		records := detectPersonalRecords(1, 1, repository.RecordBaseline{}, history)
+
+		var maxWeights []*model.PersonalRecord
+		for _, r := range records {
+			if r.RecordType == model.RecordTypeMaxWeight {
+				maxWeights = append(maxWeights, r)
+			}
+		}
+		if len(maxWeights) != 2 {
+			t.Fatalf("期待される最大重量の更新数: 2, 実際: %d", len(maxWeights))
+		}
+		if maxWeights[1].Value != 95 || maxWeights[1].PreviousValue == nil || *maxWeights[1].PreviousValue != 90 {
+			t.Errorf("期待される更新: 90→95, 実際: %v→%f", maxWeights[1].PreviousValue, maxWeights[1].Value)
+		}
+		if maxWeights[1].WorkoutSetID == nil || *maxWeights[1].WorkoutSetID != 4 {
+			t.Errorf("更新したセットが紐づいていない")
+		}
+	})
+
+	t.Run("同じ重量以上での最多レップ更新を検出する", func(t *testing.T) {
+		history := []repository.SetHistory{
+			{WorkoutID: 1, SetID: 1, Date: day(1), SetNumber: 1, Weight: 100, Reps: 5},
+			{WorkoutID: 2, SetID: 2, Date: day(3), SetNumber: 1, Weight: 100, Reps: 7},
+			{WorkoutID: 2, SetID: 3, Date: day(3), SetNumber: 2, Weight: 95, Reps: 6},
+		}
+		records := detectPersonalRecords(1, 1, repository.RecordBaseline{}, history)
+
+		var repRecords []*model.PersonalRecord
+		for _, r := range records {
+			if r.RecordType == model.RecordTypeMaxRepsAtWeight {
+				repRecords = append(repRecords, r)
+			}
+		}
+		// 95kg×6 は同じワークアウトの 100kg×7 に劣るため記録しない
+		if len(repRecords) != 1 {
+			t.Fatalf("期待されるレップ更新数: 1, 実際: %d", len(repRecords))
+		}
+		if repRecords[0].Weight != 100 || repRecords[0].Reps != 7 {
+			t.Errorf("期待される記録: 100kg×7, 実際: %fkg×%d", repRecords[0].Weight, repRecords[0].Reps)
+		}
+	})
+
+	t.Run("起点より前の記録を基準に更新を検出する", func(t *testing.T) {
+		baseline := repository.RecordBaseline{
+			BestWeight:   100,
+			RepsByWeight: map[float64]uint16{100: 5, 90: 8},
+		}
+		history := []repository.SetHistory{
+			{WorkoutID: 5, SetID: 10, Date: day(10), SetNumber: 1, Weight: 95, Reps: 6},
+			{WorkoutID: 6, SetID: 11, Date: day(12), SetNumber: 1, Weight: 90, Reps: 7},
+		}
+		records := detectPersonalRecords(1, 1, baseline, history)
+
+		if countRecords(derefRecords(records), model.RecordTypeMaxWeight) != 0 {
+			t.Errorf("起点より前の100kgを超えていないため最大重量の更新はないはず: %+v", records)
+		}
+		var repRecords []*model.PersonalRecord
+		for _, r := range records {
+			if r.RecordType == model.RecordTypeMaxRepsAtWeight {
+				repRecords = append(repRecords, r)
+			}
+		}
+		// 95kg×6 は 100kg×5 を超える。90kg×7 は起点より前の 90kg×8 に届かない
+		if len(repRecords) != 1 || repRecords[0].Weight != 95 || *repRecords[0].PreviousValue != 5 {
+			t.Errorf("期待される記録: 95kg×6（前回5回）, 実際: %+v", repRecords)
+		}
+	})
+
+	t.Run("総ボリュームの更新はセットに紐づけない", func(t *testing.T) {
+		history := []repository.SetHistory{
+			{WorkoutID: 1, SetID: 1, Date: day(1), SetNumber: 1, Weight: 60, Reps: 10},
+			{WorkoutID: 2, SetID: 2, Date: day(3), SetNumber: 1, Weight: 60, Reps: 10},
+			{WorkoutID: 2, SetID: 3, Date: day(3), SetNumber: 2, Weight: 60, Reps: 10},
+		}
+		records := detectPersonalRecords(1, 1, repository.RecordBaseline{}, history)
+
+		var volume *model.PersonalRecord
+		for _, r := range records {
+			if r.RecordType == model.RecordTypeSessionVolume && r.WorkoutID == 2 {
+				volume = r
+			}
+		}
+		if volume == nil || volume.Value != 1200 {
+			t.Fatalf("期待される総ボリューム: 1200, 実際: %+v", volume)
+		}
+		if volume.WorkoutSetID != nil {
+			t.Errorf("総ボリュームはセットに紐づかないべき")
+		}
+	})
+}
+
+func TestWorkoutService_PersonalRecords(t *testing.T) {
+	newService := func() (*WorkoutService, *MockPersonalRecordRepository) {
+		workoutRepo := NewMockWorkoutRepository()
+		recordRepo := workoutRepo.records
+		tracker := NewPersonalRecordTracker(workoutRepo, recordRepo)
+		exerciseRepo := NewMockExerciseRepository()
+		achievements := NewAchievementService(&MockAchievementRepository{}, workoutRepo, exerciseRepo, &MockBodyWeightRepository{}, DefaultAchievementRules)
+		return NewWorkoutService(workoutRepo, exerciseRepo, tracker, achievements, NewGoalService(&MockGoalRepository{}, workoutRepo, exerciseRepo, &MockBodyWeightRepository{}), nil), recordRepo
+	}
+
+	t.Run("作成時に更新した自己ベストを返す", func(t *testing.T) {
+		workoutService, _ := newService()
+		userID := uint64(1)
+
+		if _, err := workoutService.CreateWorkout(userID, &CreateWorkoutInput{
+			Date: "2026-10-01",
+			Sets: []CreateSetInput{{ExerciseID: 1, SetNumber: 1, Weight: 100, Reps: 5}},
+		}); err != nil {
+			t.Fatalf("ワークアウト作成に失敗: %v", err)
+		}
+
+		workout, err := workoutService.CreateWorkout(userID, &CreateWorkoutInput{
+			Date: "2026-10-03",
+			Sets: []CreateSetInput{{ExerciseID: 1, SetNumber: 1, Weight: 105, Reps: 5}},
+		})
+		if err != nil {
+			t.Fatalf("ワークアウト作成に失敗: %v", err)
+		}
+		if countRecords(workout.NewPersonalRecords, model.RecordTypeMaxWeight) != 1 {
+			t.Errorf("最大重量の更新が返されていない: %+v", workout.NewPersonalRecords)
+		}
+	})
+
+	t.Run("削除すると後続のワークアウトの記録を再計算する", func(t *testing.T) {
+		workoutService, recordRepo := newService()
+		userID := uint64(1)
+
+		first, _ := workoutService.CreateWorkout(userID, &CreateWorkoutInput{
+			Date: "2026-10-01",
+			Sets: []CreateSetInput{{ExerciseID: 1, SetNumber: 1, Weight: 110, Reps: 5}},
+		})
+		second, _ := workoutService.CreateWorkout(userID, &CreateWorkoutInput{
+			Date: "2026-10-03",
+			Sets: []CreateSetInput{{ExerciseID: 1, SetNumber: 1, Weight: 100, Reps: 5}},
+		})
+		if countRecords(second.NewPersonalRecords, model.RecordTypeMaxWeight) != 0 {
+			t.Fatalf("2回目は最大重量の更新ではないはず")
+		}
+
+		if err := workoutService.DeleteWorkout(userID, first.ID); err != nil {
+			t.Fatalf("ワークアウト削除に失敗: %v", err)
+		}
+
+		records, _ := recordRepo.FindByWorkoutID(second.ID)
+		if countRecords(records, model.RecordTypeMaxWeight) != 1 {
+			t.Errorf("削除後は2回目が最大重量の記録になるべき: %+v", records)
+		}
+	})
+	t.Run("編集前から達成していた記録は返さない", func(t *testing.T) {
+		workoutService, _ := newService()
+		userID := uint64(1)
+
+		workout, _ := workoutService.CreateWorkout(userID, &CreateWorkoutInput{
+			Date: "2026-10-01",
+			Sets: []CreateSetInput{{ExerciseID: 1, SetNumber: 1, Weight: 100, Reps: 5}},
+		})
+		if countRecords(workout.NewPersonalRecords, model.RecordTypeMaxWeight) != 1 {
+			t.Fatalf("作成時は最大重量の更新を返すはず: %+v", workout.NewPersonalRecords)
+		}
+
+		updated, err := workoutService.UpdateWorkout(userID, workout.ID, &UpdateWorkoutInput{
+			Sets: []UpdateSetInput{{ExerciseID: 1, SetNumber: 1, Weight: 100, Reps: 5}},
+		})
+		if err != nil {
+			t.Fatalf("ワークアウト更新に失敗: %v", err)
+		}
+		if len(updated.NewPersonalRecords) != 0 {
+			t.Errorf("セットを変えていないため新しい記録はないはず: %+v", updated.NewPersonalRecords)
+		}
+
+		updated, _ = workoutService.UpdateWorkout(userID, workout.ID, &UpdateWorkoutInput{
+			Sets: []UpdateSetInput{{ExerciseID: 1, SetNumber: 1, Weight: 102.5, Reps: 5}},
+		})
+		if countRecords(updated.NewPersonalRecords, model.RecordTypeMaxWeight) != 1 {
+			t.Errorf("重量を変えた最大重量の記録を返すはず: %+v", updated.NewPersonalRecords)
+		}
+	})
+}
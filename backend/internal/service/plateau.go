@@ -0,0 +1,194 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/training-memo/backend/internal/repository"
+)
+
+const (
+	defaultPlateauSessions = 4
+	defaultPlateauWeeks    = 4
+	// plateauActiveDays は停滞の判定対象とする種目の最終実施日の範囲（やめた種目は対象外）
+	plateauActiveDays = 28
+)
+
+// PlateauRule は停滞した種目に提案する対処法の条件
+// 条件はすべて満たした場合に一致する（0 や nil の条件は判定しない）
+type PlateauRule struct {
+	Intervention       string   `json:"intervention"`
+	Description        string   `json:"description"`
+	MinSessionsSincePR int      `json:"min_sessions_since_pr"`
+	MinWeeksSincePR    int      `json:"min_weeks_since_pr"`
+	MaxSlope           *float64 `json:"max_slope"` // 推定1RMの傾き（kg/週）の上限
+}
+
+func (r PlateauRule) matches(lift StalledLift) bool {
+	if lift.SessionsSincePR < r.MinSessionsSincePR {
+		return false
+	}
+	if lift.WeeksSincePR < r.MinWeeksSincePR {
+		return false
+	}
+	if r.MaxSlope != nil && lift.Slope > *r.MaxSlope {
+		return false
+	}
+	return true
+}
+
+func slopeAtMost(v float64) *float64 { return &v }
+
+// DefaultPlateauRules は PLATEAU_RULES_FILE を指定しない場合の対処法の一覧
+var DefaultPlateauRules = []PlateauRule{
+	{
+		Intervention: "deload",
+		Description:  "推定1RMが下がり続けています。重量を10%ほど落として1〜2週間行い、疲労を抜きましょう",
+		MaxSlope:     slopeAtMost(-0.5),
+	},
+	{
+		Intervention:       "rep_range_change",
+		Description:        "同じレップ範囲で伸び悩んでいます。5レップ中心なら8〜10レップなど、レップ範囲を変えてみましょう",
+		MinSessionsSincePR: 6,
+	},
+	{
+		Intervention:    "variation",
+		Description:     "長期間記録が更新されていません。バリエーション種目に切り替えて刺激を変えてみましょう",
+		MinWeeksSincePR: 8,
+	},
+	{
+		Intervention: "microload",
+		Description:  "1.25kgなど小さい刻みで重量を上げてみましょう",
+	},
+}
+
+// LoadPlateauRules は JSON ファイルから対処法の一覧を読み込む（path が空の場合は既定の一覧）
+func LoadPlateauRules(path string) ([]PlateauRule, error) {
+	if path == "" {
+		return DefaultPlateauRules, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading plateau rules: %w", err)
+	}
+	var rules []PlateauRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("parsing plateau rules: %w", err)
+	}
+	return rules, nil
+}
+
+// StalledLift は停滞している種目
+type StalledLift struct {
+	ExerciseID      uint64        `json:"exercise_id"`
+	ExerciseName    string        `json:"exercise_name"`
+	MuscleGroup     string        `json:"muscle_group"`
+	BestOneRepMax   float64       `json:"best_one_rep_max"`
+	LastPRDate      time.Time     `json:"last_pr_date"`
+	LastSessionDate time.Time     `json:"last_session_date"`
+	SessionsSincePR int           `json:"sessions_since_pr"`
+	WeeksSincePR    int           `json:"weeks_since_pr"`
+	Slope           float64       `json:"slope"` // 最後の自己ベスト以降の推定1RMの傾き（kg/週）
+	Suggestions     []PlateauRule `json:"suggestions"`
+}
+
+// GetPlateaus は推定1RMが sessions 回または weeks 週のあいだ更新されていない種目を返す
+func (s *StatsService) GetPlateaus(userID uint64, sessions, weeks, formula string) ([]StalledLift, error) {
+	minSessions, err := plateauThreshold(sessions, defaultPlateauSessions)
+	if err != nil {
+		return nil, err
+	}
+	minWeeks, err := plateauThreshold(weeks, defaultPlateauWeeks)
+	if err != nil {
+		return nil, err
+	}
+	f, err := ParseOneRepMaxFormula(formula)
+	if err != nil {
+		return nil, err
+	}
+
+	activeSince := toDate(time.Now()).AddDate(0, 0, -plateauActiveDays)
+	history, err := s.workoutRepo.GetActiveOneRepMaxHistories(userID, f, activeSince)
+	if err != nil {
+		return nil, err
+	}
+
+	lifts := []StalledLift{}
+	for start := 0; start < len(history); {
+		end := start
+		progress := []repository.ExerciseProgress{}
+		for end < len(history) && history[end].ExerciseID == history[start].ExerciseID {
+			progress = append(progress, repository.ExerciseProgress{Date: history[end].Date, EstimatedOneRepMax: history[end].EstimatedOneRepMax})
+			end++
+		}
+		exercise := history[start]
+		start = end
+
+		lift, stalled := detectPlateau(progress, minSessions, minWeeks)
+		if !stalled {
+			continue
+		}
+		lift.ExerciseID = exercise.ExerciseID
+		lift.ExerciseName = exercise.ExerciseName
+		lift.MuscleGroup = exercise.MuscleGroup
+		for _, rule := range s.plateauRules {
+			if rule.matches(lift) {
+				lift.Suggestions = append(lift.Suggestions, rule)
+			}
+		}
+		if lift.Suggestions == nil {
+			lift.Suggestions = []PlateauRule{}
+		}
+		lifts = append(lifts, lift)
+	}
+	return lifts, nil
+}
+
+func plateauThreshold(v string, def int) (int, error) {
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidPlateauThreshold, v)
+	}
+	return n, nil
+}
+
+// detectPlateau は日付順の推移から最後に推定1RMを更新した日以降の停滞を判定する
+func detectPlateau(progress []repository.ExerciseProgress, minSessions, minWeeks int) (StalledLift, bool) {
+	var lift StalledLift
+	if len(progress) == 0 {
+		return lift, false
+	}
+
+	lastPR := 0
+	for i, p := range progress {
+		if p.EstimatedOneRepMax > progress[lastPR].EstimatedOneRepMax {
+			lastPR = i
+		}
+	}
+
+	last := progress[len(progress)-1]
+	lift.BestOneRepMax = progress[lastPR].EstimatedOneRepMax
+	lift.LastPRDate = progress[lastPR].Date
+	lift.LastSessionDate = last.Date
+	lift.SessionsSincePR = len(progress) - 1 - lastPR
+	lift.WeeksSincePR = int(last.Date.Sub(lift.LastPRDate).Hours() / 24 / 7)
+	lift.Slope = oneRepMaxSlope(progress[lastPR:])
+
+	stalled := lift.SessionsSincePR >= minSessions || lift.WeeksSincePR >= minWeeks
+	return lift, stalled
+}
+
+// oneRepMaxSlope は推定1RMの推移を直線に当てはめた傾き（kg/週）を返す
+func oneRepMaxSlope(progress []repository.ExerciseProgress) float64 {
+	points := make([]trendPoint, len(progress))
+	for i, p := range progress {
+		points[i] = trendPoint{Date: p.Date, Value: p.EstimatedOneRepMax}
+	}
+	return linearSlope(points) * 7
+}
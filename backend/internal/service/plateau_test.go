@@ -0,0 +1,139 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/repository"
+)
+
+func progressOf(points map[string]float64, days ...string) []repository.ExerciseProgress {
+	progress := make([]repository.ExerciseProgress, 0, len(days))
+	for _, d := range days {
+		progress = append(progress, repository.ExerciseProgress{Date: date(d), EstimatedOneRepMax: points[d]})
+	}
+	return progress
+}
+
+func TestDetectPlateau(t *testing.T) {
+	days := []string{"2026-09-01", "2026-09-04", "2026-09-08", "2026-09-11", "2026-09-15", "2026-09-18"}
+
+	t.Run("規定回数のあいだ更新がなければ停滞とする", func(t *testing.T) {
+		progress := progressOf(map[string]float64{
+			"2026-09-01": 100, "2026-09-04": 105, "2026-09-08": 104,
+			"2026-09-11": 105, "2026-09-15": 103, "2026-09-18": 104,
+		}, days...)
+
+		lift, stalled := detectPlateau(progress, 4, 8)
+		if !stalled {
+			t.Fatal("停滞として検出されるべき")
+		}
+		if lift.SessionsSincePR != 4 {
+			t.Errorf("期待される自己ベスト以降の回数: 4, 実際: %d", lift.SessionsSincePR)
+		}
+		if lift.BestOneRepMax != 105 || !lift.LastPRDate.Equal(date("2026-09-04")) {
+			t.Errorf("期待される自己ベスト: 105（2026-09-04）, 実際: %v（%v）", lift.BestOneRepMax, lift.LastPRDate)
+		}
+		if lift.Slope >= 0 {
+			t.Errorf("傾きは負を期待, 実際: %v", lift.Slope)
+		}
+	})
+
+	t.Run("記録が伸びていれば停滞としない", func(t *testing.T) {
+		progress := progressOf(map[string]float64{
+			"2026-09-01": 100, "2026-09-04": 101, "2026-09-08": 102,
+			"2026-09-11": 103, "2026-09-15": 104, "2026-09-18": 105,
+		}, days...)
+
+		if _, stalled := detectPlateau(progress, 4, 4); stalled {
+			t.Error("停滞として検出されるべきではない")
+		}
+	})
+
+	t.Run("規定週数のあいだ更新がなければ停滞とする", func(t *testing.T) {
+		progress := progressOf(map[string]float64{"2026-08-01": 100, "2026-09-18": 95}, "2026-08-01", "2026-09-18")
+
+		lift, stalled := detectPlateau(progress, 4, 4)
+		if !stalled || lift.WeeksSincePR != 6 {
+			t.Errorf("6週の停滞を期待, 実際: %v（%d週）", stalled, lift.WeeksSincePR)
+		}
+	})
+}
+
+func TestPlateauRule_Matches(t *testing.T) {
+	t.Run("条件をすべて満たす対処法を提案する", func(t *testing.T) {
+		lift := StalledLift{SessionsSincePR: 6, WeeksSincePR: 3, Slope: -1}
+
+		var got []string
+		for _, rule := range DefaultPlateauRules {
+			if rule.matches(lift) {
+				got = append(got, rule.Intervention)
+			}
+		}
+		want := []string{"deload", "rep_range_change", "microload"}
+		if len(got) != len(want) {
+			t.Fatalf("期待される提案: %v, 実際: %v", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("期待される提案: %v, 実際: %v", want, got)
+			}
+		}
+	})
+}
+
+func TestLoadPlateauRules(t *testing.T) {
+	t.Run("未指定の場合は既定の一覧を使う", func(t *testing.T) {
+		rules, err := LoadPlateauRules("")
+		if err != nil {
+			t.Fatalf("読み込みに失敗: %v", err)
+		}
+		if len(rules) != len(DefaultPlateauRules) {
+			t.Errorf("期待される件数: %d, 実際: %d", len(DefaultPlateauRules), len(rules))
+		}
+	})
+}
+
+func TestGetPlateaus(t *testing.T) {
+	t.Run("最近行った種目の推移から停滞を判定する", func(t *testing.T) {
+		workoutRepo := NewMockWorkoutRepository()
+		today := toDate(time.Now())
+		record := func(daysAgo int, exerciseID uint64, weight float64) {
+			workoutRepo.CreateWithSets(
+				&model.Workout{UserID: 1, Date: today.AddDate(0, 0, -daysAgo)},
+				[]*model.WorkoutSet{{ExerciseID: exerciseID, SetNumber: 1, Weight: weight, Reps: 1}},
+				nil,
+			)
+		}
+		// 種目1は20日前の自己ベストから4回更新がない
+		for i, weight := range []float64{100, 105, 104, 103, 104, 102} {
+			record(25-i*5, 1, weight)
+		}
+		// 種目2は同じように停滞しているが、最後に行ったのが60日前
+		for i, weight := range []float64{100, 105, 104, 103, 104, 102} {
+			record(90-i*5, 2, weight)
+		}
+
+		lifts, err := NewStatsService(workoutRepo, DefaultPlateauRules).GetPlateaus(1, "", "", "")
+		if err != nil {
+			t.Fatalf("停滞の判定に失敗: %v", err)
+		}
+		if len(lifts) != 1 || lifts[0].ExerciseID != 1 {
+			t.Fatalf("種目1のみの停滞を期待, 実際: %+v", lifts)
+		}
+		if lifts[0].SessionsSincePR != 4 || lifts[0].BestOneRepMax != 105 {
+			t.Errorf("期待: 105kg以降4回, 実際: %vkg以降%d回", lifts[0].BestOneRepMax, lifts[0].SessionsSincePR)
+		}
+	})
+}
+
+func TestGetPlateaus_InvalidThreshold(t *testing.T) {
+	statsService := NewStatsService(NewMockWorkoutRepository(), DefaultPlateauRules)
+
+	_, err := statsService.GetPlateaus(1, "0", "", "")
+	if !errors.Is(err, ErrInvalidPlateauThreshold) {
+		t.Errorf("ErrInvalidPlateauThreshold を期待, 実際: %v", err)
+	}
+}
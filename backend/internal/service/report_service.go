@@ -0,0 +1,251 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/repository"
+)
+
+// ReportKind はレポートの期間の種類
+type ReportKind string
+
+const (
+	ReportKindWeekly  ReportKind = "weekly"
+	ReportKindMonthly ReportKind = "monthly"
+)
+
+// ReportPeriod はレポートの対象期間（From・To は両端を含む）
+type ReportPeriod struct {
+	Kind  ReportKind
+	Label string
+	From  time.Time
+	To    time.Time
+}
+
+// WeeklyPeriod は ISO 8601 の週（例: 2026-W41）を月曜〜日曜の期間に変換する
+func WeeklyPeriod(week string) (ReportPeriod, error) {
+	var year, num int
+	if _, err := fmt.Sscanf(week, "%d-W%d", &year, &num); err != nil || len(week) != len("2006-W01") {
+		return ReportPeriod{}, fmt.Errorf("%w: %s", ErrInvalidReportPeriod, week)
+	}
+
+	// 1月4日を含む週がその年の第1週
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	from := weekStart(jan4).AddDate(0, 0, (num-1)*7)
+
+	if y, w := from.ISOWeek(); num < 1 || y != year || w != num {
+		return ReportPeriod{}, fmt.Errorf("%w: %s", ErrInvalidReportPeriod, week)
+	}
+
+	return ReportPeriod{
+		Kind:  ReportKindWeekly,
+		Label: week,
+		From:  from,
+		To:    from.AddDate(0, 0, 6),
+	}, nil
+}
+
+// MonthlyPeriod は月（例: 2026-10）を月初〜月末の期間に変換する
+func MonthlyPeriod(month string) (ReportPeriod, error) {
+	from, err := time.Parse("2006-01", month)
+	if err != nil {
+		return ReportPeriod{}, fmt.Errorf("%w: %s", ErrInvalidReportPeriod, month)
+	}
+	return ReportPeriod{
+		Kind:  ReportKindMonthly,
+		Label: month,
+		From:  from,
+		To:    from.AddDate(0, 1, -1),
+	}, nil
+}
+
+// Previous は直前の同じ長さの期間を返す
+func (p ReportPeriod) Previous() ReportPeriod {
+	if p.Kind == ReportKindMonthly {
+		from := p.From.AddDate(0, -1, 0)
+		return ReportPeriod{
+			Kind:  p.Kind,
+			Label: from.Format("2006-01"),
+			From:  from,
+			To:    p.From.AddDate(0, 0, -1),
+		}
+	}
+	from := p.From.AddDate(0, 0, -7)
+	year, week := from.ISOWeek()
+	return ReportPeriod{
+		Kind:  p.Kind,
+		Label: fmt.Sprintf("%04d-W%02d", year, week),
+		From:  from,
+		To:    p.To.AddDate(0, 0, -7),
+	}
+}
+
+func (p ReportPeriod) filter() repository.StatsFilter {
+	from, to := p.From, p.To
+	return repository.StatsFilter{From: &from, To: &to}
+}
+
+// Delta は今期と前期の比較（前期が0の場合 ChangePercent は nil）
+type Delta struct {
+	Current       float64  `json:"current"`
+	Previous      float64  `json:"previous"`
+	Change        float64  `json:"change"`
+	ChangePercent *float64 `json:"change_percent"`
+}
+
+func newDelta(current, previous float64) Delta {
+	d := Delta{
+		Current:  current,
+		Previous: previous,
+		Change:   current - previous,
+	}
+	if previous != 0 {
+		percent := (current - previous) / previous * 100
+		d.ChangePercent = &percent
+	}
+	return d
+}
+
+// BodyWeightChange は期間内の最初と最後の体重記録の差
+type BodyWeightChange struct {
+	StartDate   time.Time `json:"start_date"`
+	StartWeight float64   `json:"start_weight"`
+	EndDate     time.Time `json:"end_date"`
+	EndWeight   float64   `json:"end_weight"`
+	Change      float64   `json:"change"`
+}
+
+// ReportComparison は前期との比較
+type ReportComparison struct {
+	Period   string `json:"period"`
+	Sessions Delta  `json:"sessions"`
+	Sets     Delta  `json:"sets"`
+	Reps     Delta  `json:"reps"`
+	Volume   Delta  `json:"volume"`
+}
+
+// TrainingReport は週間・月間レポート
+type TrainingReport struct {
+	Kind               ReportKind                   `json:"kind"`
+	Period             string                       `json:"period"`
+	From               time.Time                    `json:"from"`
+	To                 time.Time                    `json:"to"`
+	Totals             repository.TrainingTotals    `json:"totals"`
+	MuscleGroups       []repository.MuscleGroupStat `json:"muscle_groups"`
+	NewPersonalRecords []model.PersonalRecord       `json:"new_personal_records"`
+	BodyWeight         *BodyWeightChange            `json:"body_weight"`
+	Comparison         ReportComparison             `json:"comparison"`
+}
+
+// ReportService はトレーニングレポートを組み立てる
+// Build は API 以外（メール配信など）からも利用できる
+type ReportService struct {
+	workoutRepo    WorkoutRepository
+	recordRepo     PersonalRecordRepository
+	bodyWeightRepo BodyWeightRepository
+}
+
+func NewReportService(workoutRepo WorkoutRepository, recordRepo PersonalRecordRepository, bodyWeightRepo BodyWeightRepository) *ReportService {
+	return &ReportService{
+		workoutRepo:    workoutRepo,
+		recordRepo:     recordRepo,
+		bodyWeightRepo: bodyWeightRepo,
+	}
+}
+
+// GetWeeklyReport は週間レポートを返す（week が空の場合は今週）
+func (s *ReportService) GetWeeklyReport(userID uint64, week string) (*TrainingReport, error) {
+	if week == "" {
+		year, w := time.Now().ISOWeek()
+		week = fmt.Sprintf("%04d-W%02d", year, w)
+	}
+	period, err := WeeklyPeriod(week)
+	if err != nil {
+		return nil, err
+	}
+	return s.Build(userID, period)
+}
+
+// GetMonthlyReport は月間レポートを返す（month が空の場合は今月）
+func (s *ReportService) GetMonthlyReport(userID uint64, month string) (*TrainingReport, error) {
+	if month == "" {
+		month = time.Now().Format("2006-01")
+	}
+	period, err := MonthlyPeriod(month)
+	if err != nil {
+		return nil, err
+	}
+	return s.Build(userID, period)
+}
+
+// Build は指定期間のレポートを組み立てる
+func (s *ReportService) Build(userID uint64, period ReportPeriod) (*TrainingReport, error) {
+	totals, err := s.workoutRepo.GetTrainingTotals(userID, period.filter())
+	if err != nil {
+		return nil, fmt.Errorf("getting training totals: %w", err)
+	}
+
+	previous := period.Previous()
+	previousTotals, err := s.workoutRepo.GetTrainingTotals(userID, previous.filter())
+	if err != nil {
+		return nil, fmt.Errorf("getting previous training totals: %w", err)
+	}
+
+	muscleGroups, err := s.workoutRepo.GetMuscleGroupStats(userID, period.filter())
+	if err != nil {
+		return nil, fmt.Errorf("getting muscle group stats: %w", err)
+	}
+
+	records, err := s.recordRepo.FindByUserIDAndDateRange(userID, period.From, period.To)
+	if err != nil {
+		return nil, fmt.Errorf("finding personal records: %w", err)
+	}
+
+	bodyWeights, err := s.bodyWeightRepo.FindByUserIDAndDateRange(userID, period.From, period.To)
+	if err != nil {
+		return nil, fmt.Errorf("finding body weight records: %w", err)
+	}
+
+	report := &TrainingReport{
+		Kind:               period.Kind,
+		Period:             period.Label,
+		From:               period.From,
+		To:                 period.To,
+		Totals:             *totals,
+		MuscleGroups:       muscleGroups,
+		NewPersonalRecords: records,
+		BodyWeight:         bodyWeightChange(bodyWeights),
+		Comparison: ReportComparison{
+			Period:   previous.Label,
+			Sessions: newDelta(float64(totals.Sessions), float64(previousTotals.Sessions)),
+			Sets:     newDelta(float64(totals.Sets), float64(previousTotals.Sets)),
+			Reps:     newDelta(float64(totals.Reps), float64(previousTotals.Reps)),
+			Volume:   newDelta(totals.Volume, previousTotals.Volume),
+		},
+	}
+	if report.MuscleGroups == nil {
+		report.MuscleGroups = []repository.MuscleGroupStat{}
+	}
+	if report.NewPersonalRecords == nil {
+		report.NewPersonalRecords = []model.PersonalRecord{}
+	}
+
+	return report, nil
+}
+
+// bodyWeightChange は日付順の体重記録から変化量を求める（記録が2件未満の場合は nil）
+func bodyWeightChange(records []model.BodyWeight) *BodyWeightChange {
+	if len(records) < 2 {
+		return nil
+	}
+	first, last := records[0], records[len(records)-1]
+	return &BodyWeightChange{
+		StartDate:   first.Date,
+		StartWeight: first.Weight,
+		EndDate:     last.Date,
+		EndWeight:   last.Weight,
+		Change:      last.Weight - first.Weight,
+	}
+}
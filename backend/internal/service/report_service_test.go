@@ -0,0 +1,77 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWeeklyPeriod(t *testing.T) {
+	t.Run("ISO週を月曜〜日曜に変換する", func(t *testing.T) {
+		period, err := WeeklyPeriod("2026-W41")
+		if err != nil {
+			t.Fatalf("変換に失敗: %v", err)
+		}
+		if got := period.From.Format("2006-01-02"); got != "2026-10-05" {
+			t.Errorf("期待される開始日: 2026-10-05, 実際: %s", got)
+		}
+		if got := period.To.Format("2006-01-02"); got != "2026-10-11" {
+			t.Errorf("期待される終了日: 2026-10-11, 実際: %s", got)
+		}
+	})
+
+	t.Run("年をまたぐ第1週", func(t *testing.T) {
+		period, err := WeeklyPeriod("2026-W01")
+		if err != nil {
+			t.Fatalf("変換に失敗: %v", err)
+		}
+		if got := period.From.Format("2006-01-02"); got != "2025-12-29" {
+			t.Errorf("期待される開始日: 2025-12-29, 実際: %s", got)
+		}
+	})
+
+	t.Run("前週は年をまたいでも週番号を引き継ぐ", func(t *testing.T) {
+		period, _ := WeeklyPeriod("2026-W01")
+		if got := period.Previous().Label; got != "2025-W52" {
+			t.Errorf("期待される前週: 2025-W52, 実際: %s", got)
+		}
+	})
+
+	t.Run("存在しない週はエラー", func(t *testing.T) {
+		for _, week := range []string{"2026-W54", "2026-W00", "2026-41", "2026-W4"} {
+			if _, err := WeeklyPeriod(week); !errors.Is(err, ErrInvalidReportPeriod) {
+				t.Errorf("%s: ErrInvalidReportPeriod が返るべき, 実際: %v", week, err)
+			}
+		}
+	})
+}
+
+func TestMonthlyPeriod(t *testing.T) {
+	t.Run("月初〜月末に変換し前月と比較する", func(t *testing.T) {
+		period, err := MonthlyPeriod("2026-03")
+		if err != nil {
+			t.Fatalf("変換に失敗: %v", err)
+		}
+		if got := period.To.Format("2006-01-02"); got != "2026-03-31" {
+			t.Errorf("期待される終了日: 2026-03-31, 実際: %s", got)
+		}
+		previous := period.Previous()
+		if previous.Label != "2026-02" || previous.To.Format("2006-01-02") != "2026-02-28" {
+			t.Errorf("期待される前月: 2026-02（〜02-28）, 実際: %s（〜%s）", previous.Label, previous.To.Format("2006-01-02"))
+		}
+	})
+}
+
+func TestNewDelta(t *testing.T) {
+	t.Run("増減と増減率を求める", func(t *testing.T) {
+		d := newDelta(1200, 1000)
+		if d.Change != 200 || d.ChangePercent == nil || *d.ChangePercent != 20 {
+			t.Errorf("期待される増減: +200 (20%%), 実際: %+v", d)
+		}
+	})
+
+	t.Run("前期が0の場合は増減率なし", func(t *testing.T) {
+		if d := newDelta(3, 0); d.ChangePercent != nil {
+			t.Errorf("増減率はnilであるべき")
+		}
+	})
+}
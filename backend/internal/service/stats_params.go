@@ -0,0 +1,64 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/repository"
+)
+
+// statsRollUpVariations はバリエーションを親種目にまとめる集計方法
+const statsRollUpVariations = "variations"
+
+// StatsParams は統計エンドポイント共通のクエリパラメータ
+type StatsParams struct {
+	From    string // 集計開始日（YYYY-MM-DD、含む）
+	To      string // 集計終了日（YYYY-MM-DD、含む）
+	Bucket  string // 集計単位（day / week / month）
+	Formula string // 推定1RMの算出式
+	RollUp  string // variations の場合、種目ごとの統計でバリエーションを親種目にまとめる
+}
+
+// filter はパラメータを検証してリポジトリの集計条件に変換する
+func (p StatsParams) filter() (repository.StatsFilter, error) {
+	var filter repository.StatsFilter
+
+	if p.From != "" {
+		from, err := time.Parse("2006-01-02", p.From)
+		if err != nil {
+			return filter, fmt.Errorf("%w: %s", ErrInvalidDateFormat, p.From)
+		}
+		filter.From = &from
+	}
+	if p.To != "" {
+		to, err := time.Parse("2006-01-02", p.To)
+		if err != nil {
+			return filter, fmt.Errorf("%w: %s", ErrInvalidDateFormat, p.To)
+		}
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return filter, fmt.Errorf("%w: %s > %s", ErrInvalidDateRange, p.From, p.To)
+	}
+
+	filter.Bucket = repository.StatsBucket(p.Bucket)
+	if !filter.Bucket.IsValid() {
+		return filter, fmt.Errorf("%w: %s", ErrInvalidBucket, p.Bucket)
+	}
+
+	switch p.RollUp {
+	case "":
+	case statsRollUpVariations:
+		filter.RollUpVariations = true
+	default:
+		return filter, fmt.Errorf("%w: %s", ErrInvalidRollUp, p.RollUp)
+	}
+
+	return filter, nil
+}
+
+// formula は推定1RMの算出式を解釈する
+func (p StatsParams) formula() (model.OneRepMaxFormula, error) {
+	return ParseOneRepMaxFormula(p.Formula)
+}
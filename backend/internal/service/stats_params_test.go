@@ -0,0 +1,50 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/training-memo/backend/internal/repository"
+)
+
+func TestStatsParams_Filter(t *testing.T) {
+	t.Run("期間と集計単位を変換できる", func(t *testing.T) {
+		filter, err := StatsParams{From: "2026-07-01", To: "2026-09-30", Bucket: "week"}.filter()
+		if err != nil {
+			t.Fatalf("変換に失敗: %v", err)
+		}
+		if filter.From == nil || filter.From.Format("2006-01-02") != "2026-07-01" {
+			t.Errorf("期待される開始日: 2026-07-01, 実際: %v", filter.From)
+		}
+		if filter.Bucket != repository.StatsBucketWeek {
+			t.Errorf("期待される集計単位: week, 実際: %s", filter.Bucket)
+		}
+	})
+
+	t.Run("未指定の場合は全期間", func(t *testing.T) {
+		filter, err := StatsParams{}.filter()
+		if err != nil {
+			t.Fatalf("変換に失敗: %v", err)
+		}
+		if filter.From != nil || filter.To != nil || filter.Bucket != repository.StatsBucketNone {
+			t.Errorf("全期間・集計単位なしであるべき: %+v", filter)
+		}
+	})
+
+	t.Run("不正な値はエラー", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			params StatsParams
+			want   error
+		}{
+			{"日付の形式", StatsParams{From: "2026/07/01"}, ErrInvalidDateFormat},
+			{"開始日が終了日より後", StatsParams{From: "2026-10-01", To: "2026-09-01"}, ErrInvalidDateRange},
+			{"集計単位", StatsParams{Bucket: "year"}, ErrInvalidBucket},
+		}
+		for _, tt := range tests {
+			if _, err := tt.params.filter(); !errors.Is(err, tt.want) {
+				t.Errorf("%s: 期待されるエラー: %v, 実際: %v", tt.name, tt.want, err)
+			}
+		}
+	})
+}
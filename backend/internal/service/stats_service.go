@@ -0,0 +1,192 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/training-memo/backend/internal/repository"
+)
+
+const (
+	defaultWeeklyTarget = 3
+	// heatmapDays はヒートマップに含める日数（今日を含む）
+	heatmapDays = 365
+	// heatmapLevels はヒートマップの濃さの段階数（0は未実施）
+	heatmapLevels = 4
+)
+
+// HeatmapIntensity はヒートマップの濃さに使う指標
+type HeatmapIntensity string
+
+const (
+	HeatmapIntensityVolume HeatmapIntensity = "volume"
+	HeatmapIntensitySets   HeatmapIntensity = "sets"
+)
+
+// WeeklyConsistency は週ごとのワークアウト数（週は月曜始まり）
+type WeeklyConsistency struct {
+	WeekStart time.Time `json:"week_start"`
+	Sessions  int       `json:"sessions"`
+	MetTarget bool      `json:"met_target"`
+}
+
+// HeatmapDay はヒートマップの1日分（実施日のみ含む）
+type HeatmapDay struct {
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+	Level int       `json:"level"`
+}
+
+// ConsistencyStats は継続状況の統計
+// 連続記録は週単位で、目標回数を満たした週が続いた数
+type ConsistencyStats struct {
+	WeeklyTarget  int                 `json:"weekly_target"`
+	CurrentStreak int                 `json:"current_streak"`
+	LongestStreak int                 `json:"longest_streak"`
+	Weeks         []WeeklyConsistency `json:"weeks"`
+	Intensity     HeatmapIntensity    `json:"intensity"`
+	Heatmap       []HeatmapDay        `json:"heatmap"`
+}
+
+// StatsService はワークアウト記録から継続状況・負荷・停滞などの分析を行う
+type StatsService struct {
+	workoutRepo  WorkoutRepository
+	plateauRules []PlateauRule
+}
+
+func NewStatsService(workoutRepo WorkoutRepository, plateauRules []PlateauRule) *StatsService {
+	return &StatsService{
+		workoutRepo:  workoutRepo,
+		plateauRules: plateauRules,
+	}
+}
+
+// GetConsistency は週の目標回数に対する連続記録・週ごとの回数・1年分のヒートマップを返す
+func (s *StatsService) GetConsistency(userID uint64, target, intensity string) (*ConsistencyStats, error) {
+	weeklyTarget := defaultWeeklyTarget
+	if target != "" {
+		t, err := strconv.Atoi(target)
+		if err != nil || t < 1 || t > 7 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidWeeklyTarget, target)
+		}
+		weeklyTarget = t
+	}
+
+	metric := HeatmapIntensity(intensity)
+	if metric == "" {
+		metric = HeatmapIntensityVolume
+	}
+	if metric != HeatmapIntensityVolume && metric != HeatmapIntensitySets {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIntensity, intensity)
+	}
+
+	activity, err := s.workoutRepo.GetDailyActivity(userID, repository.StatsFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildConsistency(activity, weeklyTarget, metric, time.Now()), nil
+}
+
+// buildConsistency は日付順の日別実績から継続状況を組み立てる
+func buildConsistency(activity []repository.DailyActivity, weeklyTarget int, metric HeatmapIntensity, now time.Time) *ConsistencyStats {
+	today := toDate(now)
+	stats := &ConsistencyStats{
+		WeeklyTarget: weeklyTarget,
+		Weeks:        []WeeklyConsistency{},
+		Intensity:    metric,
+		Heatmap:      []HeatmapDay{},
+	}
+	if len(activity) == 0 {
+		return stats
+	}
+
+	// 週ごとの回数（最初の実施週から今週まで、実施のない週も含める）
+	sessionsByWeek := make(map[time.Time]int)
+	for _, day := range activity {
+		sessionsByWeek[weekStart(toDate(day.Date))] += day.Sessions
+	}
+	currentWeek := weekStart(today)
+	run := 0
+	for week := weekStart(toDate(activity[0].Date)); !week.After(currentWeek); week = week.AddDate(0, 0, 7) {
+		sessions := sessionsByWeek[week]
+		met := sessions >= weeklyTarget
+		stats.Weeks = append(stats.Weeks, WeeklyConsistency{WeekStart: week, Sessions: sessions, MetTarget: met})
+
+		if met {
+			run++
+			if run > stats.LongestStreak {
+				stats.LongestStreak = run
+			}
+		} else if !week.Equal(currentWeek) {
+			// 今週は途中のため、未達でも連続記録は途切れない
+			run = 0
+		}
+	}
+	stats.CurrentStreak = run
+
+	// 直近1年分のヒートマップ
+	from := today.AddDate(0, 0, -(heatmapDays - 1))
+	var maxValue float64
+	for _, day := range activity {
+		date := toDate(day.Date)
+		if date.Before(from) || date.After(today) {
+			continue
+		}
+		value := day.Volume
+		if metric == HeatmapIntensitySets {
+			value = float64(day.Sets)
+		}
+		stats.Heatmap = append(stats.Heatmap, HeatmapDay{Date: date, Value: value})
+		maxValue = math.Max(maxValue, value)
+	}
+	for i := range stats.Heatmap {
+		level := 1
+		if maxValue > 0 {
+			level = int(math.Ceil(stats.Heatmap[i].Value / maxValue * heatmapLevels))
+		}
+		stats.Heatmap[i].Level = max(level, 1)
+	}
+
+	return stats
+}
+
+// toDate は時刻を切り捨てた日付を返す（DBの date 型と比較できるよう UTC にそろえる）
+func toDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// weekStart は日付を含む週の月曜日を返す
+func weekStart(date time.Time) time.Time {
+	return date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+}
+
+// trendPoint は傾向を求めるための日付と値の組
+type trendPoint struct {
+	Date  time.Time
+	Value float64
+}
+
+// linearSlope は最小二乗法で直線に当てはめた1日あたりの傾きを返す（2点未満の場合は0）
+func linearSlope(points []trendPoint) float64 {
+	if len(points) < 2 {
+		return 0
+	}
+	start := points[0].Date
+	n := float64(len(points))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, p := range points {
+		x := p.Date.Sub(start).Hours() / 24
+		sumX += x
+		sumY += p.Value
+		sumXY += x * p.Value
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/training-memo/backend/internal/repository"
+)
+
+func date(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// sessionsOn は指定日にそれぞれ1回ずつワークアウトした日別実績を作る
+func sessionsOn(days ...string) []repository.DailyActivity {
+	activity := make([]repository.DailyActivity, 0, len(days))
+	for _, d := range days {
+		activity = append(activity, repository.DailyActivity{Date: date(d), Sessions: 1, Sets: 5, Volume: 1000})
+	}
+	return activity
+}
+
+func TestBuildConsistency(t *testing.T) {
+	// 2026-10-14 は水曜日（今週は 10-12 〜 10-18）
+	now := date("2026-10-14")
+
+	t.Run("目標を満たした週が続いた数を連続記録とする", func(t *testing.T) {
+		activity := sessionsOn(
+			"2026-09-21", "2026-09-23", // 9/21週: 2回（未達）
+			"2026-09-28", "2026-09-30", "2026-10-02", // 9/28週: 3回
+			"2026-10-05", "2026-10-06", "2026-10-08", // 10/5週: 3回
+		)
+		stats := buildConsistency(activity, 3, HeatmapIntensityVolume, now)
+
+		if len(stats.Weeks) != 4 {
+			t.Fatalf("期待される週数: 4, 実際: %d", len(stats.Weeks))
+		}
+		if stats.CurrentStreak != 2 {
+			t.Errorf("期待される現在の連続記録: 2, 実際: %d", stats.CurrentStreak)
+		}
+		if stats.LongestStreak != 2 {
+			t.Errorf("期待される最長の連続記録: 2, 実際: %d", stats.LongestStreak)
+		}
+	})
+
+	t.Run("今週が未達でも連続記録は途切れない", func(t *testing.T) {
+		activity := sessionsOn("2026-10-05", "2026-10-06", "2026-10-08", "2026-10-12")
+		stats := buildConsistency(activity, 3, HeatmapIntensityVolume, now)
+
+		if stats.CurrentStreak != 1 {
+			t.Errorf("期待される現在の連続記録: 1, 実際: %d", stats.CurrentStreak)
+		}
+	})
+
+	t.Run("実施のない週で連続記録が途切れる", func(t *testing.T) {
+		activity := sessionsOn("2026-09-21", "2026-09-22", "2026-09-23")
+		stats := buildConsistency(activity, 3, HeatmapIntensityVolume, now)
+
+		if stats.CurrentStreak != 0 {
+			t.Errorf("期待される現在の連続記録: 0, 実際: %d", stats.CurrentStreak)
+		}
+		if stats.LongestStreak != 1 {
+			t.Errorf("期待される最長の連続記録: 1, 実際: %d", stats.LongestStreak)
+		}
+	})
+
+	t.Run("ヒートマップは直近1年の実施日を最大値に対する段階で表す", func(t *testing.T) {
+		activity := []repository.DailyActivity{
+			{Date: date("2025-10-01"), Sessions: 1, Sets: 20, Volume: 8000},
+			{Date: date("2026-10-01"), Sessions: 1, Sets: 5, Volume: 1000},
+			{Date: date("2026-10-05"), Sessions: 1, Sets: 20, Volume: 4000},
+		}
+		stats := buildConsistency(activity, 3, HeatmapIntensityVolume, now)
+
+		if len(stats.Heatmap) != 2 {
+			t.Fatalf("期待される日数: 2, 実際: %d", len(stats.Heatmap))
+		}
+		if stats.Heatmap[0].Level != 1 || stats.Heatmap[1].Level != 4 {
+			t.Errorf("期待される段階: 1, 4, 実際: %d, %d", stats.Heatmap[0].Level, stats.Heatmap[1].Level)
+		}
+
+		bySets := buildConsistency(activity, 3, HeatmapIntensitySets, now)
+		if bySets.Heatmap[0].Value != 5 {
+			t.Errorf("期待される値: 5, 実際: %v", bySets.Heatmap[0].Value)
+		}
+	})
+
+	t.Run("記録がない場合は空の結果を返す", func(t *testing.T) {
+		stats := buildConsistency(nil, 3, HeatmapIntensityVolume, now)
+		if stats.CurrentStreak != 0 || len(stats.Weeks) != 0 || len(stats.Heatmap) != 0 {
+			t.Errorf("空の結果を期待: %+v", stats)
+		}
+	})
+}
+
+func TestGetConsistency_InvalidParams(t *testing.T) {
+	statsService := NewStatsService(NewMockWorkoutRepository(), DefaultPlateauRules)
+
+	t.Run("週の目標回数が範囲外の場合はエラー", func(t *testing.T) {
+		_, err := statsService.GetConsistency(1, "8", "")
+		if !errors.Is(err, ErrInvalidWeeklyTarget) {
+			t.Errorf("ErrInvalidWeeklyTarget を期待, 実際: %v", err)
+		}
+	})
+
+	t.Run("未対応の指標の場合はエラー", func(t *testing.T) {
+		_, err := statsService.GetConsistency(1, "", "reps")
+		if !errors.Is(err, ErrInvalidIntensity) {
+			t.Errorf("ErrInvalidIntensity を期待, 実際: %v", err)
+		}
+	})
+}
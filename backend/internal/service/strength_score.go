@@ -0,0 +1,166 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/training-memo/backend/internal/model"
+)
+
+// Sex は係数・基準表の選択に使う性別
+type Sex string
+
+const (
+	SexMale   Sex = "male"
+	SexFemale Sex = "female"
+)
+
+// BigLift は体重比の強さを評価する主要種目
+type BigLift string
+
+const (
+	BigLiftSquat         BigLift = "squat"
+	BigLiftBenchPress    BigLift = "bench_press"
+	BigLiftDeadlift      BigLift = "deadlift"
+	BigLiftOverheadPress BigLift = "overhead_press"
+)
+
+// bigLiftOf は種目が主要種目であればその主要種目を返す
+// 表示名は名称変更や翻訳で変わるため、プリセット種目の PresetKey で判定する
+func bigLiftOf(exercise model.Exercise) (BigLift, bool) {
+	if exercise.IsCustom || exercise.PresetKey == nil {
+		return "", false
+	}
+	switch lift := BigLift(*exercise.PresetKey); lift {
+	case BigLiftSquat, BigLiftBenchPress, BigLiftDeadlift, BigLiftOverheadPress:
+		return lift, true
+	}
+	return "", false
+}
+
+// DOTS 係数の多項式（体重の0〜4次）と体重の適用範囲
+var dotsCoefficients = map[Sex]struct {
+	poly     [5]float64
+	min, max float64
+}{
+	SexMale:   {[5]float64{-307.75076, 24.0900756, -0.1918759221, 0.0007391293, -0.000001093}, 40, 210},
+	SexFemale: {[5]float64{-57.96288, 13.6175032, -0.1126655495, 0.0005158568, -0.0000010706}, 40, 150},
+}
+
+// Wilks 係数の多項式（体重の0〜5次）と体重の適用範囲
+var wilksCoefficients = map[Sex]struct {
+	poly     [6]float64
+	min, max float64
+}{
+	SexMale:   {[6]float64{-216.0475144, 16.2606339, -0.002388645, -0.00113732, 7.01863e-06, -1.291e-08}, 40, 201.9},
+	SexFemale: {[6]float64{594.31747775582, -27.23842536447, 0.82112226871, -0.00930733913, 4.731582e-05, -9.054e-08}, 26.51, 154.53},
+}
+
+// IPF GL ポイントの係数（ノーギア）
+type ipfGLCoefficient struct{ a, b, c float64 }
+
+var (
+	ipfGLTotalCoefficients = map[Sex]ipfGLCoefficient{
+		SexMale:   {1199.72839, 1025.18162, 0.00921},
+		SexFemale: {610.32796, 1045.59282, 0.03048},
+	}
+	ipfGLBenchCoefficients = map[Sex]ipfGLCoefficient{
+		SexMale:   {320.98041, 281.40258, 0.01008},
+		SexFemale: {142.40398, 442.52671, 0.04724},
+	}
+)
+
+func polynomial(coefficients []float64, x float64) float64 {
+	var sum float64
+	for i, c := range coefficients {
+		sum += c * math.Pow(x, float64(i))
+	}
+	return sum
+}
+
+func clamp(x, min, max float64) float64 {
+	return math.Max(min, math.Min(max, x))
+}
+
+// dotsScore は挙上重量と体重から DOTS スコアを算出する
+func dotsScore(sex Sex, lifted, bodyWeight float64) float64 {
+	c := dotsCoefficients[sex]
+	return lifted * 500 / polynomial(c.poly[:], clamp(bodyWeight, c.min, c.max))
+}
+
+// wilksScore は挙上重量と体重から Wilks スコアを算出する
+func wilksScore(sex Sex, lifted, bodyWeight float64) float64 {
+	c := wilksCoefficients[sex]
+	return lifted * 500 / polynomial(c.poly[:], clamp(bodyWeight, c.min, c.max))
+}
+
+// ipfGLScore は挙上重量と体重から IPF GL ポイントを算出する
+func ipfGLScore(c ipfGLCoefficient, lifted, bodyWeight float64) float64 {
+	return lifted * 100 / (c.a - c.b*math.Exp(-c.c*bodyWeight))
+}
+
+// StrengthLevel は体重比の基準（MinRatio 以上でその段階）
+type StrengthLevel struct {
+	Level    string  `json:"level"`
+	MinRatio float64 `json:"min_ratio"`
+}
+
+// StrengthStandards は性別・種目ごとの基準表（段階は MinRatio の昇順）
+type StrengthStandards map[Sex]map[BigLift][]StrengthLevel
+
+func levels(ratios ...float64) []StrengthLevel {
+	names := []string{"beginner", "novice", "intermediate", "advanced", "elite"}
+	result := make([]StrengthLevel, len(names))
+	for i, name := range names {
+		result[i] = StrengthLevel{Level: name, MinRatio: ratios[i]}
+	}
+	return result
+}
+
+// DefaultStrengthStandards は STRENGTH_STANDARDS_FILE を指定しない場合の基準表（推定1RM ÷ 体重）
+var DefaultStrengthStandards = StrengthStandards{
+	SexMale: {
+		BigLiftSquat:         levels(0, 1.0, 1.5, 2.0, 2.5),
+		BigLiftBenchPress:    levels(0, 0.75, 1.0, 1.5, 2.0),
+		BigLiftDeadlift:      levels(0, 1.25, 1.75, 2.5, 3.0),
+		BigLiftOverheadPress: levels(0, 0.5, 0.65, 0.85, 1.05),
+	},
+	SexFemale: {
+		BigLiftSquat:         levels(0, 0.75, 1.0, 1.5, 1.75),
+		BigLiftBenchPress:    levels(0, 0.5, 0.65, 0.85, 1.1),
+		BigLiftDeadlift:      levels(0, 1.0, 1.25, 1.75, 2.25),
+		BigLiftOverheadPress: levels(0, 0.35, 0.45, 0.6, 0.75),
+	},
+}
+
+// LoadStrengthStandards は JSON ファイルから基準表を読み込む（path が空の場合は既定の基準表）
+func LoadStrengthStandards(path string) (StrengthStandards, error) {
+	if path == "" {
+		return DefaultStrengthStandards, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading strength standards: %w", err)
+	}
+	var standards StrengthStandards
+	if err := json.Unmarshal(data, &standards); err != nil {
+		return nil, fmt.Errorf("parsing strength standards: %w", err)
+	}
+	return standards, nil
+}
+
+// classify は体重比から現在の段階と次の段階を返す（基準がない場合は nil）
+func (s StrengthStandards) classify(sex Sex, lift BigLift, ratio float64) (current, next *StrengthLevel) {
+	table := s[sex][lift]
+	for i := range table {
+		if ratio >= table[i].MinRatio {
+			current = &table[i]
+			continue
+		}
+		next = &table[i]
+		break
+	}
+	return current, next
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/training-memo/backend/internal/model"
+)
+
+func TestStrengthScoreFormulas(t *testing.T) {
+	t.Run("体重100kg・合計800kgの男性のスコア", func(t *testing.T) {
+		tests := []struct {
+			name string
+			got  float64
+			want float64
+		}{
+			{"DOTS", dotsScore(SexMale, 800, 100), 492.41},
+			{"Wilks", wilksScore(SexMale, 800, 100), 486.87},
+			{"IPF GL", ipfGLScore(ipfGLTotalCoefficients[SexMale], 800, 100), 101.06},
+		}
+		for _, tt := range tests {
+			if math.Abs(tt.got-tt.want) > 0.01 {
+				t.Errorf("%s: 期待される値: %v, 実際: %v", tt.name, tt.want, tt.got)
+			}
+		}
+	})
+
+	t.Run("係数の適用範囲外の体重は範囲内に収める", func(t *testing.T) {
+		if dotsScore(SexFemale, 100, 200) != dotsScore(SexFemale, 100, 150) {
+			t.Error("150kgを超える体重は150kgとして計算されるべき")
+		}
+	})
+}
+
+func TestStrengthStandards_Classify(t *testing.T) {
+	t.Run("体重比から段階と次の段階を求める", func(t *testing.T) {
+		current, next := DefaultStrengthStandards.classify(SexMale, BigLiftBenchPress, 1.2)
+		if current == nil || current.Level != "intermediate" {
+			t.Errorf("期待される段階: intermediate, 実際: %+v", current)
+		}
+		if next == nil || next.Level != "advanced" {
+			t.Errorf("期待される次の段階: advanced, 実際: %+v", next)
+		}
+	})
+
+	t.Run("最上位の段階では次の段階はない", func(t *testing.T) {
+		current, next := DefaultStrengthStandards.classify(SexMale, BigLiftDeadlift, 3.2)
+		if current == nil || current.Level != "elite" || next != nil {
+			t.Errorf("elite のみを期待, 実際: %+v, %+v", current, next)
+		}
+	})
+}
+
+func TestStrengthService_ScoreLift(t *testing.T) {
+	strengthService := NewStrengthService(nil, nil, nil, DefaultStrengthStandards)
+
+	t.Run("体重比と次の段階に必要な重量を求める", func(t *testing.T) {
+		score := strengthService.scoreLift(SexMale, BigLiftSquat, 120, &model.BodyWeight{Weight: 80})
+
+		if score.BodyWeightRatio == nil || *score.BodyWeightRatio != 1.5 {
+			t.Errorf("期待される体重比: 1.5, 実際: %v", score.BodyWeightRatio)
+		}
+		if score.NextLevelWeight == nil || *score.NextLevelWeight != 160 {
+			t.Errorf("期待される次の段階の重量: 160, 実際: %v", score.NextLevelWeight)
+		}
+		if score.IPFGL != nil {
+			t.Error("スクワット単体では IPF GL を算出しない")
+		}
+	})
+
+	t.Run("体重の記録がなくても推定1RMを返す", func(t *testing.T) {
+		workoutRepo := NewMockWorkoutRepository()
+		exerciseRepo := NewMockExerciseRepository()
+		workoutRepo.CreateWithSets(
+			&model.Workout{UserID: 1, Date: date("2026-10-01")},
+			[]*model.WorkoutSet{
+				{ExerciseID: 1, SetNumber: 1, Weight: 100, Reps: 1},
+				{ExerciseID: 2, SetNumber: 1, Weight: 140, Reps: 1},
+				{ExerciseID: 3, SetNumber: 1, Weight: 180, Reps: 1},
+			},
+			nil,
+		)
+		// 名前を変えても主要種目として扱う
+		exerciseRepo.exercises[1].Name = "Bench Press"
+		service := NewStrengthService(workoutRepo, exerciseRepo, &MockBodyWeightRepository{}, DefaultStrengthStandards)
+
+		scores, err := service.GetStrengthScores(1, "male", StatsParams{})
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(scores.Lifts) != 3 {
+			t.Fatalf("3種目の記録を期待, 実際: %+v", scores.Lifts)
+		}
+		bench := scores.Lifts[1]
+		if bench.Lift != BigLiftBenchPress || bench.EstimatedOneRepMax != 100 {
+			t.Errorf("期待: ベンチプレス 100kg, 実際: %s %vkg", bench.Lift, bench.EstimatedOneRepMax)
+		}
+		if bench.BodyWeightRatio != nil || bench.DOTS != nil || bench.Level != nil {
+			t.Errorf("体重を使う値は nil を期待, 実際: %+v", bench)
+		}
+		if scores.Total == nil || scores.Total.Total != 420 || scores.Total.DOTS != nil {
+			t.Errorf("合計420kg・スコアなしを期待, 実際: %+v", scores.Total)
+		}
+	})
+
+	t.Run("性別が不正な場合はエラー", func(t *testing.T) {
+		_, err := strengthService.GetStrengthScores(1, "", StatsParams{})
+		if !errors.Is(err, ErrInvalidSex) {
+			t.Errorf("ErrInvalidSex を期待, 実際: %v", err)
+		}
+	})
+}
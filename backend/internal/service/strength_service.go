@@ -0,0 +1,201 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/training-memo/backend/internal/model"
+)
+
+// bigLiftOrder は結果に並べる主要種目の順序
+var bigLiftOrder = []BigLift{BigLiftSquat, BigLiftBenchPress, BigLiftDeadlift, BigLiftOverheadPress}
+
+// LiftStrengthScore は主要種目の体重比の強さ
+// 推定1RMが最も高い日の記録と、その日に最も近い体重記録を使う
+// 体重の記録がない場合、体重を使う値（体重比・スコア・段階）は nil
+type LiftStrengthScore struct {
+	Lift               BigLift        `json:"lift"`
+	ExerciseID         uint64         `json:"exercise_id"`
+	ExerciseName       string         `json:"exercise_name"`
+	Date               time.Time      `json:"date"`
+	EstimatedOneRepMax float64        `json:"estimated_one_rep_max"`
+	BodyWeight         *float64       `json:"body_weight"`
+	BodyWeightDate     *time.Time     `json:"body_weight_date"`
+	BodyWeightRatio    *float64       `json:"body_weight_ratio"`
+	DOTS               *float64       `json:"dots"`
+	Wilks              *float64       `json:"wilks"`
+	IPFGL              *float64       `json:"ipf_gl"` // ベンチプレスのみ
+	Level              *StrengthLevel `json:"level"`
+	NextLevel          *StrengthLevel `json:"next_level"`
+	NextLevelWeight    *float64       `json:"next_level_weight"` // 次の段階に必要な推定1RM
+}
+
+// TotalStrengthScore はスクワット・ベンチプレス・デッドリフトの合計のスコア
+// 体重の記録がない場合、体重とスコアは nil
+type TotalStrengthScore struct {
+	Total      float64  `json:"total"`
+	BodyWeight *float64 `json:"body_weight"`
+	DOTS       *float64 `json:"dots"`
+	Wilks      *float64 `json:"wilks"`
+	IPFGL      *float64 `json:"ipf_gl"`
+}
+
+// StrengthScores は体重比の強さの評価
+type StrengthScores struct {
+	Sex   Sex                 `json:"sex"`
+	Lifts []LiftStrengthScore `json:"lifts"`
+	Total *TotalStrengthScore `json:"total"` // 3種目がそろわない場合は nil
+}
+
+// StrengthService は挙上記録と体重記録を組み合わせて体重比の強さを評価する
+type StrengthService struct {
+	workoutRepo    WorkoutRepository
+	exerciseRepo   ExerciseRepository
+	bodyWeightRepo BodyWeightRepository
+	standards      StrengthStandards
+}
+
+func NewStrengthService(workoutRepo WorkoutRepository, exerciseRepo ExerciseRepository, bodyWeightRepo BodyWeightRepository, standards StrengthStandards) *StrengthService {
+	return &StrengthService{
+		workoutRepo:    workoutRepo,
+		exerciseRepo:   exerciseRepo,
+		bodyWeightRepo: bodyWeightRepo,
+		standards:      standards,
+	}
+}
+
+// GetStrengthScores は主要種目ごとの DOTS・Wilks・体重比と基準表による段階を返す
+func (s *StrengthService) GetStrengthScores(userID uint64, sex string, params StatsParams) (*StrengthScores, error) {
+	sx := Sex(sex)
+	if sx != SexMale && sx != SexFemale {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSex, sex)
+	}
+	filter, err := params.filter()
+	if err != nil {
+		return nil, err
+	}
+	formula, err := params.formula()
+	if err != nil {
+		return nil, err
+	}
+
+	exercises, err := s.exerciseRepo.FindAll(userID)
+	if err != nil {
+		return nil, fmt.Errorf("finding exercises: %w", err)
+	}
+	lifts := make(map[uint64]BigLift)
+	names := make(map[uint64]string)
+	var exerciseIDs []uint64
+	for _, exercise := range exercises {
+		if lift, ok := bigLiftOf(exercise); ok {
+			lifts[exercise.ID] = lift
+			names[exercise.ID] = exercise.Name
+			exerciseIDs = append(exerciseIDs, exercise.ID)
+		}
+	}
+
+	bests, err := s.workoutRepo.GetBestOneRepMaxDays(userID, exerciseIDs, formula, filter)
+	if err != nil {
+		return nil, err
+	}
+	bodyWeights, err := s.bodyWeightRepo.FindByUserID(userID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("finding body weights: %w", err)
+	}
+
+	byLift := make(map[BigLift]LiftStrengthScore)
+	for _, best := range bests {
+		if best.EstimatedOneRepMax <= 0 {
+			continue
+		}
+		lift := lifts[best.ExerciseID]
+		score := s.scoreLift(sx, lift, best.EstimatedOneRepMax, nearestBodyWeight(bodyWeights, best.Date))
+		score.ExerciseID = best.ExerciseID
+		score.ExerciseName = names[best.ExerciseID]
+		score.Date = best.Date
+		byLift[lift] = score
+	}
+
+	result := &StrengthScores{Sex: sx, Lifts: []LiftStrengthScore{}}
+	for _, lift := range bigLiftOrder {
+		if score, ok := byLift[lift]; ok {
+			result.Lifts = append(result.Lifts, score)
+		}
+	}
+
+	squat, hasSquat := byLift[BigLiftSquat]
+	bench, hasBench := byLift[BigLiftBenchPress]
+	deadlift, hasDeadlift := byLift[BigLiftDeadlift]
+	if hasSquat && hasBench && hasDeadlift {
+		total := squat.EstimatedOneRepMax + bench.EstimatedOneRepMax + deadlift.EstimatedOneRepMax
+		result.Total = &TotalStrengthScore{Total: total}
+		// 合計は3種目のうち最も新しい記録の日の体重で評価する
+		latest := squat.Date
+		for _, d := range []time.Time{bench.Date, deadlift.Date} {
+			if d.After(latest) {
+				latest = d
+			}
+		}
+		if bodyWeight := nearestBodyWeight(bodyWeights, latest); bodyWeight != nil {
+			bw := bodyWeight.Weight
+			dots := dotsScore(sx, total, bw)
+			wilks := wilksScore(sx, total, bw)
+			gl := ipfGLScore(ipfGLTotalCoefficients[sx], total, bw)
+			result.Total.BodyWeight = &bw
+			result.Total.DOTS = &dots
+			result.Total.Wilks = &wilks
+			result.Total.IPFGL = &gl
+		}
+	}
+
+	return result, nil
+}
+
+// nearestBodyWeight は date に最も近い日付の体重記録を返す（同じ差の場合は前の記録を優先、記録がなければ nil）
+func nearestBodyWeight(bodyWeights []model.BodyWeight, date time.Time) *model.BodyWeight {
+	var nearest *model.BodyWeight
+	var nearestDiff time.Duration
+	for i := range bodyWeights {
+		bw := &bodyWeights[i]
+		diff := toDate(bw.Date).Sub(toDate(date))
+		if diff < 0 {
+			diff = -diff
+		}
+		if nearest == nil || diff < nearestDiff || (diff == nearestDiff && bw.Date.Before(nearest.Date)) {
+			nearest, nearestDiff = bw, diff
+		}
+	}
+	return nearest
+}
+
+// scoreLift は推定1RMと体重からスコアと段階を求める（bodyWeight が nil の場合は推定1RMのみ）
+func (s *StrengthService) scoreLift(sex Sex, lift BigLift, oneRepMax float64, bodyWeight *model.BodyWeight) LiftStrengthScore {
+	score := LiftStrengthScore{
+		Lift:               lift,
+		EstimatedOneRepMax: oneRepMax,
+	}
+	if bodyWeight == nil {
+		return score
+	}
+
+	bw, date := bodyWeight.Weight, bodyWeight.Date
+	ratio := oneRepMax / bw
+	dots := dotsScore(sex, oneRepMax, bw)
+	wilks := wilksScore(sex, oneRepMax, bw)
+	score.BodyWeight = &bw
+	score.BodyWeightDate = &date
+	score.BodyWeightRatio = &ratio
+	score.DOTS = &dots
+	score.Wilks = &wilks
+	if lift == BigLiftBenchPress {
+		gl := ipfGLScore(ipfGLBenchCoefficients[sex], oneRepMax, bw)
+		score.IPFGL = &gl
+	}
+
+	score.Level, score.NextLevel = s.standards.classify(sex, lift, ratio)
+	if score.NextLevel != nil {
+		weight := score.NextLevel.MinRatio * bw
+		score.NextLevelWeight = &weight
+	}
+	return score
+}
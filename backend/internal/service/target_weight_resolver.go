@@ -0,0 +1,125 @@
+package service
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/training-memo/backend/internal/model"
+)
+
+const (
+	defaultPlateIncrement = 2.5
+	// recentSetLimit は推定1RMの算出に使う直近のセット数
+	recentSetLimit = 30
+)
+
+// TargetWeightResolver はメニュー項目の目標重量を直近の記録から確定させる
+type TargetWeightResolver struct {
+	workoutRepo    WorkoutRepository
+	plateIncrement float64
+}
+
+// NewTargetWeightResolver は PLATE_INCREMENT_KG（未設定時は2.5kg）を丸め単位として使う
+func NewTargetWeightResolver(workoutRepo WorkoutRepository) *TargetWeightResolver {
+	increment := defaultPlateIncrement
+	if v := os.Getenv("PLATE_INCREMENT_KG"); v != "" {
+		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed > 0 {
+			increment = parsed
+		}
+	}
+	return &TargetWeightResolver{
+		workoutRepo:    workoutRepo,
+		plateIncrement: increment,
+	}
+}
+
+// Resolve は各項目の ResolvedWeight を設定する
+// 記録がなく重量を決められない項目は nil のままにする
+func (r *TargetWeightResolver) Resolve(userID uint64, items []model.MenuItem) error {
+	refs := make([]*model.MenuItem, len(items))
+	for i := range items {
+		refs[i] = &items[i]
+	}
+	return r.resolve(userID, refs)
+}
+
+// ResolveMenus は複数のメニューの項目をまとめて確定させる
+func (r *TargetWeightResolver) ResolveMenus(userID uint64, menus []model.Menu) error {
+	var refs []*model.MenuItem
+	for i := range menus {
+		for j := range menus[i].Items {
+			refs = append(refs, &menus[i].Items[j])
+		}
+	}
+	return r.resolve(userID, refs)
+}
+
+// resolve は割合・RPE指定の項目の種目について直近のセットを1回のクエリで取得し、重量を確定させる
+func (r *TargetWeightResolver) resolve(userID uint64, items []*model.MenuItem) error {
+	seen := make(map[uint64]bool)
+	var exerciseIDs []uint64
+	for _, item := range items {
+		if item.TargetType == "" || item.TargetType == model.TargetTypeFixed || seen[item.ExerciseID] {
+			continue
+		}
+		seen[item.ExerciseID] = true
+		exerciseIDs = append(exerciseIDs, item.ExerciseID)
+	}
+	e1rms, err := r.recentOneRepMaxes(userID, exerciseIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if item.TargetType == "" || item.TargetType == model.TargetTypeFixed {
+			item.ResolvedWeight = item.TargetWeight
+			continue
+		}
+
+		e1rm := e1rms[item.ExerciseID]
+		if e1rm <= 0 {
+			item.ResolvedWeight = nil
+			continue
+		}
+
+		var ratio float64
+		switch item.TargetType {
+		case model.TargetTypePercentE1RM:
+			if item.TargetPercentage == nil {
+				continue
+			}
+			ratio = *item.TargetPercentage / 100
+		case model.TargetTypeRPE:
+			if item.TargetRPE == nil {
+				continue
+			}
+			ratio = rpePercentage(item.TargetReps, *item.TargetRPE)
+		default:
+			continue
+		}
+
+		weight := roundToIncrement(e1rm*ratio, r.plateIncrement)
+		item.ResolvedWeight = &weight
+	}
+
+	return nil
+}
+
+// recentOneRepMaxes は種目ごとに直近のセットから最も高い推定1RMを返す
+func (r *TargetWeightResolver) recentOneRepMaxes(userID uint64, exerciseIDs []uint64) (map[uint64]float64, error) {
+	best := make(map[uint64]float64, len(exerciseIDs))
+	if len(exerciseIDs) == 0 {
+		return best, nil
+	}
+	sets, err := r.workoutRepo.GetRecentSets(userID, exerciseIDs, recentSetLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, set := range sets {
+		if e1rm := EstimateOneRepMax(set.Weight, set.Reps); e1rm > best[set.ExerciseID] {
+			best[set.ExerciseID] = e1rm
+		}
+	}
+	return best, nil
+}
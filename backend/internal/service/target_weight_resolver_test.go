@@ -0,0 +1,116 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/training-memo/backend/internal/model"
+)
+
+func TestEstimateOneRepMax(t *testing.T) {
+	t.Run("1レップは重量そのまま", func(t *testing.T) {
+		if got := EstimateOneRepMax(100, 1); got != 100 {
+			t.Errorf("期待される1RM: 100, 実際: %f", got)
+		}
+	})
+
+	t.Run("複数レップはEpley式で推定する", func(t *testing.T) {
+		got := EstimateOneRepMax(100, 6)
+		if math.Abs(got-120) > 0.001 {
+			t.Errorf("期待される1RM: 120, 実際: %f", got)
+		}
+	})
+
+	t.Run("0レップは0", func(t *testing.T) {
+		if got := EstimateOneRepMax(100, 0); got != 0 {
+			t.Errorf("期待される1RM: 0, 実際: %f", got)
+		}
+	})
+}
+
+func TestTargetWeightResolver_Resolve(t *testing.T) {
+	newResolver := func() (*TargetWeightResolver, uint64) {
+		workoutRepo := NewMockWorkoutRepository()
+		userID := uint64(1)
+		workout := &model.Workout{UserID: userID, Date: time.Now()}
+		workoutRepo.Create(workout)
+		// 100kg×6 → 推定1RM 120kg
+		workoutRepo.AddSet(&model.WorkoutSet{WorkoutID: workout.ID, ExerciseID: 1, SetNumber: 1, Weight: 100, Reps: 6})
+		workoutRepo.AddSet(&model.WorkoutSet{WorkoutID: workout.ID, ExerciseID: 1, SetNumber: 2, Weight: 90, Reps: 5})
+		return &TargetWeightResolver{workoutRepo: workoutRepo, plateIncrement: 2.5}, userID
+	}
+
+	t.Run("推定1RMの割合をプレート刻みに丸める", func(t *testing.T) {
+		resolver, userID := newResolver()
+		percentage := 75.0
+		items := []model.MenuItem{
+			{ExerciseID: 1, TargetReps: 5, TargetType: model.TargetTypePercentE1RM, TargetPercentage: &percentage},
+		}
+		if err := resolver.Resolve(userID, items); err != nil {
+			t.Fatalf("重量の確定に失敗: %v", err)
+		}
+		if items[0].ResolvedWeight == nil || *items[0].ResolvedWeight != 90 {
+			t.Errorf("期待される重量: 90, 実際: %v", items[0].ResolvedWeight)
+		}
+	})
+
+	t.Run("RPE指定は余力込みのレップ数から算出する", func(t *testing.T) {
+		resolver, userID := newResolver()
+		rpe := 8.0
+		items := []model.MenuItem{
+			{ExerciseID: 1, TargetReps: 5, TargetType: model.TargetTypeRPE, TargetRPE: &rpe},
+		}
+		if err := resolver.Resolve(userID, items); err != nil {
+			t.Fatalf("重量の確定に失敗: %v", err)
+		}
+		// 120 / (1 + 7/30) = 97.3 → 97.5
+		if items[0].ResolvedWeight == nil || *items[0].ResolvedWeight != 97.5 {
+			t.Errorf("期待される重量: 97.5, 実際: %v", items[0].ResolvedWeight)
+		}
+	})
+
+	t.Run("固定重量はそのまま", func(t *testing.T) {
+		resolver, userID := newResolver()
+		weight := 60.0
+		items := []model.MenuItem{
+			{ExerciseID: 1, TargetType: model.TargetTypeFixed, TargetWeight: &weight},
+		}
+		resolver.Resolve(userID, items)
+		if items[0].ResolvedWeight == nil || *items[0].ResolvedWeight != 60 {
+			t.Errorf("期待される重量: 60, 実際: %v", items[0].ResolvedWeight)
+		}
+	})
+
+	t.Run("記録がない種目は確定しない", func(t *testing.T) {
+		resolver, userID := newResolver()
+		percentage := 75.0
+		items := []model.MenuItem{
+			{ExerciseID: 2, TargetType: model.TargetTypePercentE1RM, TargetPercentage: &percentage},
+		}
+		resolver.Resolve(userID, items)
+		if items[0].ResolvedWeight != nil {
+			t.Errorf("重量はnilであるべき, 実際: %v", *items[0].ResolvedWeight)
+		}
+	})
+
+	t.Run("複数のメニューの項目を1回の取得で確定する", func(t *testing.T) {
+		resolver, userID := newResolver()
+		percentage := 75.0
+		menus := []model.Menu{
+			{Items: []model.MenuItem{{ExerciseID: 1, TargetReps: 5, TargetType: model.TargetTypePercentE1RM, TargetPercentage: &percentage}}},
+			{Items: []model.MenuItem{{ExerciseID: 1, TargetReps: 5, TargetType: model.TargetTypePercentE1RM, TargetPercentage: &percentage}}},
+		}
+		if err := resolver.ResolveMenus(userID, menus); err != nil {
+			t.Fatalf("重量の確定に失敗: %v", err)
+		}
+		for i, menu := range menus {
+			if w := menu.Items[0].ResolvedWeight; w == nil || *w != 90 {
+				t.Errorf("メニュー %d: 期待される重量: 90, 実際: %v", i, w)
+			}
+		}
+		if queries := resolver.workoutRepo.(*MockWorkoutRepository).recentSetQueries; queries != 1 {
+			t.Errorf("直近のセットの取得は1回であるべき, 実際: %d", queries)
+		}
+	})
+}
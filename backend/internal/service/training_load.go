@@ -0,0 +1,197 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/repository"
+)
+
+const (
+	// acuteLoadDays は急性負荷（疲労）の集計日数
+	acuteLoadDays = 7
+	// chronicLoadDays は慢性負荷（体力）の集計日数
+	chronicLoadDays = 28
+	// defaultLoadDays は期間を指定しない場合に返す日数（今日を含む）
+	defaultLoadDays = 90
+
+	// ACWR の目安（0.8〜1.3 が適正、1.5 を超えると急増とみなす）
+	acwrLowThreshold   = 0.8
+	acwrHighThreshold  = 1.3
+	acwrSpikeThreshold = 1.5
+)
+
+// LoadMetric はセッション負荷の算出方法
+type LoadMetric string
+
+const (
+	// LoadMetricAuto は全セットに RPE が記録されていれば rpe、それ以外は volume を使う
+	LoadMetricAuto   LoadMetric = "auto"
+	LoadMetricVolume LoadMetric = "volume" // 重量 × レップ数の合計
+	LoadMetricRPE    LoadMetric = "rpe"    // セット数 × RPE（RPE の合計）
+)
+
+// LoadZone は ACWR の区分
+type LoadZone string
+
+const (
+	LoadZoneLow     LoadZone = "low"
+	LoadZoneOptimal LoadZone = "optimal"
+	LoadZoneHigh    LoadZone = "high"
+	LoadZoneSpike   LoadZone = "spike"
+)
+
+// LoadPoint は1日分の負荷
+// AcuteLoad は直近7日の合計、ChronicLoad は直近28日の週平均（どちらも当日を含む）
+type LoadPoint struct {
+	Date        time.Time `json:"date"`
+	Load        float64   `json:"load"`
+	AcuteLoad   float64   `json:"acute_load"`
+	ChronicLoad float64   `json:"chronic_load"`
+	ACWR        *float64  `json:"acwr"`
+	Zone        *LoadZone `json:"zone"`
+	Spike       bool      `json:"spike"`
+}
+
+// MuscleGroupLoadSeries は部位ごとの負荷の推移
+type MuscleGroupLoadSeries struct {
+	MuscleGroup model.MuscleGroup `json:"muscle_group"`
+	Series      []LoadPoint       `json:"series"`
+}
+
+// LoadStats はトレーニング負荷の統計
+type LoadStats struct {
+	Metric       LoadMetric              `json:"metric"`
+	From         time.Time               `json:"from"`
+	To           time.Time               `json:"to"`
+	Overall      []LoadPoint             `json:"overall"`
+	MuscleGroups []MuscleGroupLoadSeries `json:"muscle_groups"`
+}
+
+// GetLoad は日ごとの負荷と急性・慢性負荷、ACWR を全体と部位ごとに返す
+// 期間の指定がない場合は今日までの90日間を対象とする
+func (s *StatsService) GetLoad(userID uint64, params StatsParams, metric string) (*LoadStats, error) {
+	loadMetric := LoadMetric(metric)
+	if loadMetric == "" {
+		loadMetric = LoadMetricAuto
+	}
+	if loadMetric != LoadMetricAuto && loadMetric != LoadMetricVolume && loadMetric != LoadMetricRPE {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidLoadMetric, metric)
+	}
+
+	filter, err := params.filter()
+	if err != nil {
+		return nil, err
+	}
+	to := toDate(time.Now())
+	if filter.To != nil {
+		to = *filter.To
+	}
+	from := to.AddDate(0, 0, -(defaultLoadDays - 1))
+	if filter.From != nil {
+		from = *filter.From
+	}
+
+	// 期間の初日から慢性負荷を求められるよう、28日前から取得する
+	fetchFrom := from.AddDate(0, 0, -(chronicLoadDays - 1))
+	loads, err := s.workoutRepo.GetDailyMuscleGroupLoad(userID, repository.StatsFilter{From: &fetchFrom, To: &to})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildLoadStats(loads, loadMetric, from, to), nil
+}
+
+// buildLoadStats は日・部位ごとの実績から負荷の推移を組み立てる
+// loads は from の27日前から to までの実績を想定する
+func buildLoadStats(loads []repository.DailyMuscleGroupLoad, metric LoadMetric, from, to time.Time) *LoadStats {
+	if metric == LoadMetricAuto {
+		metric = LoadMetricVolume
+		if len(loads) > 0 {
+			metric = LoadMetricRPE
+			for _, l := range loads {
+				if l.RPESets < l.Sets {
+					metric = LoadMetricVolume
+					break
+				}
+			}
+		}
+	}
+
+	overall := make(map[time.Time]float64)
+	byGroup := make(map[model.MuscleGroup]map[time.Time]float64)
+	for _, l := range loads {
+		load := l.Volume
+		if metric == LoadMetricRPE {
+			load = l.RPETotal
+		}
+		date := toDate(l.Date)
+		overall[date] += load
+		if byGroup[l.MuscleGroup] == nil {
+			byGroup[l.MuscleGroup] = make(map[time.Time]float64)
+		}
+		byGroup[l.MuscleGroup][date] += load
+	}
+
+	groups := make([]model.MuscleGroup, 0, len(byGroup))
+	for g := range byGroup {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i] < groups[j] })
+
+	stats := &LoadStats{
+		Metric:       metric,
+		From:         from,
+		To:           to,
+		Overall:      loadSeries(overall, from, to),
+		MuscleGroups: make([]MuscleGroupLoadSeries, 0, len(groups)),
+	}
+	for _, g := range groups {
+		stats.MuscleGroups = append(stats.MuscleGroups, MuscleGroupLoadSeries{
+			MuscleGroup: g,
+			Series:      loadSeries(byGroup[g], from, to),
+		})
+	}
+	return stats
+}
+
+// loadSeries は日別の負荷から from〜to の毎日の急性・慢性負荷と ACWR を求める
+func loadSeries(daily map[time.Time]float64, from, to time.Time) []LoadPoint {
+	var series []LoadPoint
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		point := LoadPoint{Date: day, Load: daily[day]}
+		for i := 0; i < chronicLoadDays; i++ {
+			load := daily[day.AddDate(0, 0, -i)]
+			if i < acuteLoadDays {
+				point.AcuteLoad += load
+			}
+			point.ChronicLoad += load
+		}
+		point.ChronicLoad = point.ChronicLoad / chronicLoadDays * acuteLoadDays
+
+		// 慢性負荷がない場合は比率を求められない
+		if point.ChronicLoad > 0 {
+			acwr := point.AcuteLoad / point.ChronicLoad
+			zone := loadZone(acwr)
+			point.ACWR = &acwr
+			point.Zone = &zone
+			point.Spike = zone == LoadZoneSpike
+		}
+		series = append(series, point)
+	}
+	return series
+}
+
+func loadZone(acwr float64) LoadZone {
+	switch {
+	case acwr > acwrSpikeThreshold:
+		return LoadZoneSpike
+	case acwr > acwrHighThreshold:
+		return LoadZoneHigh
+	case acwr >= acwrLowThreshold:
+		return LoadZoneOptimal
+	}
+	return LoadZoneLow
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/training-memo/backend/internal/model"
+	"github.com/training-memo/backend/internal/repository"
+)
+
+func TestBuildLoadStats(t *testing.T) {
+	from, to := date("2026-10-01"), date("2026-10-14")
+
+	// 9月は週1000ずつ、10月14日に急増
+	var loads []repository.DailyMuscleGroupLoad
+	for _, d := range []string{"2026-09-04", "2026-09-11", "2026-09-18", "2026-09-25", "2026-10-02", "2026-10-09"} {
+		loads = append(loads, repository.DailyMuscleGroupLoad{Date: date(d), MuscleGroup: model.MuscleGroupChest, Sets: 5, Volume: 1000})
+	}
+	loads = append(loads,
+		repository.DailyMuscleGroupLoad{Date: date("2026-10-14"), MuscleGroup: model.MuscleGroupChest, Sets: 5, Volume: 1000},
+		repository.DailyMuscleGroupLoad{Date: date("2026-10-14"), MuscleGroup: model.MuscleGroupLegs, Sets: 10, Volume: 3000},
+	)
+
+	t.Run("期間内の毎日の負荷を返す", func(t *testing.T) {
+		stats := buildLoadStats(loads, LoadMetricVolume, from, to)
+
+		if len(stats.Overall) != 14 {
+			t.Fatalf("期待される日数: 14, 実際: %d", len(stats.Overall))
+		}
+		if len(stats.MuscleGroups) != 2 {
+			t.Errorf("期待される部位数: 2, 実際: %d", len(stats.MuscleGroups))
+		}
+	})
+
+	t.Run("急性負荷と慢性負荷の比率を求める", func(t *testing.T) {
+		stats := buildLoadStats(loads, LoadMetricVolume, from, to)
+
+		steady := stats.Overall[8] // 10月9日: 急性1000、慢性1000
+		if steady.ACWR == nil || *steady.ACWR != 1 || *steady.Zone != LoadZoneOptimal {
+			t.Errorf("期待されるACWR: 1（optimal）, 実際: %+v", steady)
+		}
+
+		last := stats.Overall[13] // 10月14日: 急性5000、慢性(1000*3+5000)/4
+		if last.AcuteLoad != 5000 || last.ChronicLoad != 2000 {
+			t.Errorf("期待される負荷: 5000 / 2000, 実際: %v / %v", last.AcuteLoad, last.ChronicLoad)
+		}
+		if !last.Spike {
+			t.Errorf("急増として検出されるべき: %+v", last)
+		}
+	})
+
+	t.Run("慢性負荷がない日は比率を返さない", func(t *testing.T) {
+		stats := buildLoadStats(loads, LoadMetricVolume, from, to)
+
+		legs := stats.MuscleGroups[1]
+		if legs.MuscleGroup != model.MuscleGroupLegs {
+			t.Fatalf("期待される部位: legs, 実際: %s", legs.MuscleGroup)
+		}
+		if legs.Series[0].ACWR != nil {
+			t.Errorf("比率は nil を期待, 実際: %v", *legs.Series[0].ACWR)
+		}
+	})
+
+	t.Run("全セットにRPEがあればRPEで算出する", func(t *testing.T) {
+		rpeLoads := []repository.DailyMuscleGroupLoad{
+			{Date: date("2026-10-02"), MuscleGroup: model.MuscleGroupBack, Sets: 3, RPESets: 3, Volume: 1500, RPETotal: 24},
+		}
+		stats := buildLoadStats(rpeLoads, LoadMetricAuto, from, to)
+		if stats.Metric != LoadMetricRPE {
+			t.Errorf("期待される指標: rpe, 実際: %s", stats.Metric)
+		}
+		if stats.Overall[1].Load != 24 {
+			t.Errorf("期待される負荷: 24, 実際: %v", stats.Overall[1].Load)
+		}
+
+		stats = buildLoadStats(loads, LoadMetricAuto, from, to)
+		if stats.Metric != LoadMetricVolume {
+			t.Errorf("RPEがないセットがある場合は volume を期待, 実際: %s", stats.Metric)
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/training-memo/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// maxProgressionDepth は段階をたどる最大の数
+const maxProgressionDepth = 20
+
+// ProgressionStep は段階的な種目の1段階
+type ProgressionStep struct {
+	model.Exercise
+	Step int `json:"step"` // 最も易しい種目を0とする段階（分岐している場合は同じ段階に複数の種目がある）
+}
+
+// Progression は種目を含む段階的な種目の一覧と次に取り組む種目
+type Progression struct {
+	Steps     []ProgressionStep `json:"steps"`
+	CurrentID *uint64           `json:"current_id"` // 記録のある最も難しい段階の種目（記録がない場合は nil）
+	Next      []model.Exercise  `json:"next"`       // 次に取り組む種目（記録がない場合は最も易しい段階の種目）
+}
+
+// applyExerciseRelations はカスタム種目のバリエーションの親と1つ前の段階を検証して設定する
+// 指定がない場合は関係を解除する
+func (s *WorkoutService) applyExerciseRelations(userID uint64, exercise *model.Exercise, input *CreateExerciseInput) error {
+	if input.ParentID != nil {
+		if err := s.validateParent(userID, exercise, *input.ParentID); err != nil {
+			return err
+		}
+	}
+	if input.ProgressesFromID != nil {
+		if err := s.validateProgressesFrom(userID, exercise, *input.ProgressesFromID); err != nil {
+			return err
+		}
+	}
+	exercise.ParentID = input.ParentID
+	exercise.ProgressesFromID = input.ProgressesFromID
+	return nil
+}
+
+// validateParent はバリエーションの親が1階層に収まることを確認する
+func (s *WorkoutService) validateParent(userID uint64, exercise *model.Exercise, parentID uint64) error {
+	if parentID == exercise.ID {
+		return fmt.Errorf("%w: an exercise cannot be a variation of itself", ErrInvalidVariation)
+	}
+	parent, err := s.findVisibleExercise(userID, parentID)
+	if err != nil {
+		if errors.Is(err, ErrExerciseNotFound) {
+			return fmt.Errorf("%w: parent exercise %d not found", ErrInvalidVariation, parentID)
+		}
+		return err
+	}
+	if parent.ParentID != nil {
+		return fmt.Errorf("%w: %s is itself a variation", ErrInvalidVariation, parent.Name)
+	}
+	if exercise.ID == 0 {
+		return nil
+	}
+	variations, err := s.exerciseRepo.FindVariations(userID, exercise.ID)
+	if err != nil {
+		return fmt.Errorf("finding variations: %w", err)
+	}
+	if len(variations) > 0 {
+		return fmt.Errorf("%w: an exercise with variations cannot be a variation", ErrInvalidVariation)
+	}
+	return nil
+}
+
+// validateProgressesFrom は1つ前の段階をたどって自身に戻らないことを確認する
+func (s *WorkoutService) validateProgressesFrom(userID uint64, exercise *model.Exercise, fromID uint64) error {
+	if fromID == exercise.ID {
+		return fmt.Errorf("%w: an exercise cannot progress from itself", ErrInvalidProgression)
+	}
+	from, err := s.findVisibleExercise(userID, fromID)
+	if err != nil {
+		if errors.Is(err, ErrExerciseNotFound) {
+			return fmt.Errorf("%w: exercise %d not found", ErrInvalidProgression, fromID)
+		}
+		return err
+	}
+	if exercise.ID == 0 {
+		return nil
+	}
+	for depth := 0; from.ProgressesFromID != nil && depth < maxProgressionDepth; depth++ {
+		if *from.ProgressesFromID == exercise.ID {
+			return fmt.Errorf("%w: progression would form a cycle", ErrInvalidProgression)
+		}
+		if from, err = s.exerciseRepo.FindByID(*from.ProgressesFromID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return fmt.Errorf("finding exercise: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetVariations は種目のバリエーションを返す
+func (s *WorkoutService) GetVariations(userID, exerciseID uint64) ([]model.Exercise, error) {
+	if _, err := s.findVisibleExercise(userID, exerciseID); err != nil {
+		return nil, err
+	}
+	return s.exerciseRepo.FindVariations(userID, exerciseID)
+}
+
+// GetProgression は種目を含む段階的な種目の一覧と、記録から次に取り組む種目を返す
+func (s *WorkoutService) GetProgression(userID, exerciseID uint64) (*Progression, error) {
+	if _, err := s.findVisibleExercise(userID, exerciseID); err != nil {
+		return nil, err
+	}
+	chain, err := s.exerciseRepo.FindProgressionChain(userID, exerciseID)
+	if err != nil {
+		return nil, fmt.Errorf("finding progression: %w", err)
+	}
+
+	byID := make(map[uint64]*model.Exercise, len(chain))
+	for i := range chain {
+		byID[chain[i].ID] = &chain[i]
+	}
+	steps := make([]ProgressionStep, 0, len(chain))
+	for _, e := range chain {
+		steps = append(steps, ProgressionStep{Exercise: e, Step: progressionStep(byID, e)})
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		if steps[i].Step != steps[j].Step {
+			return steps[i].Step < steps[j].Step
+		}
+		return steps[i].Name < steps[j].Name
+	})
+
+	progression := &Progression{Steps: steps, Next: []model.Exercise{}}
+	var current *ProgressionStep
+	for i := range steps {
+		step := &steps[i]
+		if step.UseCount == 0 {
+			continue
+		}
+		if current == nil || step.Step > current.Step ||
+			(step.Step == current.Step && step.LastUsedOn != nil && current.LastUsedOn != nil && step.LastUsedOn.After(*current.LastUsedOn)) {
+			current = step
+		}
+	}
+	for _, step := range steps {
+		if (current == nil && step.Step == 0) ||
+			(current != nil && step.ProgressesFromID != nil && *step.ProgressesFromID == current.ID) {
+			progression.Next = append(progression.Next, step.Exercise)
+		}
+	}
+	if current != nil {
+		id := current.ID
+		progression.CurrentID = &id
+	}
+	return progression, nil
+}
+
+// progressionStep は1つ前の段階をたどった数を返す（一覧にない種目の手前で止める）
+func progressionStep(byID map[uint64]*model.Exercise, e model.Exercise) int {
+	step := 0
+	for e.ProgressesFromID != nil && step < maxProgressionDepth {
+		from, ok := byID[*e.ProgressesFromID]
+		if !ok {
+			break
+		}
+		e = *from
+		step++
+	}
+	return step
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
+
+func TestWorkoutService_Variations(t *testing.T) {
+	t.Run("バリエーションを登録すると親種目から取得できる", func(t *testing.T) {
+		exerciseRepo := NewMockExerciseRepository()
+		service := NewWorkoutService(NewMockWorkoutRepository(), exerciseRepo, nil, nil, nil, nil)
+		incline, err := service.CreateCustomExercise(1, &CreateExerciseInput{Name: "インクラインベンチ", MuscleGroup: "chest", ParentID: uint64Ptr(1)})
+		if err != nil {
+			t.Fatalf("作成に失敗: %v", err)
+		}
+		// 他のユーザーのバリエーションは含まない
+		if _, err := service.CreateCustomExercise(2, &CreateExerciseInput{Name: "デクラインベンチ", MuscleGroup: "chest", ParentID: uint64Ptr(1)}); err != nil {
+			t.Fatalf("作成に失敗: %v", err)
+		}
+
+		variations, err := service.GetVariations(1, 1)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(variations) != 1 || variations[0].ID != incline.ID {
+			t.Errorf("インクラインベンチのみを期待, 実際: %+v", variations)
+		}
+	})
+
+	t.Run("親種目は1階層までで、使えない種目は親にできない", func(t *testing.T) {
+		service := NewWorkoutService(NewMockWorkoutRepository(), NewMockExerciseRepository(), nil, nil, nil, nil)
+		incline, _ := service.CreateCustomExercise(1, &CreateExerciseInput{Name: "インクラインベンチ", MuscleGroup: "chest", ParentID: uint64Ptr(1)})
+		other, _ := service.CreateCustomExercise(2, &CreateExerciseInput{Name: "マイ種目", MuscleGroup: "chest"})
+
+		tests := []struct {
+			name     string
+			parentID uint64
+		}{
+			{"バリエーションの親", incline.ID},
+			{"他のユーザーのカスタム種目", other.ID},
+			{"存在しない種目", 999},
+		}
+		for _, tt := range tests {
+			_, err := service.CreateCustomExercise(1, &CreateExerciseInput{Name: "ベンチ", MuscleGroup: "chest", ParentID: uint64Ptr(tt.parentID)})
+			if !errors.Is(err, ErrInvalidVariation) {
+				t.Errorf("%s: ErrInvalidVariation が返されるべき, 実際: %v", tt.name, err)
+			}
+		}
+	})
+
+	t.Run("バリエーションを持つ種目は別の種目のバリエーションにできない", func(t *testing.T) {
+		service := NewWorkoutService(NewMockWorkoutRepository(), NewMockExerciseRepository(), nil, nil, nil, nil)
+		press, _ := service.CreateCustomExercise(1, &CreateExerciseInput{Name: "マシンプレス", MuscleGroup: "chest"})
+		if _, err := service.CreateCustomExercise(1, &CreateExerciseInput{Name: "片手マシンプレス", MuscleGroup: "chest", ParentID: &press.ID}); err != nil {
+			t.Fatalf("作成に失敗: %v", err)
+		}
+
+		_, err := service.UpdateCustomExercise(1, press.ID, &UpdateExerciseInput{Name: "マシンプレス", MuscleGroup: "chest", ParentID: uint64Ptr(1)})
+		if !errors.Is(err, ErrInvalidVariation) {
+			t.Errorf("ErrInvalidVariation が返されるべき, 実際: %v", err)
+		}
+	})
+}
+
+func TestWorkoutService_Progression(t *testing.T) {
+	// 膝つき → 通常 → 加重 の段階を作る
+	setup := func(t *testing.T) (*WorkoutService, *MockExerciseRepository, [3]uint64) {
+		t.Helper()
+		exerciseRepo := NewMockExerciseRepository()
+		service := NewWorkoutService(NewMockWorkoutRepository(), exerciseRepo, nil, nil, nil, nil)
+		var ids [3]uint64
+		var from *uint64
+		for i, name := range []string{"膝つき腕立て", "腕立て", "加重腕立て"} {
+			exercise, err := service.CreateCustomExercise(1, &CreateExerciseInput{Name: name, MuscleGroup: "chest", ProgressesFromID: from})
+			if err != nil {
+				t.Fatalf("作成に失敗: %v", err)
+			}
+			ids[i] = exercise.ID
+			from = &ids[i]
+		}
+		return service, exerciseRepo, ids
+	}
+
+	t.Run("記録がない場合は最も易しい段階から始める", func(t *testing.T) {
+		service, _, ids := setup(t)
+
+		progression, err := service.GetProgression(1, ids[1])
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(progression.Steps) != 3 {
+			t.Fatalf("3段階を期待, 実際: %+v", progression.Steps)
+		}
+		for i, step := range progression.Steps {
+			if step.ID != ids[i] || step.Step != i {
+				t.Errorf("段階 %d: 期待: %d, 実際: %d (step=%d)", i, ids[i], step.ID, step.Step)
+			}
+		}
+		if progression.CurrentID != nil {
+			t.Errorf("記録がない場合は current_id が nil であるべき, 実際: %d", *progression.CurrentID)
+		}
+		if len(progression.Next) != 1 || progression.Next[0].ID != ids[0] {
+			t.Errorf("膝つき腕立てを期待, 実際: %+v", progression.Next)
+		}
+	})
+
+	t.Run("記録のある最も難しい段階の次を勧める", func(t *testing.T) {
+		service, exerciseRepo, ids := setup(t)
+		exerciseRepo.exercises[ids[0]].UseCount = 10
+		exerciseRepo.exercises[ids[1]].UseCount = 2
+
+		progression, err := service.GetProgression(1, ids[0])
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if progression.CurrentID == nil || *progression.CurrentID != ids[1] {
+			t.Errorf("current_id: 期待: %d, 実際: %v", ids[1], progression.CurrentID)
+		}
+		if len(progression.Next) != 1 || progression.Next[0].ID != ids[2] {
+			t.Errorf("加重腕立てを期待, 実際: %+v", progression.Next)
+		}
+	})
+
+	t.Run("段階が循環する変更はエラー", func(t *testing.T) {
+		service, _, ids := setup(t)
+
+		_, err := service.UpdateCustomExercise(1, ids[0], &UpdateExerciseInput{Name: "膝つき腕立て", MuscleGroup: "chest", ProgressesFromID: &ids[2]})
+		if !errors.Is(err, ErrInvalidProgression) {
+			t.Errorf("ErrInvalidProgression が返されるべき, 実際: %v", err)
+		}
+	})
+}
+
+func TestStatsParams_RollUp(t *testing.T) {
+	t.Run("variations を指定するとバリエーションを親種目にまとめる", func(t *testing.T) {
+		filter, err := StatsParams{RollUp: "variations"}.filter()
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if !filter.RollUpVariations {
+			t.Error("RollUpVariations が true であるべき")
+		}
+	})
+
+	t.Run("不明な集計方法はエラー", func(t *testing.T) {
+		if _, err := (StatsParams{RollUp: "muscles"}).filter(); !errors.Is(err, ErrInvalidRollUp) {
+			t.Errorf("ErrInvalidRollUp が返されるべき, 実際: %v", err)
+		}
+	})
+}
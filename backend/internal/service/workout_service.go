@@ -11,28 +11,40 @@ import (
 )
 
 type WorkoutService struct {
-	workoutRepo  WorkoutRepository
-	exerciseRepo ExerciseRepository
+	workoutRepo   WorkoutRepository
+	exerciseRepo  ExerciseRepository
+	recordTracker *PersonalRecordTracker
+	achievements  *AchievementService
+	goals         *GoalService
+	storage       MediaStorage
 }
 
-func NewWorkoutService(workoutRepo WorkoutRepository, exerciseRepo ExerciseRepository) *WorkoutService {
+// NewWorkoutService の storage はカスタム種目の削除・統合で画像・動画を消す保存先（nil の場合は消さない）
+func NewWorkoutService(workoutRepo WorkoutRepository, exerciseRepo ExerciseRepository, recordTracker *PersonalRecordTracker, achievements *AchievementService, goals *GoalService, storage MediaStorage) *WorkoutService {
 	return &WorkoutService{
-		workoutRepo:  workoutRepo,
-		exerciseRepo: exerciseRepo,
+		workoutRepo:   workoutRepo,
+		exerciseRepo:  exerciseRepo,
+		recordTracker: recordTracker,
+		achievements:  achievements,
+		goals:         goals,
+		storage:       storage,
 	}
 }
 
 type CreateWorkoutInput struct {
-	Date string           `json:"date" validate:"required"`
+	Date string           `json:"date" validate:"required,isodate"`
 	Memo *string          `json:"memo"`
 	Sets []CreateSetInput `json:"sets" validate:"required,min=1,dive"`
 }
 
+// CreateSetInput の Weight・Reps の上限は入力ミスを防ぐためのもの
+// Weight は脚のマシン種目などの高重量も含めて 600kg、Reps は自重種目の高回数も含めて 200回とする
 type CreateSetInput struct {
-	ExerciseID uint64  `json:"exercise_id" validate:"required"`
-	SetNumber  uint8   `json:"set_number" validate:"required,min=1"`
-	Weight     float64 `json:"weight" validate:"required,min=0"`
-	Reps       uint16  `json:"reps" validate:"required,min=1"`
+	ExerciseID uint64   `json:"exercise_id" validate:"required"`
+	SetNumber  uint8    `json:"set_number" validate:"required,min=1"`
+	Weight     float64  `json:"weight" validate:"min=0,max=600"`
+	Reps       uint16   `json:"reps" validate:"required,min=1,max=200"`
+	RPE        *float64 `json:"rpe" validate:"omitempty,min=1,max=10"`
 }
 
 type UpdateSetInput = CreateSetInput
@@ -42,13 +54,35 @@ type UpdateWorkoutInput struct {
 	Sets []UpdateSetInput `json:"sets" validate:"required,min=1,dive"`
 }
 
+// CreateExerciseInput の Muscles を省略した場合、作成時は MuscleGroup のみに関与する種目とし、更新時は変更しない
+// Equipment も省略した場合、作成時は器具なし、更新時は変更しない
+// Instructions・Cues・CommonMistakes も同様に、省略した場合は作成時は空、更新時は変更しない
 type CreateExerciseInput struct {
-	Name        string `json:"name" validate:"required,min=1,max=100"`
-	MuscleGroup string `json:"muscle_group" validate:"required"`
+	Name           string                `json:"name" validate:"required,min=1,max=100"`
+	MuscleGroup    string                `json:"muscle_group" validate:"required,musclegroup"`
+	Muscles        []ExerciseMuscleInput `json:"muscles" validate:"omitempty,dive"`
+	Equipment      []string              `json:"equipment"`
+	Instructions   []string              `json:"instructions"`
+	Cues           []string              `json:"cues"`
+	CommonMistakes []string              `json:"common_mistakes"`
+	// ParentID・ProgressesFromID はバリエーションの親と1つ前の段階（更新時に省略すると関係を解除する）
+	ParentID         *uint64 `json:"parent_id"`
+	ProgressesFromID *uint64 `json:"progresses_from_id"`
 }
 
 type UpdateExerciseInput = CreateExerciseInput
 
+// RepMaxEntry はレップ数ごとの自己ベスト（記録がないレップ数は nil）
+type RepMaxEntry struct {
+	Reps               uint16     `json:"reps"`
+	Weight             *float64   `json:"weight"`
+	Date               *time.Time `json:"date"`
+	EstimatedOneRepMax *float64   `json:"estimated_one_rep_max"`
+}
+
+// repMaxTableSize はレップ数ごとの自己ベスト表に含める最大レップ数
+const repMaxTableSize = 12
+
 type WorkoutListResponse struct {
 	Workouts   []model.Workout `json:"workouts"`
 	Total      int64           `json:"total"`
@@ -63,27 +97,46 @@ func (s *WorkoutService) CreateWorkout(userID uint64, input *CreateWorkoutInput)
 		return nil, fmt.Errorf("%w: %s", ErrInvalidDateFormat, input.Date)
 	}
 
+	sets, err := buildWorkoutSets(0, input.Sets)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkExerciseReferences(s.exerciseRepo, userID, setExerciseReferences(input.Sets)); err != nil {
+		return nil, err
+	}
+
 	workout := &model.Workout{
 		UserID: userID,
 		Date:   date,
 		Memo:   input.Memo,
 	}
 
-	sets := make([]*model.WorkoutSet, len(input.Sets))
-	for i, setInput := range input.Sets {
+	rebuild := s.recordTracker.Rebuild(userID, exerciseIDsOf(nil, sets), date)
+	if err := s.workoutRepo.CreateWithSets(workout, sets, rebuild); err != nil {
+		return nil, err
+	}
+
+	return s.findWithNewRecords(userID, workout.ID, nil)
+}
+
+// buildWorkoutSets は入力をセットに変換し、RPEが指定されていれば範囲を検証する
+// ハンドラーを通さない呼び出しでも不正な値を保存しないよう、入力の検証とは別に確認する
+func buildWorkoutSets(workoutID uint64, inputs []CreateSetInput) ([]*model.WorkoutSet, error) {
+	sets := make([]*model.WorkoutSet, len(inputs))
+	for i, setInput := range inputs {
+		if r := setInput.RPE; r != nil && (*r < 1 || *r > 10) {
+			return nil, fmt.Errorf("%w: set %d", ErrInvalidRPE, i+1)
+		}
 		sets[i] = &model.WorkoutSet{
+			WorkoutID:  workoutID,
 			ExerciseID: setInput.ExerciseID,
 			SetNumber:  setInput.SetNumber,
 			Weight:     setInput.Weight,
 			Reps:       setInput.Reps,
+			RPE:        setInput.RPE,
 		}
 	}
-
-	if err := s.workoutRepo.CreateWithSets(workout, sets); err != nil {
-		return nil, err
-	}
-
-	return s.workoutRepo.FindByID(workout.ID)
+	return sets, nil
 }
 
 func (s *WorkoutService) GetWorkout(userID, workoutID uint64) (*model.Workout, error) {
@@ -108,7 +161,14 @@ func (s *WorkoutService) GetWorkoutByDate(userID uint64, date string) (*model.Wo
 		return nil, fmt.Errorf("%w: %s", ErrInvalidDateFormat, date)
 	}
 
-	return s.workoutRepo.FindByUserIDAndDate(userID, dateTime)
+	workout, err := s.workoutRepo.FindByUserIDAndDate(userID, dateTime)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWorkoutNotFound
+		}
+		return nil, fmt.Errorf("finding workout: %w", err)
+	}
+	return workout, nil
 }
 
 func (s *WorkoutService) GetWorkoutList(userID uint64, page, perPage int) (*WorkoutListResponse, error) {
@@ -158,28 +218,33 @@ func (s *WorkoutService) UpdateWorkout(userID, workoutID uint64, input *UpdateWo
 		return nil, ErrUnauthorized
 	}
 
+	sets, err := buildWorkoutSets(workoutID, input.Sets)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkExerciseReferences(s.exerciseRepo, userID, setExerciseReferences(input.Sets)); err != nil {
+		return nil, err
+	}
+
 	workout.Memo = input.Memo
 
 	if err := s.workoutRepo.Update(workout); err != nil {
 		return nil, err
 	}
 
-	sets := make([]*model.WorkoutSet, len(input.Sets))
-	for i, setInput := range input.Sets {
-		sets[i] = &model.WorkoutSet{
-			WorkoutID:  workoutID,
-			ExerciseID: setInput.ExerciseID,
-			SetNumber:  setInput.SetNumber,
-			Weight:     setInput.Weight,
-			Reps:       setInput.Reps,
-		}
+	// 編集前から達成していた記録は新しい自己ベストとして返さない
+	before, err := s.recordTracker.RecordsForWorkout(workoutID)
+	if err != nil {
+		return nil, fmt.Errorf("finding personal records: %w", err)
 	}
 
-	if err := s.workoutRepo.ReplaceSetsByWorkoutID(workoutID, sets); err != nil {
+	// 変更前の種目も記録が変わりうるため再計算の対象に含める
+	rebuild := s.recordTracker.Rebuild(userID, exerciseIDsOf(workout.Sets, sets), workout.Date)
+	if err := s.workoutRepo.ReplaceSetsByWorkoutID(workoutID, sets, rebuild); err != nil {
 		return nil, err
 	}
 
-	return s.workoutRepo.FindByID(workoutID)
+	return s.findWithNewRecords(userID, workoutID, before)
 }
 
 func (s *WorkoutService) DeleteWorkout(userID, workoutID uint64) error {
@@ -195,15 +260,76 @@ func (s *WorkoutService) DeleteWorkout(userID, workoutID uint64) error {
 		return ErrUnauthorized
 	}
 
-	return s.workoutRepo.Delete(workoutID)
+	rebuild := s.recordTracker.Rebuild(userID, exerciseIDsOf(workout.Sets, nil), workout.Date)
+	if err := s.workoutRepo.Delete(workoutID, rebuild); err != nil {
+		return err
+	}
+
+	// 獲得済みの実績は取り消さないが、削除で記録が変わるため未獲得の実績は判定し直す
+	evaluateAfterSave(s.achievements, s.goals, userID)
+	return nil
 }
 
-func (s *WorkoutService) GetExercises(userID uint64) ([]model.Exercise, error) {
-	return s.exerciseRepo.FindAll(userID)
+// findWithNewRecords はワークアウトと、そのワークアウトで更新した自己ベスト・新たに獲得した実績を返す
+// あわせて目標の達成を判定する
+// before には保存前からワークアウトで達成していた自己ベストを渡し、それらは返さない
+func (s *WorkoutService) findWithNewRecords(userID, workoutID uint64, before []model.PersonalRecord) (*model.Workout, error) {
+	workout, err := s.workoutRepo.FindByID(workoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.recordTracker.RecordsForWorkout(workoutID)
+	if err != nil {
+		return nil, fmt.Errorf("finding personal records: %w", err)
+	}
+	workout.NewPersonalRecords = newlyAchieved(records, before)
+
+	workout.NewAchievements = evaluateAfterSave(s.achievements, s.goals, userID)
+	return workout, nil
+}
+
+func (s *WorkoutService) GetExercises(userID uint64, query ExerciseQuery) ([]model.Exercise, error) {
+	filter, err := query.filter()
+	if err != nil {
+		return nil, err
+	}
+	return s.exerciseRepo.FindFiltered(userID, filter)
+}
+
+// AddFavorite は種目をお気に入りに登録する
+func (s *WorkoutService) AddFavorite(userID, exerciseID uint64) error {
+	if _, err := s.findVisibleExercise(userID, exerciseID); err != nil {
+		return err
+	}
+	return s.exerciseRepo.AddFavorite(userID, exerciseID)
+}
+
+// RemoveFavorite は種目をお気に入りから外す
+func (s *WorkoutService) RemoveFavorite(userID, exerciseID uint64) error {
+	if _, err := s.findVisibleExercise(userID, exerciseID); err != nil {
+		return err
+	}
+	return s.exerciseRepo.RemoveFavorite(userID, exerciseID)
 }
 
-func (s *WorkoutService) GetExercisesByMuscleGroup(userID uint64, muscleGroup string) ([]model.Exercise, error) {
-	return s.exerciseRepo.FindByMuscleGroup(model.MuscleGroup(muscleGroup), userID)
+// findVisibleExercise はプリセット種目かユーザー自身のカスタム種目を取得する
+func (s *WorkoutService) findVisibleExercise(userID, exerciseID uint64) (*model.Exercise, error) {
+	return findVisibleExercise(s.exerciseRepo, userID, exerciseID)
+}
+
+func findVisibleExercise(exerciseRepo ExerciseRepository, userID, exerciseID uint64) (*model.Exercise, error) {
+	exercise, err := exerciseRepo.FindByID(exerciseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExerciseNotFound
+		}
+		return nil, fmt.Errorf("finding exercise: %w", err)
+	}
+	if exercise.IsCustom && (exercise.UserID == nil || *exercise.UserID != userID) {
+		return nil, ErrExerciseNotFound
+	}
+	return exercise, nil
 }
 
 // カレンダー用：月別ワークアウト取得
@@ -212,18 +338,74 @@ func (s *WorkoutService) GetWorkoutsByMonth(userID uint64, year, month int) ([]m
 }
 
 // 統計：部位別集計
-func (s *WorkoutService) GetMuscleGroupStats(userID uint64) ([]repository.MuscleGroupStat, error) {
-	return s.workoutRepo.GetMuscleGroupStats(userID)
+func (s *WorkoutService) GetMuscleGroupStats(userID uint64, params StatsParams) ([]repository.MuscleGroupStat, error) {
+	filter, err := params.filter()
+	if err != nil {
+		return nil, err
+	}
+	return s.workoutRepo.GetMuscleGroupStats(userID, filter)
 }
 
 // 統計：自己ベスト一覧
-func (s *WorkoutService) GetPersonalBests(userID uint64) ([]repository.PersonalBest, error) {
-	return s.workoutRepo.GetPersonalBests(userID)
+func (s *WorkoutService) GetPersonalBests(userID uint64, params StatsParams) ([]repository.PersonalBest, error) {
+	filter, err := params.filter()
+	if err != nil {
+		return nil, err
+	}
+	formula, err := params.formula()
+	if err != nil {
+		return nil, err
+	}
+	return s.workoutRepo.GetPersonalBests(userID, formula, filter)
 }
 
 // 統計：種目の重量推移
-func (s *WorkoutService) GetExerciseProgress(userID uint64, exerciseID uint64) ([]repository.ExerciseProgress, error) {
-	return s.workoutRepo.GetExerciseProgress(userID, exerciseID)
+func (s *WorkoutService) GetExerciseProgress(userID uint64, exerciseID uint64, params StatsParams) ([]repository.ExerciseProgress, error) {
+	filter, err := params.filter()
+	if err != nil {
+		return nil, err
+	}
+	formula, err := params.formula()
+	if err != nil {
+		return nil, err
+	}
+	return s.workoutRepo.GetExerciseProgress(userID, exerciseID, formula, filter)
+}
+
+// 統計：自己ベストの更新履歴
+func (s *WorkoutService) GetPersonalRecordHistory(userID uint64, exerciseID uint64) ([]model.PersonalRecord, error) {
+	return s.recordTracker.History(userID, exerciseID)
+}
+
+// 統計：レップ数ごとの自己ベスト表（1〜12レップ）
+func (s *WorkoutService) GetRepMaxes(userID uint64, exerciseID uint64, formula string) ([]RepMaxEntry, error) {
+	f, err := ParseOneRepMaxFormula(formula)
+	if err != nil {
+		return nil, err
+	}
+
+	repMaxes, err := s.workoutRepo.GetRepMaxes(userID, exerciseID, repMaxTableSize)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]RepMaxEntry, repMaxTableSize)
+	for i := range entries {
+		entries[i].Reps = uint16(i + 1)
+	}
+	for _, rm := range repMaxes {
+		if rm.Reps < 1 || int(rm.Reps) > repMaxTableSize {
+			continue
+		}
+		weight, date := rm.Weight, rm.Date
+		e1rm := f.Estimate(rm.Weight, rm.Reps)
+		entry := &entries[rm.Reps-1]
+		entry.Weight = &weight
+		entry.Date = &date
+		entry.EstimatedOneRepMax = &e1rm
+	}
+
+	return entries, nil
 }
 
 // カスタム種目作成
@@ -231,15 +413,36 @@ func (s *WorkoutService) CreateCustomExercise(userID uint64, input *CreateExerci
 	exercise := &model.Exercise{
 		Name:        input.Name,
 		MuscleGroup: model.MuscleGroup(input.MuscleGroup),
+		Equipment:   model.EquipmentList{},
 		IsCustom:    true,
 		UserID:      &userID,
 	}
+	if input.Equipment != nil {
+		equipment, err := ParseEquipment(input.Equipment)
+		if err != nil {
+			return nil, err
+		}
+		exercise.Equipment = equipment
+	}
+	if input.Muscles != nil {
+		muscles, err := s.exerciseMuscles(input.Muscles)
+		if err != nil {
+			return nil, err
+		}
+		exercise.Muscles = muscles
+	}
+	if err := applyExerciseContent(exercise, input); err != nil {
+		return nil, err
+	}
+	if err := s.applyExerciseRelations(userID, exercise, input); err != nil {
+		return nil, err
+	}
 
 	if err := s.exerciseRepo.Create(exercise); err != nil {
 		return nil, err
 	}
 
-	return exercise, nil
+	return s.exerciseRepo.FindByID(exercise.ID)
 }
 
 func (s *WorkoutService) GetCustomExercises(userID uint64) ([]model.Exercise, error) {
@@ -261,7 +464,71 @@ func (s *WorkoutService) UpdateCustomExercise(userID uint64, exerciseID uint64,
 
 	exercise.Name = input.Name
 	exercise.MuscleGroup = model.MuscleGroup(input.MuscleGroup)
+	if input.Equipment != nil {
+		if exercise.Equipment, err = ParseEquipment(input.Equipment); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyExerciseContent(exercise, input); err != nil {
+		return nil, err
+	}
+	if err := s.applyExerciseRelations(userID, exercise, input); err != nil {
+		return nil, err
+	}
+
+	var muscles []model.ExerciseMuscle
+	if input.Muscles != nil {
+		if muscles, err = s.exerciseMuscles(input.Muscles); err != nil {
+			return nil, err
+		}
+	}
 
+	if err := s.exerciseRepo.Update(exercise); err != nil {
+		return nil, err
+	}
+	if input.Muscles != nil {
+		if err := s.exerciseRepo.ReplaceMuscles(exerciseID, muscles); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.exerciseRepo.FindByID(exerciseID)
+}
+
+// ArchiveCustomExercise は記録のあるカスタム種目を選択肢から外す（過去の記録や統計には残る）
+func (s *WorkoutService) ArchiveCustomExercise(userID uint64, exerciseID uint64) (*model.Exercise, error) {
+	return s.setArchived(userID, exerciseID, true)
+}
+
+// UnarchiveCustomExercise はアーカイブしたカスタム種目を選択肢に戻す
+func (s *WorkoutService) UnarchiveCustomExercise(userID uint64, exerciseID uint64) (*model.Exercise, error) {
+	return s.setArchived(userID, exerciseID, false)
+}
+
+func (s *WorkoutService) setArchived(userID uint64, exerciseID uint64, archived bool) (*model.Exercise, error) {
+	exercise, err := s.exerciseRepo.FindByID(exerciseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExerciseNotFound
+		}
+		return nil, fmt.Errorf("finding exercise: %w", err)
+	}
+
+	if !exercise.IsCustom || exercise.UserID == nil || *exercise.UserID != userID {
+		return nil, ErrNotCustomExercise
+	}
+
+	// アーカイブ済みの種目を再度アーカイブしても日時は変えない
+	if archived == (exercise.ArchivedAt != nil) {
+		return exercise, nil
+	}
+	if archived {
+		now := time.Now()
+		exercise.ArchivedAt = &now
+	} else {
+		exercise.ArchivedAt = nil
+	}
 	if err := s.exerciseRepo.Update(exercise); err != nil {
 		return nil, err
 	}
@@ -290,5 +557,49 @@ func (s *WorkoutService) DeleteCustomExercise(userID uint64, exerciseID uint64)
 		return ErrExerciseInUse
 	}
 
-	return s.exerciseRepo.Delete(exerciseID)
+	// 画像・動画のレコードは種目と一緒に削除されるため、ファイルは削除後に消す
+	if err := s.exerciseRepo.Delete(exerciseID); err != nil {
+		return err
+	}
+	mediaKeys := make([]string, len(exercise.Media))
+	for i, media := range exercise.Media {
+		mediaKeys[i] = media.StorageKey
+	}
+	deleteMediaFiles(s.storage, mediaKeys)
+	return nil
+}
+
+// MergeCustomExercise はカスタム種目 sourceID をプリセット種目か別のカスタム種目 targetID に統合する
+// 重複して作った種目の記録をまとめたうえで統合元を削除する
+func (s *WorkoutService) MergeCustomExercise(userID, sourceID, targetID uint64) (*model.Exercise, error) {
+	source, err := s.exerciseRepo.FindByID(sourceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExerciseNotFound
+		}
+		return nil, fmt.Errorf("finding exercise: %w", err)
+	}
+	if !source.IsCustom || source.UserID == nil || *source.UserID != userID {
+		return nil, ErrNotCustomExercise
+	}
+	if sourceID == targetID {
+		return nil, fmt.Errorf("%w: cannot merge an exercise into itself", ErrInvalidMergeTarget)
+	}
+	target, err := s.findVisibleExercise(userID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if target.ArchivedAt != nil {
+		return nil, fmt.Errorf("%w: cannot merge into an archived exercise", ErrInvalidMergeTarget)
+	}
+
+	// 統合先の自己ベスト履歴は統合したセットを含めて全期間作り直す
+	rebuild := s.recordTracker.Rebuild(userID, []uint64{targetID}, time.Time{})
+	removedMedia, err := s.exerciseRepo.Merge(userID, sourceID, targetID, rebuild)
+	if err != nil {
+		return nil, fmt.Errorf("merging exercises: %w", err)
+	}
+	deleteMediaFiles(s.storage, removedMedia)
+
+	return s.exerciseRepo.FindByID(targetID)
 }
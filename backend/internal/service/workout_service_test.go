@@ -1,19 +1,30 @@
 package service
 
 import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/training-memo/backend/internal/model"
 	"github.com/training-memo/backend/internal/repository"
+	"gorm.io/gorm"
 )
 
 // MockWorkoutRepository はテスト用のモックリポジトリ
 type MockWorkoutRepository struct {
-	workouts map[uint64]*model.Workout
-	sets     map[uint64]*model.WorkoutSet
-	nextID   uint64
+	workouts  map[uint64]*model.Workout
+	sets      map[uint64]*model.WorkoutSet
+	nextID    uint64
 	nextSetID uint64
+	// recentSetQueries は GetRecentSets の呼び出し回数
+	recentSetQueries int
+	// records は自己ベストの再計算の結果を保存する
+	records *MockPersonalRecordRepository
+	// rebuildErr は自己ベストの再計算で返すエラー（トランザクションの失敗の確認用）
+	rebuildErr error
 }
 
 func NewMockWorkoutRepository() *MockWorkoutRepository {
@@ -22,6 +33,7 @@ func NewMockWorkoutRepository() *MockWorkoutRepository {
 		sets:      make(map[uint64]*model.WorkoutSet),
 		nextID:    1,
 		nextSetID: 1,
+		records:   &MockPersonalRecordRepository{},
 	}
 }
 
@@ -86,7 +98,7 @@ func (r *MockWorkoutRepository) Update(workout *model.Workout) error {
 	return nil
 }
 
-func (r *MockWorkoutRepository) Delete(id uint64) error {
+func (r *MockWorkoutRepository) Delete(id uint64, rebuild *repository.RecordRebuild) error {
 	delete(r.workouts, id)
 	// セットも削除
 	for setID, set := range r.sets {
@@ -94,7 +106,7 @@ func (r *MockWorkoutRepository) Delete(id uint64) error {
 			delete(r.sets, setID)
 		}
 	}
-	return nil
+	return r.rebuildPersonalRecords(rebuild)
 }
 
 func (r *MockWorkoutRepository) AddSet(set *model.WorkoutSet) error {
@@ -124,6 +136,72 @@ func (r *MockWorkoutRepository) DeleteSetsByWorkoutID(workoutID uint64) error {
 	return nil
 }
 
+func (r *MockWorkoutRepository) CreateWithSets(workout *model.Workout, sets []*model.WorkoutSet, rebuild *repository.RecordRebuild) error {
+	r.Create(workout)
+	for _, set := range sets {
+		set.WorkoutID = workout.ID
+		r.AddSet(set)
+	}
+	return r.rebuildPersonalRecords(rebuild)
+}
+
+func (r *MockWorkoutRepository) ReplaceSetsByWorkoutID(workoutID uint64, sets []*model.WorkoutSet, rebuild *repository.RecordRebuild) error {
+	r.DeleteSetsByWorkoutID(workoutID)
+	for _, set := range sets {
+		r.AddSet(set)
+	}
+	return r.rebuildPersonalRecords(rebuild)
+}
+
+// rebuildPersonalRecords は From 以降の自己ベストを r.records に作り直す（rebuildErr がある場合は失敗する）
+func (r *MockWorkoutRepository) rebuildPersonalRecords(rebuild *repository.RecordRebuild) error {
+	if rebuild == nil {
+		return nil
+	}
+	if r.rebuildErr != nil {
+		return r.rebuildErr
+	}
+	for _, exerciseID := range rebuild.ExerciseIDs {
+		baseline := repository.RecordBaseline{RepsByWeight: make(map[float64]uint16)}
+		var kept []model.PersonalRecord
+		for _, record := range r.records.records {
+			if record.UserID != rebuild.UserID || record.ExerciseID != exerciseID {
+				kept = append(kept, record)
+				continue
+			}
+			if !record.AchievedOn.Before(rebuild.From) {
+				continue
+			}
+			kept = append(kept, record)
+			switch record.RecordType {
+			case model.RecordTypeMaxWeight:
+				baseline.BestWeight = math.Max(baseline.BestWeight, record.Value)
+			case model.RecordTypeEstimatedOneRepMax:
+				baseline.BestE1RM = math.Max(baseline.BestE1RM, record.Value)
+			case model.RecordTypeSessionVolume:
+				baseline.BestVolume = math.Max(baseline.BestVolume, record.Value)
+			}
+		}
+
+		var history []repository.SetHistory
+		for _, set := range r.setHistory(rebuild.UserID, exerciseID) {
+			if set.Date.Before(rebuild.From) {
+				if set.Reps > baseline.RepsByWeight[set.Weight] {
+					baseline.RepsByWeight[set.Weight] = set.Reps
+				}
+				continue
+			}
+			history = append(history, set)
+		}
+
+		for _, record := range rebuild.Detect(exerciseID, baseline, history) {
+			kept = append(kept, *record)
+		}
+		r.records.records = kept
+	}
+	return nil
+}
+
 func (r *MockWorkoutRepository) FindByUserIDAndMonth(userID uint64, year, month int) ([]model.Workout, error) {
 	var workouts []model.Workout
 	for _, workout := range r.workouts {
@@ -136,34 +214,276 @@ func (r *MockWorkoutRepository) FindByUserIDAndMonth(userID uint64, year, month
 	return workouts, nil
 }
 
-func (r *MockWorkoutRepository) GetMuscleGroupStats(userID uint64) ([]repository.MuscleGroupStat, error) {
+func (r *MockWorkoutRepository) GetTrainingTotals(userID uint64, filter repository.StatsFilter) (*repository.TrainingTotals, error) {
+	totals := &repository.TrainingTotals{}
+	sessions := make(map[uint64]bool)
+	for _, set := range r.sets {
+		workout, ok := r.workouts[set.WorkoutID]
+		if !ok || workout.UserID != userID {
+			continue
+		}
+		if (filter.From != nil && workout.Date.Before(*filter.From)) || (filter.To != nil && workout.Date.After(*filter.To)) {
+			continue
+		}
+		sessions[workout.ID] = true
+		totals.Sets++
+		totals.Reps += int64(set.Reps)
+		totals.Volume += set.Weight * float64(set.Reps)
+	}
+	totals.Sessions = int64(len(sessions))
+	return totals, nil
+}
+
+func (r *MockWorkoutRepository) GetDailyActivity(userID uint64, filter repository.StatsFilter) ([]repository.DailyActivity, error) {
+	byDate := make(map[time.Time]*repository.DailyActivity)
+	sessions := make(map[time.Time]map[uint64]bool)
+	for _, set := range r.sets {
+		workout, ok := r.workouts[set.WorkoutID]
+		if !ok || workout.UserID != userID {
+			continue
+		}
+		day, ok := byDate[workout.Date]
+		if !ok {
+			day = &repository.DailyActivity{Date: workout.Date}
+			byDate[workout.Date] = day
+			sessions[workout.Date] = make(map[uint64]bool)
+		}
+		sessions[workout.Date][workout.ID] = true
+		day.Sessions = len(sessions[workout.Date])
+		day.Sets++
+		day.Volume += set.Weight * float64(set.Reps)
+	}
+	activity := []repository.DailyActivity{}
+	for _, day := range byDate {
+		activity = append(activity, *day)
+	}
+	sort.Slice(activity, func(i, j int) bool { return activity[i].Date.Before(activity[j].Date) })
+	return activity, nil
+}
+
+func (r *MockWorkoutRepository) GetWorkoutVolumes(userID uint64) ([]repository.WorkoutVolume, error) {
+	byWorkout := make(map[uint64]*repository.WorkoutVolume)
+	for _, set := range r.sets {
+		workout, ok := r.workouts[set.WorkoutID]
+		if !ok || workout.UserID != userID {
+			continue
+		}
+		if _, ok := byWorkout[workout.ID]; !ok {
+			byWorkout[workout.ID] = &repository.WorkoutVolume{WorkoutID: workout.ID, Date: workout.Date}
+		}
+		byWorkout[workout.ID].Volume += set.Weight * float64(set.Reps)
+	}
+	volumes := []repository.WorkoutVolume{}
+	for _, v := range byWorkout {
+		volumes = append(volumes, *v)
+	}
+	sort.Slice(volumes, func(i, j int) bool {
+		if !volumes[i].Date.Equal(volumes[j].Date) {
+			return volumes[i].Date.Before(volumes[j].Date)
+		}
+		return volumes[i].WorkoutID < volumes[j].WorkoutID
+	})
+	return volumes, nil
+}
+
+func (r *MockWorkoutRepository) GetDailyMuscleGroupLoad(userID uint64, filter repository.StatsFilter) ([]repository.DailyMuscleGroupLoad, error) {
+	return []repository.DailyMuscleGroupLoad{}, nil
+}
+
+func (r *MockWorkoutRepository) GetMuscleGroupStats(userID uint64, filter repository.StatsFilter) ([]repository.MuscleGroupStat, error) {
 	return []repository.MuscleGroupStat{}, nil
 }
 
-func (r *MockWorkoutRepository) GetPersonalBests(userID uint64) ([]repository.PersonalBest, error) {
+func (r *MockWorkoutRepository) GetMuscleStats(userID uint64, filter repository.StatsFilter) ([]repository.MuscleStat, error) {
+	return []repository.MuscleStat{}, nil
+}
+
+func (r *MockWorkoutRepository) GetPersonalBests(userID uint64, formula model.OneRepMaxFormula, filter repository.StatsFilter) ([]repository.PersonalBest, error) {
 	return []repository.PersonalBest{}, nil
 }
 
-func (r *MockWorkoutRepository) GetExerciseProgress(userID uint64, exerciseID uint64) ([]repository.ExerciseProgress, error) {
-	return []repository.ExerciseProgress{}, nil
+// GetExerciseProgress は日ごとの推移を返す（集計単位の指定は使わない）
+func (r *MockWorkoutRepository) GetExerciseProgress(userID uint64, exerciseID uint64, formula model.OneRepMaxFormula, filter repository.StatsFilter) ([]repository.ExerciseProgress, error) {
+	byDate := make(map[time.Time]*repository.ExerciseProgress)
+	for _, set := range r.sets {
+		workout, ok := r.workouts[set.WorkoutID]
+		if !ok || workout.UserID != userID || set.ExerciseID != exerciseID {
+			continue
+		}
+		if (filter.From != nil && workout.Date.Before(*filter.From)) || (filter.To != nil && workout.Date.After(*filter.To)) {
+			continue
+		}
+		day, ok := byDate[workout.Date]
+		if !ok {
+			day = &repository.ExerciseProgress{Date: workout.Date}
+			byDate[workout.Date] = day
+		}
+		day.MaxWeight = math.Max(day.MaxWeight, set.Weight)
+		day.TotalVolume += set.Weight * float64(set.Reps)
+		day.EstimatedOneRepMax = math.Max(day.EstimatedOneRepMax, formula.Estimate(set.Weight, set.Reps))
+	}
+	progress := []repository.ExerciseProgress{}
+	for _, day := range byDate {
+		progress = append(progress, *day)
+	}
+	sort.Slice(progress, func(i, j int) bool { return progress[i].Date.Before(progress[j].Date) })
+	return progress, nil
+}
+
+// setHistory は種目の全セットを日付順に返す
+func (r *MockWorkoutRepository) setHistory(userID uint64, exerciseID uint64) []repository.SetHistory {
+	var history []repository.SetHistory
+	for _, set := range r.sets {
+		workout, ok := r.workouts[set.WorkoutID]
+		if !ok || workout.UserID != userID || set.ExerciseID != exerciseID {
+			continue
+		}
+		history = append(history, repository.SetHistory{
+			WorkoutID: set.WorkoutID,
+			SetID:     set.ID,
+			Date:      workout.Date,
+			SetNumber: set.SetNumber,
+			Weight:    set.Weight,
+			Reps:      set.Reps,
+		})
+	}
+	sort.Slice(history, func(i, j int) bool {
+		if !history[i].Date.Equal(history[j].Date) {
+			return history[i].Date.Before(history[j].Date)
+		}
+		if history[i].WorkoutID != history[j].WorkoutID {
+			return history[i].WorkoutID < history[j].WorkoutID
+		}
+		return history[i].SetNumber < history[j].SetNumber
+	})
+	return history
+}
+
+func (r *MockWorkoutRepository) GetBestOneRepMaxDays(userID uint64, exerciseIDs []uint64, formula model.OneRepMaxFormula, filter repository.StatsFilter) ([]repository.ExerciseOneRepMax, error) {
+	var bests []repository.ExerciseOneRepMax
+	for _, exerciseID := range exerciseIDs {
+		var best *repository.ExerciseOneRepMax
+		for _, set := range r.sets {
+			workout, ok := r.workouts[set.WorkoutID]
+			if !ok || workout.UserID != userID || set.ExerciseID != exerciseID {
+				continue
+			}
+			e1rm := formula.Estimate(set.Weight, set.Reps)
+			if best == nil || e1rm > best.EstimatedOneRepMax || (e1rm == best.EstimatedOneRepMax && workout.Date.Before(best.Date)) {
+				best = &repository.ExerciseOneRepMax{ExerciseID: exerciseID, Date: workout.Date, EstimatedOneRepMax: e1rm}
+			}
+		}
+		if best != nil {
+			bests = append(bests, *best)
+		}
+	}
+	return bests, nil
+}
+
+func (r *MockWorkoutRepository) GetActiveOneRepMaxHistories(userID uint64, formula model.OneRepMaxFormula, since time.Time) ([]repository.ExerciseOneRepMax, error) {
+	type key struct {
+		exerciseID uint64
+		date       time.Time
+	}
+	best := make(map[key]float64)
+	last := make(map[uint64]time.Time)
+	for _, set := range r.sets {
+		workout, ok := r.workouts[set.WorkoutID]
+		if !ok || workout.UserID != userID {
+			continue
+		}
+		k := key{set.ExerciseID, workout.Date}
+		best[k] = math.Max(best[k], formula.Estimate(set.Weight, set.Reps))
+		if workout.Date.After(last[set.ExerciseID]) {
+			last[set.ExerciseID] = workout.Date
+		}
+	}
+	var history []repository.ExerciseOneRepMax
+	for k, e1rm := range best {
+		if last[k.exerciseID].Before(since) {
+			continue
+		}
+		history = append(history, repository.ExerciseOneRepMax{ExerciseID: k.exerciseID, Date: k.date, EstimatedOneRepMax: e1rm})
+	}
+	sort.Slice(history, func(i, j int) bool {
+		if history[i].ExerciseID != history[j].ExerciseID {
+			return history[i].ExerciseID < history[j].ExerciseID
+		}
+		return history[i].Date.Before(history[j].Date)
+	})
+	return history, nil
+}
+
+func (r *MockWorkoutRepository) GetRepMaxes(userID uint64, exerciseID uint64, maxReps int) ([]repository.RepMax, error) {
+	best := make(map[uint16]repository.RepMax)
+	for _, set := range r.sets {
+		workout, ok := r.workouts[set.WorkoutID]
+		if !ok || workout.UserID != userID || set.ExerciseID != exerciseID || int(set.Reps) > maxReps {
+			continue
+		}
+		if current, ok := best[set.Reps]; !ok || set.Weight > current.Weight {
+			best[set.Reps] = repository.RepMax{Reps: set.Reps, Weight: set.Weight, Date: workout.Date}
+		}
+	}
+	var repMaxes []repository.RepMax
+	for _, rm := range best {
+		repMaxes = append(repMaxes, rm)
+	}
+	return repMaxes, nil
+}
+
+func (r *MockWorkoutRepository) GetRecentSets(userID uint64, exerciseIDs []uint64, limit int) ([]model.WorkoutSet, error) {
+	r.recentSetQueries++
+	wanted := make(map[uint64]bool, len(exerciseIDs))
+	for _, id := range exerciseIDs {
+		wanted[id] = true
+	}
+	counts := make(map[uint64]int)
+	var sets []model.WorkoutSet
+	for _, set := range r.sets {
+		workout, ok := r.workouts[set.WorkoutID]
+		if !ok || workout.UserID != userID || !wanted[set.ExerciseID] || counts[set.ExerciseID] >= limit {
+			continue
+		}
+		counts[set.ExerciseID]++
+		sets = append(sets, *set)
+	}
+	return sets, nil
 }
 
 // MockExerciseRepository はテスト用のモックリポジトリ
 type MockExerciseRepository struct {
 	exercises map[uint64]*model.Exercise
 	nextID    uint64
+	// availableEquipment はユーザーの使える器具（nil の場合は制限しない）
+	availableEquipment model.EquipmentList
+	favorites          map[uint64]bool
+	aliases            map[uint64]*model.ExerciseAlias
+	nextAliasID        uint64
+	// workouts は種目の統合でセットを付け替える対象（nil の場合は付け替えない）
+	workouts     *MockWorkoutRepository
+	media        map[uint64]*model.ExerciseMedia
+	nextMediaID  uint64
+	translations []model.ExerciseTranslation
 }
 
+func presetKey(key string) *string { return &key }
+
 func NewMockExerciseRepository() *MockExerciseRepository {
 	repo := &MockExerciseRepository{
-		exercises: make(map[uint64]*model.Exercise),
-		nextID:    1,
+		exercises:   make(map[uint64]*model.Exercise),
+		nextID:      1,
+		favorites:   make(map[uint64]bool),
+		aliases:     make(map[uint64]*model.ExerciseAlias),
+		nextAliasID: 1,
+		media:       make(map[uint64]*model.ExerciseMedia),
+		nextMediaID: 1,
 	}
 	// プリセット種目を追加
 	presets := []model.Exercise{
-		{Name: "ベンチプレス", MuscleGroup: model.MuscleGroupChest, IsCustom: false},
-		{Name: "スクワット", MuscleGroup: model.MuscleGroupLegs, IsCustom: false},
-		{Name: "デッドリフト", MuscleGroup: model.MuscleGroupBack, IsCustom: false},
+		{Name: "ベンチプレス", MuscleGroup: model.MuscleGroupChest, Equipment: model.EquipmentList{model.EquipmentBarbell}, IsCustom: false, PresetKey: presetKey("bench_press")},
+		{Name: "スクワット", MuscleGroup: model.MuscleGroupLegs, Equipment: model.EquipmentList{model.EquipmentBarbell}, IsCustom: false, PresetKey: presetKey("squat")},
+		{Name: "デッドリフト", MuscleGroup: model.MuscleGroupBack, Equipment: model.EquipmentList{model.EquipmentBarbell}, IsCustom: false, PresetKey: presetKey("deadlift")},
 	}
 	for _, e := range presets {
 		exercise := e
@@ -177,13 +497,155 @@ func NewMockExerciseRepository() *MockExerciseRepository {
 func (r *MockExerciseRepository) FindAll(userID uint64) ([]model.Exercise, error) {
 	var exercises []model.Exercise
 	for _, e := range r.exercises {
-		if !e.IsCustom || (e.UserID != nil && *e.UserID == userID) {
+		if e.ArchivedAt == nil && (!e.IsCustom || (e.UserID != nil && *e.UserID == userID)) {
 			exercises = append(exercises, *e)
 		}
 	}
 	return exercises, nil
 }
 
+func (r *MockExerciseRepository) FindFiltered(userID uint64, filter repository.ExerciseFilter) ([]model.Exercise, error) {
+	var exercises []model.Exercise
+	for id := uint64(1); id < r.nextID; id++ {
+		e, ok := r.exercises[id]
+		if !ok || e.ArchivedAt != nil || (e.IsCustom && (e.UserID == nil || *e.UserID != userID)) {
+			continue
+		}
+		if filter.MuscleGroup != "" && e.MuscleGroup != filter.MuscleGroup {
+			continue
+		}
+		if len(filter.Equipment) > 0 {
+			matched := false
+			for _, eq := range e.Equipment {
+				matched = matched || filter.Equipment.Contains(eq)
+			}
+			if !matched {
+				continue
+			}
+		}
+		if filter.AvailableOnly && !r.availableEquipment.Allows(e.Equipment) {
+			continue
+		}
+		exercise := *e
+		exercise.IsFavorite = r.favorites[id]
+		exercises = append(exercises, exercise)
+	}
+	if filter.Sort == repository.ExerciseSortFavorite {
+		sort.SliceStable(exercises, func(i, j int) bool {
+			return exercises[i].IsFavorite && !exercises[j].IsFavorite
+		})
+	}
+	return exercises, nil
+}
+
+func (r *MockExerciseRepository) AddFavorite(userID, exerciseID uint64) error {
+	r.favorites[exerciseID] = true
+	return nil
+}
+
+func (r *MockExerciseRepository) RemoveFavorite(userID, exerciseID uint64) error {
+	delete(r.favorites, exerciseID)
+	return nil
+}
+
+// Search は種目名か別名に検索語を含む種目を一致度1として返す
+func (r *MockExerciseRepository) Search(userID uint64, query string, limit int) ([]repository.ExerciseMatch, error) {
+	var matches []repository.ExerciseMatch
+	for id := uint64(1); id < r.nextID && len(matches) < limit; id++ {
+		e, ok := r.exercises[id]
+		if !ok || e.ArchivedAt != nil || (e.IsCustom && (e.UserID == nil || *e.UserID != userID)) {
+			continue
+		}
+		matched := strings.Contains(strings.ToLower(e.Name), query)
+		for _, a := range r.aliases {
+			if a.ExerciseID == id && (a.UserID == nil || *a.UserID == userID) {
+				matched = matched || strings.Contains(a.Alias, query)
+			}
+		}
+		if matched {
+			matches = append(matches, repository.ExerciseMatch{ExerciseID: id, Score: 1})
+		}
+	}
+	return matches, nil
+}
+
+func (r *MockExerciseRepository) FindAliases(userID, exerciseID uint64) ([]model.ExerciseAlias, error) {
+	var aliases []model.ExerciseAlias
+	for id := uint64(1); id < r.nextAliasID; id++ {
+		a, ok := r.aliases[id]
+		if ok && a.ExerciseID == exerciseID && (a.UserID == nil || *a.UserID == userID) {
+			aliases = append(aliases, *a)
+		}
+	}
+	return aliases, nil
+}
+
+func (r *MockExerciseRepository) FindAliasByID(id uint64) (*model.ExerciseAlias, error) {
+	a, ok := r.aliases[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return a, nil
+}
+
+func (r *MockExerciseRepository) CreateAlias(alias *model.ExerciseAlias) error {
+	alias.ID = r.nextAliasID
+	r.nextAliasID++
+	r.aliases[alias.ID] = alias
+	return nil
+}
+
+func (r *MockExerciseRepository) DeleteAlias(id uint64) error {
+	delete(r.aliases, id)
+	return nil
+}
+
+func (r *MockExerciseRepository) FindMediaByID(id uint64) (*model.ExerciseMedia, error) {
+	m, ok := r.media[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return m, nil
+}
+
+// CreateMedia は種目の Media にも追加して FindByID で取得できるようにする
+func (r *MockExerciseRepository) CreateMedia(media *model.ExerciseMedia) error {
+	exercise := r.exercises[media.ExerciseID]
+	media.ID = r.nextMediaID
+	media.Position = len(exercise.Media)
+	r.nextMediaID++
+	r.media[media.ID] = media
+	exercise.Media = append(exercise.Media, *media)
+	return nil
+}
+
+func (r *MockExerciseRepository) DeleteMedia(id uint64) error {
+	m, ok := r.media[id]
+	if !ok {
+		return nil
+	}
+	exercise := r.exercises[m.ExerciseID]
+	var kept []model.ExerciseMedia
+	for _, em := range exercise.Media {
+		if em.ID != id {
+			kept = append(kept, em)
+		}
+	}
+	exercise.Media = kept
+	delete(r.media, id)
+	return nil
+}
+
+func (r *MockExerciseRepository) CountMedia(exerciseID uint64) (int64, error) {
+	var count int64
+	for _, m := range r.media {
+		if m.ExerciseID == exerciseID {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (r *MockExerciseRepository) FindByID(id uint64) (*model.Exercise, error) {
 	e, ok := r.exercises[id]
 	if !ok {
@@ -195,7 +657,7 @@ func (r *MockExerciseRepository) FindByID(id uint64) (*model.Exercise, error) {
 func (r *MockExerciseRepository) FindByMuscleGroup(muscleGroup model.MuscleGroup, userID uint64) ([]model.Exercise, error) {
 	var exercises []model.Exercise
 	for _, e := range r.exercises {
-		if e.MuscleGroup == muscleGroup {
+		if e.MuscleGroup == muscleGroup && e.ArchivedAt == nil {
 			exercises = append(exercises, *e)
 		}
 	}
@@ -215,6 +677,11 @@ func (r *MockExerciseRepository) Update(exercise *model.Exercise) error {
 }
 
 func (r *MockExerciseRepository) Delete(id uint64) error {
+	for mediaID, media := range r.media {
+		if media.ExerciseID == id {
+			delete(r.media, mediaID)
+		}
+	}
 	delete(r.exercises, id)
 	return nil
 }
@@ -233,6 +700,169 @@ func (r *MockExerciseRepository) IsUsedInWorkouts(exerciseID uint64) (bool, erro
 	return false, nil
 }
 
+// Merge は統合元の種目を削除し、workouts にあるユーザーのセットを統合先のセットの後ろに付け替える
+// 自己ベストの再計算に失敗した場合は付け替えを元に戻す（トランザクションのロールバック）
+func (r *MockExerciseRepository) Merge(userID, sourceID, targetID uint64, rebuild *repository.RecordRebuild) ([]string, error) {
+	if r.workouts != nil {
+		var moved []*model.WorkoutSet
+		last := make(map[uint64]uint8)
+		for _, set := range r.workouts.sets {
+			workout := r.workouts.workouts[set.WorkoutID]
+			if workout == nil || workout.UserID != userID {
+				continue
+			}
+			switch set.ExerciseID {
+			case sourceID:
+				moved = append(moved, set)
+			case targetID:
+				last[set.WorkoutID] = max(last[set.WorkoutID], set.SetNumber)
+			}
+		}
+		sort.Slice(moved, func(i, j int) bool {
+			if moved[i].SetNumber != moved[j].SetNumber {
+				return moved[i].SetNumber < moved[j].SetNumber
+			}
+			return moved[i].ID < moved[j].ID
+		})
+		originalNumbers := make([]uint8, len(moved))
+		for i, set := range moved {
+			originalNumbers[i] = set.SetNumber
+			last[set.WorkoutID]++
+			set.SetNumber = last[set.WorkoutID]
+			set.ExerciseID = targetID
+		}
+
+		// 統合元の自己ベスト履歴は種目の削除に連動して消える
+		records := r.workouts.records.records
+		var kept []model.PersonalRecord
+		for _, record := range records {
+			if record.ExerciseID != sourceID {
+				kept = append(kept, record)
+			}
+		}
+		r.workouts.records.records = kept
+		if err := r.workouts.rebuildPersonalRecords(rebuild); err != nil {
+			for i, set := range moved {
+				set.SetNumber = originalNumbers[i]
+				set.ExerciseID = sourceID
+			}
+			r.workouts.records.records = records
+			return nil, err
+		}
+	}
+	if r.favorites[sourceID] {
+		r.favorites[targetID] = true
+	}
+	delete(r.favorites, sourceID)
+
+	// 画像・動画は統合先がユーザーのカスタム種目の場合のみ移す
+	target := r.exercises[targetID]
+	moveMedia := target != nil && target.IsCustom && target.UserID != nil && *target.UserID == userID
+	var removedKeys []string
+	for _, media := range r.exercises[sourceID].Media {
+		if moveMedia {
+			media.ExerciseID = targetID
+			media.Position = len(target.Media)
+			*r.media[media.ID] = media
+			target.Media = append(target.Media, media)
+			continue
+		}
+		removedKeys = append(removedKeys, media.StorageKey)
+		delete(r.media, media.ID)
+	}
+	delete(r.exercises, sourceID)
+	return removedKeys, nil
+}
+
+func (r *MockExerciseRepository) ReplaceMuscles(exerciseID uint64, muscles []model.ExerciseMuscle) error {
+	for i := range muscles {
+		muscles[i].ExerciseID = exerciseID
+	}
+	r.exercises[exerciseID].Muscles = muscles
+	return nil
+}
+
+func (r *MockExerciseRepository) FindMuscles() ([]model.Muscle, error) {
+	arms, legs := uint64(2), uint64(4)
+	return []model.Muscle{
+		{ID: 1, Key: "chest", Name: "胸", MuscleGroup: model.MuscleGroupChest},
+		{ID: 2, Key: "arms", Name: "腕", MuscleGroup: model.MuscleGroupArms},
+		{ID: 3, Key: "triceps", Name: "上腕三頭筋", ParentID: &arms, MuscleGroup: model.MuscleGroupArms},
+		{ID: 4, Key: "legs", Name: "脚", MuscleGroup: model.MuscleGroupLegs},
+		{ID: 5, Key: "quads", Name: "大腿四頭筋", ParentID: &legs, MuscleGroup: model.MuscleGroupLegs},
+	}, nil
+}
+
+func (r *MockExerciseRepository) FindTranslations(locale model.Locale, exerciseIDs []uint64) ([]model.ExerciseTranslation, error) {
+	ids := make(map[uint64]bool, len(exerciseIDs))
+	for _, id := range exerciseIDs {
+		ids[id] = true
+	}
+	var result []model.ExerciseTranslation
+	for _, t := range r.translations {
+		if t.Locale == locale && ids[t.ExerciseID] {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func (r *MockExerciseRepository) FindByIDs(ids []uint64) ([]model.Exercise, error) {
+	var result []model.Exercise
+	for _, id := range ids {
+		if e, ok := r.exercises[id]; ok {
+			result = append(result, *e)
+		}
+	}
+	return result, nil
+}
+
+func (r *MockExerciseRepository) FindVariations(userID, parentID uint64) ([]model.Exercise, error) {
+	var result []model.Exercise
+	for _, e := range r.exercises {
+		if e.ParentID == nil || *e.ParentID != parentID || e.ArchivedAt != nil {
+			continue
+		}
+		if !e.IsCustom || (e.UserID != nil && *e.UserID == userID) {
+			result = append(result, *e)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func (r *MockExerciseRepository) FindProgressionChain(userID, exerciseID uint64) ([]model.Exercise, error) {
+	visible := func(e *model.Exercise) bool {
+		return e.ID == exerciseID || ((!e.IsCustom || (e.UserID != nil && *e.UserID == userID)) && e.ArchivedAt == nil)
+	}
+	ids := map[uint64]bool{exerciseID: true}
+	// 易しい方向
+	for e, ok := r.exercises[exerciseID]; ok && e.ProgressesFromID != nil && !ids[*e.ProgressesFromID]; e, ok = r.exercises[*e.ProgressesFromID] {
+		ids[*e.ProgressesFromID] = true
+	}
+	// 難しい方向
+	queue := []uint64{exerciseID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, e := range r.exercises {
+			if e.ProgressesFromID != nil && *e.ProgressesFromID == id && !ids[e.ID] && visible(e) {
+				ids[e.ID] = true
+				queue = append(queue, e.ID)
+			}
+		}
+	}
+
+	var result []model.Exercise
+	for id := range ids {
+		if e, ok := r.exercises[id]; ok && visible(e) {
+			result = append(result, *e)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
 func TestWorkoutService_CreateWorkout(t *testing.T) {
 	t.Run("正常にワークアウトを作成できる", func(t *testing.T) {
 		workoutRepo := NewMockWorkoutRepository()
@@ -297,6 +927,22 @@ func TestWorkoutService_CreateWorkout(t *testing.T) {
 			t.Log("セットなしの場合はエラーになるべき")
 		}
 	})
+
+	t.Run("RPEが範囲外の場合はエラー", func(t *testing.T) {
+		workoutService := NewWorkoutService(NewMockWorkoutRepository(), NewMockExerciseRepository(), nil, nil, nil, nil)
+		rpe := 11.0
+		input := &CreateWorkoutInput{
+			Date: "2026-01-07",
+			Sets: []CreateSetInput{
+				{ExerciseID: 1, SetNumber: 1, Weight: 60.0, Reps: 10, RPE: &rpe},
+			},
+		}
+
+		_, err := workoutService.CreateWorkout(1, input)
+		if !errors.Is(err, ErrInvalidRPE) {
+			t.Errorf("ErrInvalidRPE を期待, 実際: %v", err)
+		}
+	})
 }
 
 func TestWorkoutService_GetWorkout(t *testing.T) {
@@ -378,7 +1024,7 @@ func TestWorkoutService_DeleteWorkout(t *testing.T) {
 		workoutID := workout.ID
 
 		// 削除
-		workoutRepo.Delete(workoutID)
+		workoutRepo.Delete(workoutID, nil)
 
 		// 検証
 		_, err := workoutRepo.FindByID(workoutID)
@@ -0,0 +1,4 @@
+ALTER TABLE menu_items
+    DROP COLUMN IF EXISTS target_rpe,
+    DROP COLUMN IF EXISTS target_percentage,
+    DROP COLUMN IF EXISTS target_type;
@@ -0,0 +1,4 @@
+ALTER TABLE menu_items
+    ADD COLUMN target_type VARCHAR(20) NOT NULL DEFAULT 'fixed',
+    ADD COLUMN target_percentage DECIMAL(5,2) NULL,
+    ADD COLUMN target_rpe DECIMAL(3,1) NULL;
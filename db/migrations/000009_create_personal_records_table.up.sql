@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS personal_records (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    exercise_id BIGINT NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    workout_id BIGINT NOT NULL REFERENCES workouts(id) ON DELETE CASCADE,
+    workout_set_id BIGINT NULL REFERENCES workout_sets(id) ON DELETE SET NULL,
+    record_type VARCHAR(30) NOT NULL,
+    weight DECIMAL(6,2) NOT NULL,
+    reps SMALLINT NOT NULL,
+    value DECIMAL(10,2) NOT NULL,
+    previous_value DECIMAL(10,2) NULL,
+    achieved_on DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_personal_records_user_exercise ON personal_records(user_id, exercise_id, achieved_on);
+CREATE INDEX idx_personal_records_workout_id ON personal_records(workout_id);
@@ -0,0 +1,2 @@
+ALTER TABLE workout_sets
+    ADD COLUMN rpe DECIMAL(3,1) NULL;
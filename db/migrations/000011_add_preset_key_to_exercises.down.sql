@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_exercises_preset_key;
+
+ALTER TABLE exercises
+    DROP COLUMN IF EXISTS preset_key;
@@ -0,0 +1,15 @@
+-- プリセット種目を表示名に依存せずに識別するキー（名称変更や翻訳で変わらない）
+ALTER TABLE exercises
+    ADD COLUMN preset_key VARCHAR(50) NULL;
+
+CREATE UNIQUE INDEX idx_exercises_preset_key ON exercises(preset_key);
+
+-- 体重比の強さで評価する主要種目
+UPDATE exercises SET preset_key = v.preset_key
+FROM (VALUES
+    ('スクワット', 'squat'),
+    ('ベンチプレス', 'bench_press'),
+    ('デッドリフト', 'deadlift'),
+    ('ショルダープレス', 'overhead_press')
+) AS v(name, preset_key)
+WHERE exercises.name = v.name AND exercises.is_custom = false;
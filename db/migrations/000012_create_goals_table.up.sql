@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS goals (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    goal_type VARCHAR(20) NOT NULL,
+    exercise_id BIGINT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    target_value DECIMAL(8,2) NOT NULL,
+    start_value DECIMAL(8,2) NULL,
+    deadline DATE NULL,
+    achieved_on DATE NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_goals_user_id ON goals(user_id);
@@ -0,0 +1,34 @@
+-- 統計用に、ユーザー・種目・日ごとのセットの集計を保持する
+-- 推定1RMは計算式ごとに最大値を持つ（計算式は repository.oneRepMaxExpr と同じ）
+CREATE TABLE IF NOT EXISTS daily_exercise_summary (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    exercise_id BIGINT NOT NULL REFERENCES exercises(id),
+    date DATE NOT NULL,
+    set_count INTEGER NOT NULL,
+    total_reps INTEGER NOT NULL,
+    total_volume NUMERIC NOT NULL,
+    max_weight DECIMAL(6,2) NOT NULL,
+    max_e1rm_epley NUMERIC NOT NULL,
+    max_e1rm_brzycki NUMERIC NOT NULL,
+    max_e1rm_lombardi NUMERIC NOT NULL,
+    PRIMARY KEY (user_id, exercise_id, date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_exercise_summary_user_date ON daily_exercise_summary(user_id, date);
+
+-- 既存の記録から集計を作成する
+INSERT INTO daily_exercise_summary (user_id, exercise_id, date, set_count, total_reps, total_volume, max_weight, max_e1rm_epley, max_e1rm_brzycki, max_e1rm_lombardi)
+SELECT
+    workouts.user_id,
+    workout_sets.exercise_id,
+    workouts.date,
+    COUNT(*),
+    SUM(workout_sets.reps),
+    SUM(workout_sets.weight * workout_sets.reps),
+    MAX(workout_sets.weight),
+    MAX(CASE WHEN workout_sets.reps <= 1 THEN workout_sets.weight ELSE workout_sets.weight * (1 + workout_sets.reps / 30.0) END),
+    MAX(CASE WHEN workout_sets.reps <= 1 THEN workout_sets.weight ELSE CASE WHEN workout_sets.reps < 37 THEN workout_sets.weight * 36.0 / (37 - workout_sets.reps) ELSE workout_sets.weight * (1 + workout_sets.reps / 30.0) END END),
+    MAX(CASE WHEN workout_sets.reps <= 1 THEN workout_sets.weight ELSE workout_sets.weight * POWER(workout_sets.reps, 0.10) END)
+FROM workout_sets
+JOIN workouts ON workouts.id = workout_sets.workout_id
+GROUP BY workouts.user_id, workout_sets.exercise_id, workouts.date;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS exercise_muscles;
+DROP TABLE IF EXISTS muscles;
@@ -0,0 +1,93 @@
+-- 筋肉の階層（部位 → 個別の筋肉）
+-- muscle_group は最上位の部位で、exercises.muscle_group と同じ分類
+CREATE TABLE IF NOT EXISTS muscles (
+    id BIGSERIAL PRIMARY KEY,
+    key VARCHAR(30) NOT NULL UNIQUE,
+    name VARCHAR(50) NOT NULL,
+    parent_id BIGINT NULL REFERENCES muscles(id),
+    muscle_group muscle_group_type NOT NULL
+);
+
+CREATE INDEX idx_muscles_parent_id ON muscles(parent_id);
+
+-- 種目と筋肉の対応
+-- contribution は種目への関与の度合い（0〜1）。集計では種目ごとの合計が1になるように按分する
+CREATE TABLE IF NOT EXISTS exercise_muscles (
+    exercise_id BIGINT NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    muscle_id BIGINT NOT NULL REFERENCES muscles(id) ON DELETE RESTRICT,
+    contribution DECIMAL(3,2) NOT NULL CHECK (contribution > 0 AND contribution <= 1),
+    PRIMARY KEY (exercise_id, muscle_id)
+);
+
+CREATE INDEX idx_exercise_muscles_muscle_id ON exercise_muscles(muscle_id);
+
+-- 部位（キーは muscle_group と同じ）
+INSERT INTO muscles (key, name, muscle_group) VALUES
+('chest', '胸', 'chest'),
+('back', '背中', 'back'),
+('shoulders', '肩', 'shoulders'),
+('arms', '腕', 'arms'),
+('legs', '脚', 'legs'),
+('abs', '腹筋', 'abs'),
+('other', 'その他', 'other');
+
+-- 個別の筋肉
+INSERT INTO muscles (key, name, parent_id, muscle_group)
+SELECT v.key, v.name, parent.id, parent.muscle_group
+FROM (VALUES
+    ('upper_chest', '大胸筋上部', 'chest'),
+    ('lats', '広背筋', 'back'),
+    ('traps', '僧帽筋', 'back'),
+    ('lower_back', '脊柱起立筋', 'back'),
+    ('front_delts', '三角筋前部', 'shoulders'),
+    ('side_delts', '三角筋中部', 'shoulders'),
+    ('rear_delts', '三角筋後部', 'shoulders'),
+    ('biceps', '上腕二頭筋', 'arms'),
+    ('triceps', '上腕三頭筋', 'arms'),
+    ('forearms', '前腕', 'arms'),
+    ('quads', '大腿四頭筋', 'legs'),
+    ('hamstrings', 'ハムストリングス', 'legs'),
+    ('glutes', '大臀筋', 'legs'),
+    ('calves', 'ふくらはぎ', 'legs'),
+    ('rectus_abdominis', '腹直筋', 'abs'),
+    ('obliques', '腹斜筋', 'abs')
+) AS v(key, name, parent_key)
+JOIN muscles parent ON parent.key = v.parent_key;
+
+-- プリセット種目の対応
+INSERT INTO exercise_muscles (exercise_id, muscle_id, contribution)
+SELECT exercises.id, muscles.id, v.contribution
+FROM (VALUES
+    ('ベンチプレス', 'chest', 1.0), ('ベンチプレス', 'triceps', 0.5), ('ベンチプレス', 'front_delts', 0.5),
+    ('インクラインベンチプレス', 'upper_chest', 1.0), ('インクラインベンチプレス', 'front_delts', 0.5), ('インクラインベンチプレス', 'triceps', 0.5),
+    ('ダンベルフライ', 'chest', 1.0), ('ダンベルフライ', 'front_delts', 0.25),
+    ('チェストプレス', 'chest', 1.0), ('チェストプレス', 'triceps', 0.5), ('チェストプレス', 'front_delts', 0.5),
+    ('プッシュアップ', 'chest', 1.0), ('プッシュアップ', 'triceps', 0.5), ('プッシュアップ', 'front_delts', 0.5), ('プッシュアップ', 'rectus_abdominis', 0.25),
+    ('デッドリフト', 'lower_back', 1.0), ('デッドリフト', 'glutes', 1.0), ('デッドリフト', 'hamstrings', 1.0), ('デッドリフト', 'traps', 0.5), ('デッドリフト', 'forearms', 0.5),
+    ('ラットプルダウン', 'lats', 1.0), ('ラットプルダウン', 'biceps', 0.5),
+    ('ベントオーバーロー', 'lats', 1.0), ('ベントオーバーロー', 'traps', 0.5), ('ベントオーバーロー', 'rear_delts', 0.5), ('ベントオーバーロー', 'biceps', 0.5),
+    ('シーテッドロー', 'lats', 1.0), ('シーテッドロー', 'traps', 0.5), ('シーテッドロー', 'biceps', 0.5),
+    ('チンニング', 'lats', 1.0), ('チンニング', 'biceps', 0.5),
+    ('ショルダープレス', 'front_delts', 1.0), ('ショルダープレス', 'side_delts', 0.5), ('ショルダープレス', 'triceps', 0.5),
+    ('サイドレイズ', 'side_delts', 1.0),
+    ('フロントレイズ', 'front_delts', 1.0),
+    ('リアレイズ', 'rear_delts', 1.0),
+    ('アップライトロー', 'side_delts', 1.0), ('アップライトロー', 'traps', 0.5),
+    ('バーベルカール', 'biceps', 1.0), ('バーベルカール', 'forearms', 0.25),
+    ('ダンベルカール', 'biceps', 1.0), ('ダンベルカール', 'forearms', 0.25),
+    ('トライセプスエクステンション', 'triceps', 1.0),
+    ('ケーブルプッシュダウン', 'triceps', 1.0),
+    ('ハンマーカール', 'biceps', 1.0), ('ハンマーカール', 'forearms', 0.5),
+    ('スクワット', 'quads', 1.0), ('スクワット', 'glutes', 0.5), ('スクワット', 'hamstrings', 0.25),
+    ('レッグプレス', 'quads', 1.0), ('レッグプレス', 'glutes', 0.5),
+    ('レッグエクステンション', 'quads', 1.0),
+    ('レッグカール', 'hamstrings', 1.0),
+    ('カーフレイズ', 'calves', 1.0),
+    ('ランジ', 'quads', 1.0), ('ランジ', 'glutes', 1.0), ('ランジ', 'hamstrings', 0.25),
+    ('クランチ', 'rectus_abdominis', 1.0),
+    ('レッグレイズ', 'rectus_abdominis', 1.0),
+    ('プランク', 'rectus_abdominis', 1.0), ('プランク', 'obliques', 0.5),
+    ('アブローラー', 'rectus_abdominis', 1.0), ('アブローラー', 'obliques', 0.25)
+) AS v(exercise_name, muscle_key, contribution)
+JOIN exercises ON exercises.name = v.exercise_name AND exercises.is_custom = FALSE
+JOIN muscles ON muscles.key = v.muscle_key;
@@ -0,0 +1,14 @@
+-- 大胸筋中部・下部への対応を部位（chest）に戻してから筋肉を削除する
+INSERT INTO exercise_muscles (exercise_id, muscle_id, contribution)
+SELECT exercise_muscles.exercise_id, chest.id, MAX(exercise_muscles.contribution)
+FROM exercise_muscles
+JOIN muscles ON muscles.id = exercise_muscles.muscle_id AND muscles.key IN ('mid_chest', 'lower_chest')
+JOIN muscles chest ON chest.key = 'chest'
+GROUP BY exercise_muscles.exercise_id, chest.id
+ON CONFLICT DO NOTHING;
+
+DELETE FROM exercise_muscles
+USING muscles
+WHERE exercise_muscles.muscle_id = muscles.id AND muscles.key IN ('mid_chest', 'lower_chest');
+
+DELETE FROM muscles WHERE key IN ('mid_chest', 'lower_chest');
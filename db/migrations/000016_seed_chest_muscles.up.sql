@@ -0,0 +1,26 @@
+-- 大胸筋の個別の筋肉
+-- 胸のプリセット種目は部位（chest）に対応づけていたため、筋肉別の集計で大胸筋上部以外が部位にまとまっていた
+INSERT INTO muscles (key, name, parent_id, muscle_group)
+SELECT v.key, v.name, parent.id, parent.muscle_group
+FROM (VALUES
+    ('mid_chest', '大胸筋中部', 'chest'),
+    ('lower_chest', '大胸筋下部', 'chest')
+) AS v(key, name, parent_key)
+JOIN muscles parent ON parent.key = v.parent_key;
+
+-- プリセット種目の部位への対応を個別の筋肉に置き換える
+DELETE FROM exercise_muscles
+USING exercises, muscles
+WHERE exercise_muscles.exercise_id = exercises.id AND exercises.is_custom = FALSE
+  AND exercise_muscles.muscle_id = muscles.id AND muscles.key = 'chest';
+
+INSERT INTO exercise_muscles (exercise_id, muscle_id, contribution)
+SELECT exercises.id, muscles.id, v.contribution
+FROM (VALUES
+    ('ベンチプレス', 'mid_chest', 1.0), ('ベンチプレス', 'lower_chest', 0.5),
+    ('ダンベルフライ', 'mid_chest', 1.0), ('ダンベルフライ', 'lower_chest', 0.5),
+    ('チェストプレス', 'mid_chest', 1.0), ('チェストプレス', 'lower_chest', 0.5),
+    ('プッシュアップ', 'mid_chest', 1.0), ('プッシュアップ', 'lower_chest', 0.25)
+) AS v(exercise_name, muscle_key, contribution)
+JOIN exercises ON exercises.name = v.exercise_name AND exercises.is_custom = FALSE
+JOIN muscles ON muscles.key = v.muscle_key;
@@ -0,0 +1,5 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS available_equipment;
+
+ALTER TABLE exercises
+    DROP COLUMN IF EXISTS equipment;
@@ -0,0 +1,43 @@
+-- 種目に使う器具（すべてそろっている場合に実施できる）
+ALTER TABLE exercises
+    ADD COLUMN equipment VARCHAR(20)[] NOT NULL DEFAULT '{}';
+
+-- ユーザーが使える器具（NULL の場合は制限しない）
+ALTER TABLE users
+    ADD COLUMN available_equipment VARCHAR(20)[] NULL;
+
+-- プリセット種目の器具
+UPDATE exercises SET equipment = v.equipment::varchar(20)[]
+FROM (VALUES
+    ('ベンチプレス', '{barbell}'),
+    ('インクラインベンチプレス', '{barbell}'),
+    ('ダンベルフライ', '{dumbbell}'),
+    ('チェストプレス', '{machine}'),
+    ('プッシュアップ', '{bodyweight}'),
+    ('デッドリフト', '{barbell}'),
+    ('ラットプルダウン', '{cable}'),
+    ('ベントオーバーロー', '{barbell}'),
+    ('シーテッドロー', '{cable}'),
+    ('チンニング', '{bodyweight}'),
+    ('ショルダープレス', '{barbell}'),
+    ('サイドレイズ', '{dumbbell}'),
+    ('フロントレイズ', '{dumbbell}'),
+    ('リアレイズ', '{dumbbell}'),
+    ('アップライトロー', '{barbell}'),
+    ('バーベルカール', '{barbell}'),
+    ('ダンベルカール', '{dumbbell}'),
+    ('トライセプスエクステンション', '{dumbbell}'),
+    ('ケーブルプッシュダウン', '{cable}'),
+    ('ハンマーカール', '{dumbbell}'),
+    ('スクワット', '{barbell}'),
+    ('レッグプレス', '{machine}'),
+    ('レッグエクステンション', '{machine}'),
+    ('レッグカール', '{machine}'),
+    ('カーフレイズ', '{bodyweight}'),
+    ('ランジ', '{bodyweight}'),
+    ('クランチ', '{bodyweight}'),
+    ('レッグレイズ', '{bodyweight}'),
+    ('プランク', '{bodyweight}'),
+    ('アブローラー', '{bodyweight}')
+) AS v(name, equipment)
+WHERE exercises.name = v.name AND exercises.is_custom = FALSE;
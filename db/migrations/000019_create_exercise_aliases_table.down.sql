@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_exercises_name_trgm;
+DROP TABLE IF EXISTS exercise_aliases;
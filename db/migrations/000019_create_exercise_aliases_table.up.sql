@@ -0,0 +1,54 @@
+-- 種目名の検索に使う pg_trgm（類似度による並び替え）
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- 種目の別名（英語名・略称・ローマ字など）
+-- user_id が NULL の別名は全ユーザー共通、それ以外はそのユーザーのみが検索に使う
+-- alias は小文字・ひらがなをカタカナにした正規化済みの値を保存する
+CREATE TABLE IF NOT EXISTS exercise_aliases (
+    id BIGSERIAL PRIMARY KEY,
+    exercise_id BIGINT NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    user_id BIGINT NULL REFERENCES users(id) ON DELETE CASCADE,
+    alias VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_exercise_aliases_unique ON exercise_aliases(exercise_id, alias, COALESCE(user_id, 0));
+CREATE INDEX idx_exercise_aliases_alias_trgm ON exercise_aliases USING gin (alias gin_trgm_ops);
+CREATE INDEX idx_exercises_name_trgm ON exercises USING gin (lower(name) gin_trgm_ops);
+
+-- プリセット種目の別名
+INSERT INTO exercise_aliases (exercise_id, alias)
+SELECT exercises.id, v.alias
+FROM (VALUES
+    ('ベンチプレス', 'bench press'), ('ベンチプレス', 'bench'), ('ベンチプレス', 'bp'), ('ベンチプレス', 'benchi puresu'), ('ベンチプレス', 'ベンチ'),
+    ('インクラインベンチプレス', 'incline bench press'), ('インクラインベンチプレス', 'incline bench'), ('インクラインベンチプレス', 'ibp'), ('インクラインベンチプレス', 'inkurain benchi puresu'), ('インクラインベンチプレス', 'インクラインベンチ'),
+    ('ダンベルフライ', 'dumbbell fly'), ('ダンベルフライ', 'db fly'), ('ダンベルフライ', 'chest fly'), ('ダンベルフライ', 'danberu furai'),
+    ('チェストプレス', 'chest press'), ('チェストプレス', 'machine chest press'), ('チェストプレス', 'chesuto puresu'),
+    ('プッシュアップ', 'push up'), ('プッシュアップ', 'pushup'), ('プッシュアップ', 'press up'), ('プッシュアップ', 'pusshu appu'), ('プッシュアップ', '腕立て伏せ'),
+    ('デッドリフト', 'deadlift'), ('デッドリフト', 'dl'), ('デッドリフト', 'conventional deadlift'), ('デッドリフト', 'deddorifuto'), ('デッドリフト', 'デッド'),
+    ('ラットプルダウン', 'lat pulldown'), ('ラットプルダウン', 'lat pull down'), ('ラットプルダウン', 'pulldown'), ('ラットプルダウン', 'ratto puru daun'), ('ラットプルダウン', 'ラットプル'),
+    ('ベントオーバーロー', 'bent over row'), ('ベントオーバーロー', 'barbell row'), ('ベントオーバーロー', 'bor'), ('ベントオーバーロー', 'bento oba ro'),
+    ('シーテッドロー', 'seated row'), ('シーテッドロー', 'seated cable row'), ('シーテッドロー', 'cable row'), ('シーテッドロー', 'shiteddo ro'),
+    ('チンニング', 'chin up'), ('チンニング', 'pull up'), ('チンニング', 'pullup'), ('チンニング', 'chinningu'), ('チンニング', '懸垂'),
+    ('ショルダープレス', 'shoulder press'), ('ショルダープレス', 'overhead press'), ('ショルダープレス', 'ohp'), ('ショルダープレス', 'military press'), ('ショルダープレス', 'shoruda puresu'),
+    ('サイドレイズ', 'side raise'), ('サイドレイズ', 'lateral raise'), ('サイドレイズ', 'side lateral raise'), ('サイドレイズ', 'saido reizu'),
+    ('フロントレイズ', 'front raise'), ('フロントレイズ', 'furonto reizu'),
+    ('リアレイズ', 'rear raise'), ('リアレイズ', 'rear delt fly'), ('リアレイズ', 'reverse fly'), ('リアレイズ', 'ria reizu'),
+    ('アップライトロー', 'upright row'), ('アップライトロー', 'appuraito ro'),
+    ('バーベルカール', 'barbell curl'), ('バーベルカール', 'bb curl'), ('バーベルカール', 'baberu karu'),
+    ('ダンベルカール', 'dumbbell curl'), ('ダンベルカール', 'db curl'), ('ダンベルカール', 'danberu karu'),
+    ('トライセプスエクステンション', 'triceps extension'), ('トライセプスエクステンション', 'tricep extension'), ('トライセプスエクステンション', 'skull crusher'), ('トライセプスエクステンション', 'toraisepusu ekusutenshon'),
+    ('ケーブルプッシュダウン', 'cable pushdown'), ('ケーブルプッシュダウン', 'triceps pushdown'), ('ケーブルプッシュダウン', 'pushdown'), ('ケーブルプッシュダウン', 'keburu pusshu daun'),
+    ('ハンマーカール', 'hammer curl'), ('ハンマーカール', 'hanma karu'),
+    ('スクワット', 'squat'), ('スクワット', 'back squat'), ('スクワット', 'sq'), ('スクワット', 'sukuwatto'),
+    ('レッグプレス', 'leg press'), ('レッグプレス', 'reggu puresu'),
+    ('レッグエクステンション', 'leg extension'), ('レッグエクステンション', 'reggu ekusutenshon'),
+    ('レッグカール', 'leg curl'), ('レッグカール', 'hamstring curl'), ('レッグカール', 'reggu karu'),
+    ('カーフレイズ', 'calf raise'), ('カーフレイズ', 'kafu reizu'),
+    ('ランジ', 'lunge'), ('ランジ', 'ranji'),
+    ('クランチ', 'crunch'), ('クランチ', 'kuranchi'),
+    ('レッグレイズ', 'leg raise'), ('レッグレイズ', 'hanging leg raise'), ('レッグレイズ', 'reggu reizu'),
+    ('プランク', 'plank'), ('プランク', 'puranku'),
+    ('アブローラー', 'ab roller'), ('アブローラー', 'ab wheel'), ('アブローラー', 'aburora'), ('アブローラー', '腹筋ローラー')
+) AS v(exercise_name, alias)
+JOIN exercises ON exercises.name = v.exercise_name AND exercises.is_custom = FALSE;
@@ -0,0 +1,2 @@
+ALTER TABLE exercises
+    DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,3 @@
+-- アーカイブしたカスタム種目（NULL の場合は種目の選択肢に表示する）
+ALTER TABLE exercises
+    ADD COLUMN archived_at TIMESTAMP NULL;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS exercise_media;
+
+ALTER TABLE exercises
+    DROP COLUMN IF EXISTS common_mistakes,
+    DROP COLUMN IF EXISTS cues,
+    DROP COLUMN IF EXISTS instructions;
@@ -0,0 +1,148 @@
+-- 種目の解説（手順・フォームのポイント・よくある間違い）
+ALTER TABLE exercises
+    ADD COLUMN instructions JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN cues JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN common_mistakes JSONB NOT NULL DEFAULT '[]';
+
+-- 種目の画像・動画（ファイル本体はストレージに保存し、storage_key で参照する）
+CREATE TABLE IF NOT EXISTS exercise_media (
+    id BIGSERIAL PRIMARY KEY,
+    exercise_id BIGINT NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    media_type VARCHAR(10) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL UNIQUE,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_exercise_media_exercise_id ON exercise_media(exercise_id, position);
+
+-- プリセット種目の解説
+UPDATE exercises SET
+    instructions = v.instructions::jsonb,
+    cues = v.cues::jsonb,
+    common_mistakes = v.common_mistakes::jsonb
+FROM (VALUES
+    ('ベンチプレス',
+        '["ベンチに仰向けになり、目の真上にバーが来る位置に寝る", "肩甲骨を寄せて下げ、足裏を床にしっかりつける", "肩幅より少し広くバーを握り、ラックから外す", "みぞおち付近に向けてバーをゆっくり下ろす", "胸に触れたら肩の上まで押し上げる"]',
+        '["肩甲骨を寄せたまま胸を張る", "前腕を床と垂直に保つ", "下ろすときは2秒かけてコントロールする"]',
+        '["お尻がベンチから浮く", "胸でバーをバウンドさせる", "肘を真横に開きすぎて肩を痛める"]'),
+    ('インクラインベンチプレス',
+        '["ベンチの角度を30〜45度に設定する", "肩甲骨を寄せて下げ、足裏を床につける", "鎖骨の下あたりに向けてバーを下ろす", "肩の真上まで押し上げる"]',
+        '["角度を上げすぎず胸上部に効かせる", "胸を張ったまま押す"]',
+        '["角度が高すぎて肩の種目になる", "腰を大きく反らせる"]'),
+    ('ダンベルフライ',
+        '["ダンベルを持ってベンチに仰向けになり、胸の上で腕を伸ばす", "肘を軽く曲げたまま弧を描くように腕を開く", "胸のストレッチを感じたら同じ軌道で閉じる"]',
+        '["肘の角度を最後まで一定に保つ", "胸を張って肩甲骨を寄せる"]',
+        '["肘を伸ばしきって肘や肩に負担をかける", "重すぎてプレス動作になる"]'),
+    ('チェストプレス',
+        '["グリップが胸の中央の高さに来るようシートを調整する", "背中をパッドにつけ、肩甲骨を寄せる", "肘を伸ばしきる手前まで押し出し、ゆっくり戻す"]',
+        '["肩をすくめない", "戻すときも負荷を抜かない"]',
+        '["背中がパッドから離れる", "勢いで押し出す"]'),
+    ('プッシュアップ',
+        '["肩幅より少し広く手をつき、頭からかかとまで一直線にする", "胸が床に近づくまで肘を曲げる", "床を押して元の姿勢に戻る"]',
+        '["体幹に力を入れて姿勢を保つ", "肘は体に対して45度程度に開く"]',
+        '["腰が落ちる、またはお尻が上がる", "可動域が浅い"]'),
+    ('デッドリフト',
+        '["足を腰幅に開き、バーが足の中央の真上に来るように立つ", "股関節から曲げ、肩幅でバーを握る", "背中をまっすぐにして胸を張る", "床を押すように脚と股関節を伸ばして立ち上がる", "同じ軌道でバーを床に戻す"]',
+        '["バーを体から離さない", "腹圧をかけて背中を丸めない", "立ち上がったら股関節をしっかり伸ばす"]',
+        '["背中が丸まる", "腕でバーを引き上げる", "トップで上体を反らしすぎる"]'),
+    ('ラットプルダウン',
+        '["膝をパッドで固定し、肩幅より広くバーを握る", "胸を張り、肘を下に引くようにバーを鎖骨まで下ろす", "腕が伸びきるまでゆっくり戻す"]',
+        '["肩甲骨を下げてから引く", "肘で引く意識を持つ"]',
+        '["上体を大きく後ろに倒して反動を使う", "首の後ろに引いて肩を痛める"]'),
+    ('ベントオーバーロー',
+        '["膝を軽く曲げ、股関節から上体を45度程度まで前傾する", "肩幅でバーを握り、腕を下に伸ばす", "肘を後ろに引いておへそに向けてバーを引く", "ゆっくり下ろす"]',
+        '["背中をまっすぐに保つ", "肩甲骨を寄せて引く"]',
+        '["上体が起き上がって反動を使う", "背中が丸まる"]'),
+    ('シーテッドロー',
+        '["足をフットレストに置き、膝を軽く曲げて座る", "胸を張ってハンドルをお腹に向けて引く", "肩甲骨を寄せたら腕を伸ばして戻す"]',
+        '["上体はほぼ垂直に保つ", "肘を体の近くに通す"]',
+        '["上体を前後に振る", "肩をすくめて引く"]'),
+    ('チンニング',
+        '["肩幅より少し広くバーを握ってぶら下がる", "肩甲骨を下げ、胸をバーに近づけるように体を引き上げる", "あごがバーを越えたらゆっくり下りる"]',
+        '["体を揺らさない", "下りるときも肩をすくめない"]',
+        '["反動を使う", "腕が伸びきる前に次の回に入る"]'),
+    ('ショルダープレス',
+        '["肩幅より少し広くバーを握り、鎖骨の前で構える", "お腹に力を入れ、頭の真上までバーを押し上げる", "ゆっくり鎖骨の前まで下ろす"]',
+        '["バーの軌道は顔の前を通ってから頭上へ", "お尻と腹筋に力を入れて体を固定する"]',
+        '["腰を反らせて胸で押す", "肘が開いてバーが前に流れる"]'),
+    ('サイドレイズ',
+        '["ダンベルを持って体の横に腕を下ろす", "肘を軽く曲げたまま、肩の高さまで腕を横に上げる", "ゆっくり下ろす"]',
+        '["肘から上げる意識を持つ", "小指側を少し高くする"]',
+        '["肩をすくめて僧帽筋で上げる", "反動で振り上げる"]'),
+    ('フロントレイズ',
+        '["ダンベルを太ももの前で持つ", "腕を伸ばしたまま肩の高さまで前に上げる", "ゆっくり下ろす"]',
+        '["体を反らさない", "肩の高さで止める"]',
+        '["反動で振り上げる", "肩より高く上げる"]'),
+    ('リアレイズ',
+        '["ダンベルを持ち、上体を床と平行近くまで前傾する", "肘を軽く曲げたまま腕を横に開く", "ゆっくり下ろす"]',
+        '["肩甲骨を寄せすぎず肩の後ろで上げる", "軽い重量で丁寧に行う"]',
+        '["上体が起き上がる", "背中の筋肉で引いてしまう"]'),
+    ('アップライトロー',
+        '["肩幅程度でバーを握り、太ももの前で持つ", "肘を先行させてバーを胸の高さまで引き上げる", "ゆっくり下ろす"]',
+        '["肘を手首より高く保つ", "肩の高さ以上に肘を上げない"]',
+        '["握りが狭すぎて肩を痛める", "反動で引き上げる"]'),
+    ('バーベルカール',
+        '["肩幅でバーを逆手で握り、腕を伸ばして立つ", "肘の位置を固定したままバーを肩に向けて巻き上げる", "ゆっくり下ろす"]',
+        '["肘を体の横に固定する", "下ろすときも負荷をかけ続ける"]',
+        '["上体を反らせて反動を使う", "肘が前に出る"]'),
+    ('ダンベルカール',
+        '["ダンベルを持って腕を体の横に下ろす", "手のひらを上に向けながら肘を曲げて持ち上げる", "ゆっくり下ろす"]',
+        '["肘の位置を動かさない", "トップで二頭筋を縮める"]',
+        '["体を揺らして持ち上げる", "可動域が浅い"]'),
+    ('トライセプスエクステンション',
+        '["ダンベルを両手で持ち、頭の上に腕を伸ばす", "肘の位置を固定したまま頭の後ろにダンベルを下ろす", "肘を伸ばして元に戻す"]',
+        '["肘を前に向けたまま開かない", "二の腕のストレッチを感じる"]',
+        '["肘が外に開く", "腰を反らせる"]'),
+    ('ケーブルプッシュダウン',
+        '["ケーブルの前に立ち、バーを肩幅より狭く握る", "肘を体の横に固定し、腕が伸びきるまで押し下げる", "肘が90度になるまでゆっくり戻す"]',
+        '["肘を体から離さない", "伸ばしきったところで一瞬止める"]',
+        '["上体をかぶせて体重で押す", "肘が前後に動く"]'),
+    ('ハンマーカール',
+        '["ダンベルを手のひらが向かい合うように持つ", "手首の向きを変えずに肘を曲げて持ち上げる", "ゆっくり下ろす"]',
+        '["肘の位置を固定する", "手首をまっすぐに保つ"]',
+        '["反動を使う", "手首が曲がる"]'),
+    ('スクワット',
+        '["バーを僧帽筋の上に担ぎ、足を肩幅程度に開く", "胸を張り、股関節と膝を同時に曲げてしゃがむ", "太ももが床と平行になるまで下ろす", "足裏全体で床を押して立ち上がる"]',
+        '["膝とつま先の向きをそろえる", "腹圧をかけて背中をまっすぐ保つ", "重心は足の中央に置く"]',
+        '["膝が内側に入る", "かかとが浮く", "しゃがみが浅い"]'),
+    ('レッグプレス',
+        '["シートに座り、足をプレートに肩幅で置く", "ロックを外し、膝が90度程度になるまで下ろす", "膝を伸ばしきる手前まで押し戻す"]',
+        '["お尻と腰をシートにつけたまま行う", "膝とつま先の向きをそろえる"]',
+        '["膝を伸ばしきってロックする", "深く下ろしすぎて腰が浮く"]'),
+    ('レッグエクステンション',
+        '["膝の位置がマシンの回転軸に合うようにシートを調整する", "足首をパッドに当て、膝を伸ばして持ち上げる", "ゆっくり下ろす"]',
+        '["トップで太ももの前を縮める", "グリップを握って体を固定する"]',
+        '["反動で蹴り上げる", "お尻がシートから浮く"]'),
+    ('レッグカール',
+        '["膝の位置がマシンの回転軸に合うように構える", "かかとをお尻に近づけるように膝を曲げる", "ゆっくり戻す"]',
+        '["腰を反らさない", "戻すときも負荷を抜かない"]',
+        '["お尻が浮く", "勢いで曲げる"]'),
+    ('カーフレイズ',
+        '["段差につま先を乗せ、かかとを下ろして立つ", "つま先立ちになるまでかかとを上げる", "かかとが段差より下がるまでゆっくり下ろす"]',
+        '["トップで一瞬止める", "膝を伸ばしたまま行う"]',
+        '["可動域が浅い", "反動で弾む"]'),
+    ('ランジ',
+        '["足を腰幅に開いて立つ", "片足を大きく前に踏み出し、後ろの膝が床に近づくまで沈む", "前足で床を押して元の位置に戻る"]',
+        '["上体をまっすぐ保つ", "前足の膝とつま先の向きをそろえる"]',
+        '["前の膝が内側に入る", "歩幅が狭く膝が前に出すぎる"]'),
+    ('クランチ',
+        '["仰向けになり膝を立て、手を胸の前か頭の横に置く", "おへそをのぞき込むように肩甲骨が浮くまで上体を丸める", "ゆっくり戻す"]',
+        '["息を吐きながら丸める", "腰は床につけたままにする"]',
+        '["首を手で引っ張る", "上体を起こしすぎて股関節の運動になる"]'),
+    ('レッグレイズ',
+        '["仰向けになり、脚をそろえて伸ばす", "脚を床と垂直になるまで持ち上げる", "床につく手前までゆっくり下ろす"]',
+        '["腰を床に押しつける", "下ろすときほどゆっくり動かす"]',
+        '["腰が反って浮く", "反動で脚を振り上げる"]'),
+    ('プランク',
+        '["肘を肩の真下につき、うつ伏せになる", "つま先を立てて体を持ち上げる", "頭からかかとまで一直線の姿勢を保つ"]',
+        '["お腹とお尻に力を入れる", "呼吸を止めない"]',
+        '["腰が落ちる", "お尻が高く上がる"]'),
+    ('アブローラー',
+        '["膝をついてローラーを肩の真下で握る", "お腹に力を入れたままローラーを前に転がす", "腰が反る手前で止め、お腹の力で引き戻す"]',
+        '["背中を少し丸めた姿勢を保つ", "できる範囲から可動域を広げる"]',
+        '["腰が反る", "腕の力で引き戻す"]')
+) AS v(name, instructions, cues, common_mistakes)
+WHERE exercises.name = v.name AND exercises.is_custom = FALSE;
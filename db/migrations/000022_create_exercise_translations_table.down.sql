@@ -0,0 +1,4 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS locale;
+
+DROP TABLE IF EXISTS exercise_translations;
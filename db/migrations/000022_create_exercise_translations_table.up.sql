@@ -0,0 +1,79 @@
+-- 種目名・説明の翻訳（翻訳がない言語では exercises.name を使う）
+CREATE TABLE IF NOT EXISTS exercise_translations (
+    exercise_id BIGINT NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NULL,
+    PRIMARY KEY (exercise_id, locale)
+);
+
+-- ユーザーの表示言語（NULL の場合は Accept-Language に従う）
+ALTER TABLE users
+    ADD COLUMN locale VARCHAR(10) NULL;
+
+-- プリセット種目の翻訳
+INSERT INTO exercise_translations (exercise_id, locale, name, description)
+SELECT exercises.id, v.locale, COALESCE(v.name, exercises.name), v.description
+FROM (VALUES
+    ('ベンチプレス', 'ja', NULL, 'バーベルを胸まで下ろして押し上げる、胸の基本種目'),
+    ('ベンチプレス', 'en', 'Bench Press', 'A fundamental chest exercise: lower a barbell to the chest and press it back up.'),
+    ('インクラインベンチプレス', 'ja', NULL, '角度をつけたベンチで行い、胸の上部を鍛えるベンチプレス'),
+    ('インクラインベンチプレス', 'en', 'Incline Bench Press', 'A bench press on an inclined bench that emphasizes the upper chest.'),
+    ('ダンベルフライ', 'ja', NULL, '腕を大きく開閉して胸をストレッチさせる種目'),
+    ('ダンベルフライ', 'en', 'Dumbbell Fly', 'Open and close the arms in a wide arc to stretch and contract the chest.'),
+    ('チェストプレス', 'ja', NULL, 'マシンで軌道が安定した状態で胸を鍛える種目'),
+    ('チェストプレス', 'en', 'Chest Press', 'A machine press that trains the chest along a fixed path.'),
+    ('プッシュアップ', 'ja', NULL, '自重で胸・肩・腕を鍛える腕立て伏せ'),
+    ('プッシュアップ', 'en', 'Push-up', 'A bodyweight press for the chest, shoulders and triceps.'),
+    ('デッドリフト', 'ja', NULL, '床からバーベルを引き上げ、背中と下半身の全体を鍛える種目'),
+    ('デッドリフト', 'en', 'Deadlift', 'Lift a barbell from the floor, training the whole posterior chain.'),
+    ('ラットプルダウン', 'ja', NULL, 'バーを胸に向けて引き下ろし、背中の広がりを鍛える種目'),
+    ('ラットプルダウン', 'en', 'Lat Pulldown', 'Pull a bar down to the chest to build the lats.'),
+    ('ベントオーバーロー', 'ja', NULL, '前傾姿勢でバーベルを引き、背中の厚みを鍛える種目'),
+    ('ベントオーバーロー', 'en', 'Bent-over Row', 'Row a barbell from a hip-hinged position to build upper-back thickness.'),
+    ('シーテッドロー', 'ja', NULL, '座った姿勢でケーブルを引き、背中の中部を鍛える種目'),
+    ('シーテッドロー', 'en', 'Seated Cable Row', 'Row a cable handle while seated to train the mid back.'),
+    ('チンニング', 'ja', NULL, 'バーにぶら下がって体を引き上げる懸垂'),
+    ('チンニング', 'en', 'Pull-up', 'Hang from a bar and pull the body up until the chin clears it.'),
+    ('ショルダープレス', 'ja', NULL, 'バーベルを頭上に押し上げ、肩を鍛える種目'),
+    ('ショルダープレス', 'en', 'Overhead Press', 'Press a barbell overhead to build the shoulders.'),
+    ('サイドレイズ', 'ja', NULL, 'ダンベルを横に上げ、肩の側部を鍛える種目'),
+    ('サイドレイズ', 'en', 'Lateral Raise', 'Raise dumbbells out to the sides to train the side delts.'),
+    ('フロントレイズ', 'ja', NULL, 'ダンベルを前に上げ、肩の前部を鍛える種目'),
+    ('フロントレイズ', 'en', 'Front Raise', 'Raise dumbbells to the front to train the front delts.'),
+    ('リアレイズ', 'ja', NULL, '前傾姿勢でダンベルを横に開き、肩の後部を鍛える種目'),
+    ('リアレイズ', 'en', 'Rear Delt Raise', 'Raise dumbbells out to the sides while bent over to train the rear delts.'),
+    ('アップライトロー', 'ja', NULL, 'バーベルを胸の高さまで引き上げ、肩と僧帽筋を鍛える種目'),
+    ('アップライトロー', 'en', 'Upright Row', 'Pull a barbell up to chest height to train the shoulders and traps.'),
+    ('バーベルカール', 'ja', NULL, 'バーベルを巻き上げ、上腕二頭筋を鍛える種目'),
+    ('バーベルカール', 'en', 'Barbell Curl', 'Curl a barbell to build the biceps.'),
+    ('ダンベルカール', 'ja', NULL, 'ダンベルを巻き上げ、上腕二頭筋を鍛える種目'),
+    ('ダンベルカール', 'en', 'Dumbbell Curl', 'Curl dumbbells to build the biceps.'),
+    ('トライセプスエクステンション', 'ja', NULL, '頭上で肘を曲げ伸ばしし、上腕三頭筋を鍛える種目'),
+    ('トライセプスエクステンション', 'en', 'Triceps Extension', 'Extend the elbows overhead to train the triceps.'),
+    ('ケーブルプッシュダウン', 'ja', NULL, 'ケーブルを押し下げ、上腕三頭筋を鍛える種目'),
+    ('ケーブルプッシュダウン', 'en', 'Cable Pushdown', 'Push a cable attachment down to train the triceps.'),
+    ('ハンマーカール', 'ja', NULL, '縦向きに持ったダンベルを巻き上げ、上腕と前腕を鍛える種目'),
+    ('ハンマーカール', 'en', 'Hammer Curl', 'Curl dumbbells with a neutral grip to train the biceps and forearms.'),
+    ('スクワット', 'ja', NULL, 'バーベルを担いでしゃがみ、下半身全体を鍛える種目'),
+    ('スクワット', 'en', 'Squat', 'Squat down with a barbell on the back to train the whole lower body.'),
+    ('レッグプレス', 'ja', NULL, 'マシンでプレートを押し、脚全体を鍛える種目'),
+    ('レッグプレス', 'en', 'Leg Press', 'Push a weighted sled away with the legs on a machine.'),
+    ('レッグエクステンション', 'ja', NULL, 'マシンで膝を伸ばし、大腿四頭筋を鍛える種目'),
+    ('レッグエクステンション', 'en', 'Leg Extension', 'Extend the knees on a machine to isolate the quadriceps.'),
+    ('レッグカール', 'ja', NULL, 'マシンで膝を曲げ、ハムストリングスを鍛える種目'),
+    ('レッグカール', 'en', 'Leg Curl', 'Flex the knees on a machine to isolate the hamstrings.'),
+    ('カーフレイズ', 'ja', NULL, 'かかとを上げ下げし、ふくらはぎを鍛える種目'),
+    ('カーフレイズ', 'en', 'Calf Raise', 'Raise and lower the heels to train the calves.'),
+    ('ランジ', 'ja', NULL, '片脚を踏み出して沈み込み、脚とお尻を鍛える種目'),
+    ('ランジ', 'en', 'Lunge', 'Step forward and sink down to train the legs and glutes.'),
+    ('クランチ', 'ja', NULL, '上体を丸めて腹直筋を鍛える種目'),
+    ('クランチ', 'en', 'Crunch', 'Curl the upper body to train the abs.'),
+    ('レッグレイズ', 'ja', NULL, '仰向けで脚を上げ下げし、下腹部を鍛える種目'),
+    ('レッグレイズ', 'en', 'Leg Raise', 'Raise and lower straight legs while lying down to train the lower abs.'),
+    ('プランク', 'ja', NULL, '肘とつま先で体を支え、体幹を鍛える種目'),
+    ('プランク', 'en', 'Plank', 'Hold a straight body position on the forearms to train the core.'),
+    ('アブローラー', 'ja', NULL, 'ローラーを転がして体を伸ばし、腹筋を鍛える種目'),
+    ('アブローラー', 'en', 'Ab Wheel Rollout', 'Roll a wheel out and back to train the abs.')
+) AS v(exercise_name, locale, name, description)
+JOIN exercises ON exercises.name = v.exercise_name AND exercises.is_custom = FALSE;
@@ -0,0 +1,3 @@
+ALTER TABLE exercises
+    DROP COLUMN IF EXISTS progresses_from_id,
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,28 @@
+-- 種目のバリエーション（統計で親種目にまとめられる）と段階的な種目の1つ前（易しい）種目
+ALTER TABLE exercises
+    ADD COLUMN parent_id BIGINT NULL REFERENCES exercises(id) ON DELETE SET NULL,
+    ADD COLUMN progresses_from_id BIGINT NULL REFERENCES exercises(id) ON DELETE SET NULL,
+    ADD CONSTRAINT chk_exercises_parent_not_self CHECK (parent_id <> id),
+    ADD CONSTRAINT chk_exercises_progresses_from_not_self CHECK (progresses_from_id <> id);
+
+CREATE INDEX idx_exercises_parent_id ON exercises(parent_id);
+CREATE INDEX idx_exercises_progresses_from_id ON exercises(progresses_from_id);
+
+-- プリセット種目のバリエーション
+UPDATE exercises SET parent_id = parent.id
+FROM (VALUES
+    ('インクラインベンチプレス', 'ベンチプレス'),
+    ('ハンマーカール', 'ダンベルカール')
+) AS v(name, parent_name)
+JOIN exercises AS parent ON parent.name = v.parent_name AND parent.is_custom = false
+WHERE exercises.name = v.name AND exercises.is_custom = false;
+
+-- プリセット種目の段階
+UPDATE exercises SET progresses_from_id = easier.id
+FROM (VALUES
+    ('チンニング', 'ラットプルダウン'),
+    ('レッグレイズ', 'クランチ'),
+    ('アブローラー', 'プランク')
+) AS v(name, easier_name)
+JOIN exercises AS easier ON easier.name = v.easier_name AND easier.is_custom = false
+WHERE exercises.name = v.name AND exercises.is_custom = false;
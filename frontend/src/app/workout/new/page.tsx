@@ -85,7 +85,7 @@ function NewWorkoutContent() {
                   exerciseId: item.exercise_id,
                   exerciseName: item.exercise?.name,
                   setNumber: menuSets.length + 1,
-                  weight: item.target_weight?.toString() || '',
+                  weight: (item.resolved_weight ?? item.target_weight)?.toString() || '',
                   reps: item.target_reps.toString(),
                 })
               }
@@ -2,8 +2,22 @@ import { getToken } from './auth'
 
 const API_BASE_URL = process.env.NEXT_PUBLIC_API_URL || 'http://localhost:8081'
 
+// Field-level problem reported with VALIDATION_FAILED or INVALID_EXERCISE_REFERENCE
+export interface ApiErrorDetail {
+  field: string
+  reason: string
+  message?: string
+  exercise_id?: number
+}
+
 export class ApiError extends Error {
-  constructor(public status: number, message: string) {
+  constructor(
+    public status: number,
+    message: string,
+    public code?: string,
+    public requestId?: string,
+    public details: ApiErrorDetail[] = []
+  ) {
     super(message)
     this.name = 'ApiError'
   }
@@ -21,6 +35,11 @@ export async function fetchAPI<T>(
     ...options?.headers as Record<string, string>,
   }
 
+  // Let the browser set the multipart boundary for file uploads
+  if (options?.body instanceof FormData) {
+    delete headers['Content-Type']
+  }
+
   if (token) {
     headers['Authorization'] = `Bearer ${token}`
   }
@@ -32,7 +51,13 @@ export async function fetchAPI<T>(
 
   if (!response.ok) {
     const errorData = await response.json().catch(() => ({}))
-    throw new ApiError(response.status, errorData.error || `API Error: ${response.status}`)
+    throw new ApiError(
+      response.status,
+      errorData.error || `API Error: ${response.status}`,
+      errorData.code,
+      errorData.request_id || response.headers.get('X-Request-ID') || undefined,
+      errorData.details || []
+    )
   }
 
   if (response.status === 204) {
@@ -66,6 +91,7 @@ export interface User {
   email: string
   name: string
   height?: number
+  locale?: 'ja' | 'en' | null
   created_at: string
   updated_at: string
 }
@@ -78,9 +104,71 @@ export interface AuthResponse {
 export interface Exercise {
   id: number
   name: string
+  description?: string
   muscle_group: string
   is_custom: boolean
   user_id?: number
+  archived_at?: string
+  muscles?: ExerciseMuscle[]
+  equipment?: string[]
+  is_favorite?: boolean
+  last_used_on?: string
+  use_count?: number
+  instructions?: string[]
+  cues?: string[]
+  common_mistakes?: string[]
+  media?: ExerciseMedia[]
+  parent_id?: number | null
+  progresses_from_id?: number | null
+}
+
+export interface ProgressionStep extends Exercise {
+  step: number
+}
+
+export interface Progression {
+  steps: ProgressionStep[]
+  current_id: number | null
+  next: Exercise[]
+}
+
+export interface ExerciseMedia {
+  id: number
+  exercise_id: number
+  media_type: 'image' | 'video'
+  content_type: string
+  size: number
+  position: number
+  url: string
+  created_at: string
+}
+
+export interface Muscle {
+  id: number
+  key: string
+  name: string
+  parent_id: number | null
+  muscle_group: string
+  children?: Muscle[]
+}
+
+export interface ExerciseMuscle {
+  exercise_id: number
+  muscle_id: number
+  contribution: number
+  muscle?: Muscle
+}
+
+export interface ExerciseAlias {
+  id: number
+  exercise_id: number
+  user_id: number | null
+  alias: string
+  created_at: string
+}
+
+export interface ExerciseSearchResult extends Exercise {
+  score: number
 }
 
 export interface WorkoutSet {
@@ -90,6 +178,7 @@ export interface WorkoutSet {
   set_number: number
   weight: number
   reps: number
+  rpe: number | null
   exercise?: Exercise
 }
 
@@ -118,27 +207,53 @@ export const authApi = {
   login: (data: { email: string; password: string }) =>
     api.post<AuthResponse>('/api/v1/auth/login', data),
   me: () => api.get<User>('/api/v1/auth/me'),
+  updateLocale: (locale: 'ja' | 'en' | null) =>
+    api.put<User>('/api/v1/auth/me/locale', { locale }),
   deleteAccount: () => api.delete('/api/v1/auth/account'),
 }
 
 export const exerciseApi = {
   getAll: () => api.get<Exercise[]>('/api/v1/exercises'),
+  get: (id: number) => api.get<Exercise>(`/api/v1/exercises/${id}`),
   getByMuscleGroup: (muscleGroup: string) =>
     api.get<Exercise[]>(`/api/v1/exercises?muscle_group=${muscleGroup}`),
   getCustom: () => api.get<Exercise[]>('/api/v1/exercises/custom'),
-  createCustom: (data: { name: string; muscle_group: string }) =>
+  createCustom: (data: { name: string; muscle_group: string; parent_id?: number | null; progresses_from_id?: number | null }) =>
     api.post<Exercise>('/api/v1/exercises/custom', data),
-  updateCustom: (id: number, data: { name: string; muscle_group: string }) =>
+  updateCustom: (id: number, data: { name: string; muscle_group: string; parent_id?: number | null; progresses_from_id?: number | null }) =>
     api.put<Exercise>(`/api/v1/exercises/custom/${id}`, data),
   deleteCustom: (id: number) => api.delete(`/api/v1/exercises/custom/${id}`),
-  getProgress: (id: number) => api.get<ExerciseProgress[]>(`/api/v1/exercises/${id}/progress`),
+  archiveCustom: (id: number) => api.put<Exercise>(`/api/v1/exercises/custom/${id}/archive`, {}),
+  unarchiveCustom: (id: number) => api.delete<Exercise>(`/api/v1/exercises/custom/${id}/archive`),
+  uploadMedia: (id: number, file: File) => {
+    const form = new FormData()
+    form.append('file', file)
+    return fetchAPI<ExerciseMedia>(`/api/v1/exercises/custom/${id}/media`, {
+      method: 'POST',
+      body: form,
+    })
+  },
+  deleteMedia: (id: number, mediaId: number) =>
+    api.delete(`/api/v1/exercises/custom/${id}/media/${mediaId}`),
+  mergeCustom: (id: number, targetId: number) =>
+    api.post<Exercise>(`/api/v1/exercises/custom/${id}/merge-into/${targetId}`, {}),
+  getProgress: (id: number, rollUpVariations = false) =>
+    api.get<ExerciseProgress[]>(`/api/v1/exercises/${id}/progress${rollUpVariations ? '?rollup=variations' : ''}`),
+  getVariations: (id: number) => api.get<Exercise[]>(`/api/v1/exercises/${id}/variations`),
+  getProgression: (id: number) => api.get<Progression>(`/api/v1/exercises/${id}/progression`),
+  search: (q: string) =>
+    api.get<ExerciseSearchResult[]>(`/api/v1/exercises/search?q=${encodeURIComponent(q)}`),
+  getAliases: (id: number) => api.get<ExerciseAlias[]>(`/api/v1/exercises/${id}/aliases`),
+  createAlias: (id: number, alias: string) =>
+    api.post<ExerciseAlias>(`/api/v1/exercises/${id}/aliases`, { alias }),
+  deleteAlias: (aliasId: number) => api.delete(`/api/v1/exercises/aliases/${aliasId}`),
 }
 
 export const workoutApi = {
   create: (data: {
     date: string
     memo?: string
-    sets: { exercise_id: number; set_number: number; weight: number; reps: number }[]
+    sets: { exercise_id: number; set_number: number; weight: number; reps: number; rpe?: number | null }[]
   }) => api.post<Workout>('/api/v1/workouts', data),
   getList: (page = 1, perPage = 20) =>
     api.get<WorkoutListResponse>(`/api/v1/workouts?page=${page}&per_page=${perPage}`),
@@ -148,7 +263,7 @@ export const workoutApi = {
     id: number,
     data: {
       memo?: string
-      sets: { exercise_id: number; set_number: number; weight: number; reps: number }[]
+      sets: { exercise_id: number; set_number: number; weight: number; reps: number; rpe?: number | null }[]
     }
   ) => api.put<Workout>(`/api/v1/workouts/${id}`, data),
   delete: (id: number) => api.delete(`/api/v1/workouts/${id}`),
@@ -161,6 +276,9 @@ export interface MuscleGroupStat {
   muscle_group: string
   workout_count: number
   set_count: number
+  // 種目が関与する筋肉の部位に按分した値
+  weighted_set_count: number
+  weighted_volume: number
 }
 
 export interface PersonalBest {
@@ -168,17 +286,20 @@ export interface PersonalBest {
   exercise_name: string
   muscle_group: string
   max_weight: number
+  estimated_one_rep_max: number
 }
 
 export interface ExerciseProgress {
   date: string
   max_weight: number
   total_volume: number
+  estimated_one_rep_max: number
 }
 
 export const statsApi = {
   getMuscleGroupStats: () => api.get<MuscleGroupStat[]>('/api/v1/stats/muscle-groups'),
-  getPersonalBests: () => api.get<PersonalBest[]>('/api/v1/stats/personal-bests'),
+  getPersonalBests: (rollUpVariations = false) =>
+    api.get<PersonalBest[]>(`/api/v1/stats/personal-bests${rollUpVariations ? '?rollup=variations' : ''}`),
 }
 
 // メニュー関連
@@ -189,7 +310,11 @@ export interface MenuItem {
   order_number: number
   target_sets: number
   target_reps: number
+  target_type: 'fixed' | 'percent_e1rm' | 'rpe'
   target_weight?: number
+  target_percentage?: number
+  target_rpe?: number
+  resolved_weight?: number
   note?: string
   exercise?: Exercise
 }
@@ -212,7 +337,10 @@ export interface CreateMenuInput {
     order_number: number
     target_sets: number
     target_reps: number
+    target_type?: 'fixed' | 'percent_e1rm' | 'rpe'
     target_weight?: number
+    target_percentage?: number
+    target_rpe?: number
     note?: string
   }[]
 }
@@ -231,7 +359,11 @@ export interface AIGeneratedMenuItem {
   order_number: number
   target_sets: number
   target_reps: number
+  target_type: 'fixed' | 'percent_e1rm' | 'rpe'
   target_weight?: number
+  target_percentage?: number
+  target_rpe?: number
+  resolved_weight?: number
   note?: string
   exercise?: Exercise
 }